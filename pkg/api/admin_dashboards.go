@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route POST /admin/dashboards/uid/{uid}/reindex admin adminReindexDashboard
+//
+// Rebuild the index entries of a single dashboard.
+//
+// Recomputes the panel, datasource and template variable index of the dashboard and its library panel
+// connections from the stored dashboard body. Use it to repair a dashboard whose index entries have
+// drifted without rebuilding the index of every dashboard.
+//
+// Security:
+// - basic:
+//
+// Responses:
+// 200: adminReindexDashboardResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) AdminReindexDashboard(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+
+	if err := hs.LibraryPanelService.ConnectLibraryPanelsForDashboard(c.Req.Context(), c.SignedInUser, dash); err != nil {
+		return response.Error(http.StatusInternalServerError, "Error while connecting library panels", err)
+	}
+	entry := hs.dashboardIndex.update(dash)
+
+	datasources := make(map[dashboardDatasourceRef]bool)
+	for _, panel := range entry.Panels {
+		for _, ref := range panel.Datasources {
+			datasources[ref] = true
+		}
+	}
+
+	return response.JSON(http.StatusOK, dtos.DashboardReindexResult{
+		UID:               dash.UID,
+		Title:             dash.Title,
+		Version:           entry.Version,
+		Panels:            len(entry.Panels),
+		Datasources:       len(datasources),
+		TemplateVariables: len(entry.TemplateVars),
+		LibraryPanels:     true,
+	})
+}
+
+// swagger:parameters adminReindexDashboard
+type AdminReindexDashboardParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response adminReindexDashboardResponse
+type AdminReindexDashboardResponse struct {
+	// in: body
+	Body dtos.DashboardReindexResult `json:"body"`
+}
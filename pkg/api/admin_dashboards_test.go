@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestAPI_AdminReindexDashboard(t *testing.T) {
+	dash := dashboards.NewDashboardFromJson(simplejson.NewFromAny(map[string]any{
+		"uid":     "dash",
+		"title":   "Dash",
+		"version": 4,
+		"panels": []any{
+			map[string]any{"id": 1, "type": "timeseries", "datasource": map[string]any{"uid": "prom"}},
+			map[string]any{"id": 2, "type": "table", "datasource": map[string]any{"uid": "prom"}},
+		},
+		"templating": map[string]any{"list": []any{map[string]any{"name": "env"}}},
+	}))
+	dash.OrgID = 1
+
+	index := newDashboardIndex(dashboardIndexMaxEntries)
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.dashboardIndex = index
+	})
+
+	t.Run("should rebuild the index entry of the dashboard", func(t *testing.T) {
+		admin := &user.SignedInUser{UserID: 1, OrgID: 1, OrgRole: org.RoleViewer, IsGrafanaAdmin: true}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewPostRequest("/api/admin/dashboards/uid/dash/reindex", nil), admin))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardReindexResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+
+		assert.Equal(t, dtos.DashboardReindexResult{UID: "dash", Title: "Dash", Version: 4, Panels: 2, Datasources: 1, TemplateVariables: 1, LibraryPanels: true}, result)
+		assert.Contains(t, index.entries, dashboardIndexKey{1, "dash"})
+	})
+
+	t.Run("should require a server admin", func(t *testing.T) {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewPostRequest("/api/admin/dashboards/uid/dash/reindex", nil), userWithPermissions(1, nil)))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
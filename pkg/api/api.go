@@ -275,6 +275,8 @@ func (hs *HTTPServer) registerRoutes() {
 		apiRoute.Group("/org", func(orgRoute routing.RouteRegister) {
 			orgRoute.Get("/", authorize(ac.EvalPermission(ac.ActionOrgsRead)), routing.Wrap(hs.GetCurrentOrg))
 			orgRoute.Get("/quotas", authorize(ac.EvalPermission(ac.ActionOrgsQuotasRead)), routing.Wrap(hs.GetCurrentOrgQuotas))
+			orgRoute.Get("/dashboard-variables", authorize(ac.EvalPermission(ac.ActionOrgsRead)), routing.Wrap(hs.GetOrgDashboardVariables))
+			orgRoute.Get("/dashboard-version-cap", authorize(ac.EvalPermission(ac.ActionOrgsRead)), routing.Wrap(hs.GetOrgDashboardVersionCap))
 		})
 
 		if hs.Features.IsEnabledGlobally(featuremgmt.FlagStorage) {
@@ -291,6 +293,8 @@ func (hs *HTTPServer) registerRoutes() {
 			userIDScope := ac.Scope("users", "id", ac.Parameter(":userId"))
 			orgRoute.Put("/", authorize(ac.EvalPermission(ac.ActionOrgsWrite)), routing.Wrap(hs.UpdateCurrentOrg))
 			orgRoute.Put("/address", authorize(ac.EvalPermission(ac.ActionOrgsWrite)), routing.Wrap(hs.UpdateCurrentOrgAddress))
+			orgRoute.Put("/dashboard-variables", authorize(ac.EvalPermission(ac.ActionOrgsWrite)), routing.Wrap(hs.UpdateOrgDashboardVariables))
+			orgRoute.Put("/dashboard-version-cap", authorize(ac.EvalPermission(ac.ActionOrgsWrite)), routing.Wrap(hs.UpdateOrgDashboardVersionCap))
 			orgRoute.Get("/users", requestmeta.SetOwner(requestmeta.TeamAuth), authorize(ac.EvalPermission(ac.ActionOrgUsersRead)), routing.Wrap(hs.GetOrgUsersForCurrentOrg))
 			orgRoute.Get("/users/search", requestmeta.SetOwner(requestmeta.TeamAuth), authorize(ac.EvalPermission(ac.ActionOrgUsersRead)), routing.Wrap(hs.SearchOrgUsersWithPaging))
 			orgRoute.Post("/users", requestmeta.SetOwner(requestmeta.TeamAuth), authorize(ac.EvalPermission(ac.ActionOrgUsersAdd, ac.ScopeUsersAll)), quota(user.QuotaTargetSrv), quota(org.QuotaTargetSrv), routing.Wrap(hs.AddOrgUserToCurrentOrg))
@@ -435,6 +439,7 @@ func (hs *HTTPServer) registerRoutes() {
 			folderRoute.Get("/", authorize(ac.EvalPermission(dashboards.ActionFoldersRead)), routing.Wrap(hs.GetFolders))
 			folderRoute.Get("/id/:id", authorize(ac.EvalPermission(dashboards.ActionFoldersRead, idScope)), routing.Wrap(hs.GetFolderByID))
 			folderRoute.Post("/", authorize(ac.EvalPermission(dashboards.ActionFoldersCreate)), routing.Wrap(hs.CreateFolder))
+			folderRoute.Get("/structure-violations", reqOrgAdmin, routing.Wrap(hs.GetFolderStructureViolations))
 
 			folderRoute.Group("/:uid", func(folderUidRoute routing.RouteRegister) {
 				folderUidRoute.Get("/", authorize(ac.EvalPermission(dashboards.ActionFoldersRead, uidScope)), routing.Wrap(hs.GetFolderByUID))
@@ -442,6 +447,12 @@ func (hs *HTTPServer) registerRoutes() {
 				folderUidRoute.Post("/move", authorize(ac.EvalPermission(dashboards.ActionFoldersWrite, uidScope)), routing.Wrap(hs.MoveFolder))
 				folderUidRoute.Delete("/", authorize(ac.EvalPermission(dashboards.ActionFoldersDelete, uidScope)), routing.Wrap(hs.DeleteFolder))
 				folderUidRoute.Get("/counts", authorize(ac.EvalPermission(dashboards.ActionFoldersRead, uidScope)), routing.Wrap(hs.GetFolderDescendantCounts))
+				folderUidRoute.Get("/export", authorize(ac.EvalPermission(dashboards.ActionFoldersRead, uidScope)), routing.Wrap(hs.ExportFolderDashboards))
+				folderUidRoute.Post("/canonicalize", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.CanonicalizeFolderDashboards))
+				folderUidRoute.Get("/variable-drift", authorize(ac.EvalPermission(dashboards.ActionFoldersRead, uidScope)), routing.Wrap(hs.GetFolderVariableDrift))
+				folderUidRoute.Post("/variable-align", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.AlignFolderVariable))
+				folderUidRoute.Get("/home", authorize(ac.EvalPermission(dashboards.ActionFoldersRead, uidScope)), routing.Wrap(hs.GetFolderHomeDashboard))
+				folderUidRoute.Put("/home", authorize(ac.EvalPermission(dashboards.ActionFoldersWrite, uidScope)), routing.Wrap(hs.SetFolderHomeDashboard))
 
 				folderUidRoute.Group("/permissions", func(folderPermissionRoute routing.RouteRegister) {
 					folderPermissionRoute.Get("/", authorize(ac.EvalPermission(dashboards.ActionFoldersPermissionsRead, uidScope)), routing.Wrap(hs.GetFolderPermissionList))
@@ -453,11 +464,42 @@ func (hs *HTTPServer) registerRoutes() {
 		// Dashboard
 		apiRoute.Group("/dashboards", func(dashboardRoute routing.RouteRegister) {
 			dashboardRoute.Get("/uid/:uid", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboard))
+			dashboardRoute.Get("/slug/:slug", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardBySlug))
 			dashboardRoute.Delete("/uid/:uid", authorize(ac.EvalPermission(dashboards.ActionDashboardsDelete)), routing.Wrap(hs.DeleteDashboardByUID))
 			dashboardRoute.Group("/uid/:uid", func(dashUidRoute routing.RouteRegister) {
 				dashUidRoute.Get("/versions", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardVersions))
+				dashUidRoute.Delete("/versions", authorize(ac.EvalPermission(dashboards.ActionDashboardsDelete)), routing.Wrap(hs.DeleteDashboardVersions))
+				dashUidRoute.Get("/panels/:panelId", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardPanel))
+				dashUidRoute.Get("/panels/:panelId/versions", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardPanelVersions))
+				dashUidRoute.Post("/panels/:panelId/revert", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.RevertDashboardPanel))
 				dashUidRoute.Post("/restore", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.RestoreDashboardVersion))
+				dashUidRoute.Get("/changelog", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardChangelog))
+				dashUidRoute.Get("/versions/export", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.ExportDashboardVersions))
 				dashUidRoute.Get("/versions/:id", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardVersion))
+				dashUidRoute.Get("/versions/:id/dashboard", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardAtVersion))
+				dashUidRoute.Post("/versions/:id/tag", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.TagDashboardVersion))
+				dashUidRoute.Post("/versions/:id/approve", authorize(ac.EvalPermission(dashboards.ActionDashboardsPermissionsWrite)), routing.Wrap(hs.ApproveDashboardVersion))
+				dashUidRoute.Post("/versions/prune", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.PruneDashboardVersions))
+				dashUidRoute.Put("/version-cap", authorize(ac.EvalPermission(dashboards.ActionDashboardsDelete)), routing.Wrap(hs.UpdateDashboardVersionCap))
+				dashUidRoute.Put("/protected", authorize(ac.EvalPermission(dashboards.ActionDashboardsPermissionsWrite)), routing.Wrap(hs.UpdateDashboardProtection))
+				dashUidRoute.Post("/import-diff", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.CalculateDashboardImportDiff))
+				dashUidRoute.Get("/changed-since/:version", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardChangedSince))
+				dashUidRoute.Get("/diff-origin", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.CalculateDashboardOriginDiff))
+				dashUidRoute.Get("/versions/:base/diff/:new", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.CalculateDashboardVersionsDiff))
+				dashUidRoute.Get("/versions/:id/diff-current", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.CalculateDashboardCurrentDiff))
+				dashUidRoute.Get("/provisioned-diff", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.CalculateDashboardProvisionedDiff))
+				dashUidRoute.Get("/time-analysis", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardTimeAnalysis))
+				dashUidRoute.Post("/clone", authorize(ac.EvalPermission(dashboards.ActionDashboardsCreate)), routing.Wrap(hs.CloneDashboard))
+				dashUidRoute.Post("/move", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.MoveDashboard))
+				dashUidRoute.Post("/tags", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.AddDashboardTag))
+				dashUidRoute.Delete("/tags/:tag", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.RemoveDashboardTag))
+				dashUidRoute.Get("/lineage", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardLineage))
+				dashUidRoute.Get("/export", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.ExportDashboard))
+				dashUidRoute.Post("/autosave", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.AutosaveDashboard))
+				dashUidRoute.Get("/autosave", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardAutosave))
+				dashUidRoute.Post("/autosave/promote", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.PromoteDashboardAutosave))
+				dashUidRoute.Get("/dependents", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardDependents))
+				dashUidRoute.Get("/a11y", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardAccessibility))
 				dashUidRoute.Group("/permissions", func(dashboardPermissionRoute routing.RouteRegister) {
 					dashboardPermissionRoute.Get("/", authorize(ac.EvalPermission(dashboards.ActionDashboardsPermissionsRead)), routing.Wrap(hs.GetDashboardPermissionList))
 					dashboardPermissionRoute.Post("/", authorize(ac.EvalPermission(dashboards.ActionDashboardsPermissionsWrite)), routing.Wrap(hs.UpdateDashboardPermissions))
@@ -466,10 +508,30 @@ func (hs *HTTPServer) registerRoutes() {
 
 			dashboardRoute.Post("/calculate-diff", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.CalculateDashboardDiff))
 			dashboardRoute.Post("/validate", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.ValidateDashboard))
+			dashboardRoute.Post("/lint", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.LintDashboard))
+			dashboardRoute.Get("/schema", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.GetDashboardSchema))
 
 			dashboardRoute.Post("/db", authorize(ac.EvalAny(ac.EvalPermission(dashboards.ActionDashboardsCreate), ac.EvalPermission(dashboards.ActionDashboardsWrite))), routing.Wrap(hs.PostDashboard))
 			dashboardRoute.Get("/home", routing.Wrap(hs.GetHomeDashboard))
 			dashboardRoute.Get("/tags", hs.GetDashboardTags)
+			dashboardRoute.Get("/single-version", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetSingleVersionDashboards))
+			dashboardRoute.Get("/hardcoded-datasources", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetHardcodedDatasourceDashboards))
+			dashboardRoute.Get("/search-content", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.SearchDashboardContent))
+			dashboardRoute.Get("/cardinality-risk", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetCardinalityRiskDashboards))
+			dashboardRoute.Get("/deprecated-options", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDeprecatedOptionDashboards))
+			dashboardRoute.Post("/bulk-fix-time", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.BulkFixDashboardTime))
+			dashboardRoute.Post("/bulk-delete", authorize(ac.EvalPermission(dashboards.ActionDashboardsDelete)), routing.Wrap(hs.BulkDeleteDashboards))
+			dashboardRoute.Post("/bulk-restore", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.BulkRestoreDashboards))
+			dashboardRoute.Post("/bulk-tag", authorize(ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.BulkTagDashboards))
+			dashboardRoute.Post("/batch", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.BatchGetDashboards))
+			dashboardRoute.Get("/export-all", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.ExportAllDashboards))
+			dashboardRoute.Get("/modified-since", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.GetDashboardsModifiedSince))
+			dashboardRoute.Post("/import-bundle", authorize(ac.EvalPermission(dashboards.ActionDashboardsCreate)), routing.Wrap(hs.ImportDashboardBundle))
+			dashboardRoute.Post("/permissions/check", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), routing.Wrap(hs.CheckDashboardPermissions))
+			dashboardRoute.Get("/trash", authorize(ac.EvalPermission(dashboards.ActionDashboardsDelete)), routing.Wrap(hs.GetDashboardTrash))
+			dashboardRoute.Post("/trash/:uid/restore", authorize(ac.EvalPermission(dashboards.ActionDashboardsCreate)), routing.Wrap(hs.RestoreDeletedDashboard))
+			dashboardRoute.Delete("/trash/:uid", authorize(ac.EvalPermission(dashboards.ActionDashboardsDelete)), routing.Wrap(hs.DeleteTrashedDashboard))
+			dashboardRoute.Get("/by-editor-team/:teamId", authorize(ac.EvalAll(ac.EvalPermission(dashboards.ActionDashboardsRead), ac.EvalPermission(ac.ActionTeamsRead, ac.Scope("teams", "id", ac.Parameter(":teamId"))))), routing.Wrap(hs.GetDashboardsByEditorTeam))
 
 			// Deprecated: used to convert internal IDs to UIDs
 			dashboardRoute.Get("/ids/:ids", authorize(ac.EvalPermission(dashboards.ActionDashboardsRead)), hs.GetDashboardUIDs)
@@ -579,6 +641,7 @@ func (hs *HTTPServer) registerRoutes() {
 		adminRoute.Get("/settings-verbose", authorize(ac.EvalPermission(ac.ActionSettingsRead)), routing.Wrap(hs.AdminGetVerboseSettings))
 		adminRoute.Get("/stats", authorize(ac.EvalPermission(ac.ActionServerStatsRead)), routing.Wrap(hs.AdminGetStats))
 		adminRoute.Post("/pause-all-alerts", reqGrafanaAdmin, routing.Wrap(hs.PauseAllAlerts(setting.AlertingEnabled)))
+		adminRoute.Post("/dashboards/uid/:uid/reindex", reqGrafanaAdmin, routing.Wrap(hs.AdminReindexDashboard))
 
 		adminRoute.Post("/encryption/rotate-data-keys", reqGrafanaAdmin, routing.Wrap(hs.AdminRotateDataEncryptionKeys))
 		adminRoute.Post("/encryption/reencrypt-data-keys", reqGrafanaAdmin, routing.Wrap(hs.AdminReEncryptEncryptionKeys))
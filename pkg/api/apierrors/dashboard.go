@@ -11,9 +11,13 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
 	"github.com/grafana/grafana/pkg/util"
+	"github.com/grafana/grafana/pkg/util/errutil"
 )
 
-// ToDashboardErrorResponse returns a different response status according to the dashboard error type
+var errSaveDashboard = errutil.Internal("dashboards.saveFailed", errutil.WithPublicMessage("Failed to save dashboard"))
+
+// ToDashboardErrorResponse returns a different response status according to the dashboard error type.
+// The body has a messageId identifying the error, e.g. dashboards.nameExistsInFolder.
 func ToDashboardErrorResponse(ctx context.Context, pluginStore pluginstore.Store, err error) response.Response {
 	var dashboardErr dashboards.DashboardErr
 	if ok := errors.As(err, &dashboardErr); ok {
@@ -27,12 +31,12 @@ func ToDashboardErrorResponse(ctx context.Context, pluginStore pluginstore.Store
 	}
 
 	if errors.Is(err, dashboards.ErrFolderNotFound) {
-		return response.Error(http.StatusBadRequest, err.Error(), nil)
+		return response.JSON(http.StatusBadRequest, util.DynMap{"message": err.Error(), "messageId": "dashboards.folderNotFound"})
 	}
 
 	var validationErr alerting.ValidationError
 	if ok := errors.As(err, &validationErr); ok {
-		return response.Error(http.StatusUnprocessableEntity, validationErr.Error(), err)
+		return response.JSON(http.StatusUnprocessableEntity, util.DynMap{"message": validationErr.Error(), "messageId": "dashboards.alertValidationFailed"})
 	}
 
 	var pluginErr dashboards.UpdatePluginDashboardError
@@ -42,8 +46,8 @@ func ToDashboardErrorResponse(ctx context.Context, pluginStore pluginstore.Store
 		if plugin, exists := pluginStore.Plugin(ctx, pluginErr.PluginId); exists {
 			message = fmt.Sprintf("The dashboard belongs to plugin %s.", plugin.Name)
 		}
-		return response.JSON(http.StatusPreconditionFailed, util.DynMap{"status": "plugin-dashboard", "message": message})
+		return response.JSON(http.StatusPreconditionFailed, util.DynMap{"status": "plugin-dashboard", "message": message, "messageId": "dashboards.pluginDashboard"})
 	}
 
-	return response.Error(http.StatusInternalServerError, "Failed to save dashboard", err)
+	return response.Err(errSaveDashboard.Errorf("failed to save dashboard: %w", err))
 }
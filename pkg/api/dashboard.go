@@ -2,14 +2,18 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/apierrors"
 	"github.com/grafana/grafana/pkg/api/dtos"
@@ -18,14 +22,21 @@ import (
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	"github.com/grafana/grafana/pkg/kinds/dashboard"
+	"github.com/grafana/grafana/pkg/plugins"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/alerting"
 	"github.com/grafana/grafana/pkg/services/auth/identity"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboardaudit"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardtrash"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/live/features"
 	"github.com/grafana/grafana/pkg/services/org"
 	pref "github.com/grafana/grafana/pkg/services/preference"
 	publicdashboardModels "github.com/grafana/grafana/pkg/services/publicdashboards/models"
@@ -73,26 +84,82 @@ func dashboardGuardianResponse(err error) response.Response {
 //
 // Will return the dashboard given the dashboard unique identifier (uid).
 //
+// The meta includes when the dashboard was created and last updated, and the logins of the users who did
+// it. Like in the versions of the dashboard, users that can't be found are shown as Anonymous.
+//
+// The response carries an ETag that changes whenever the dashboard is saved or anything else in the response
+// changes, such as the permissions or stars in the meta. Requests sending it back in If-None-Match get a 304
+// without body while the response is unchanged. 304 responses don't count as views.
+//
+// When a version of the dashboard was approved, the meta holds the approved version and divergedFromApproved
+// tells whether the dashboard has been saved since, to show that it has unapproved changes.
+//
+// For a provisioned dashboard, provisioningDrift in the meta tells whether the stored dashboard differs
+// from its provisioning file. Only content hashes are compared, use the provisioned diff to see the changes.
+//
+// The fields query parameter limits the response to the selected parts, e.g. fields=meta returns the
+// permissions and folder of the dashboard without its panels.
+//
+// The jsonPath query parameter returns only the value at the given path of the dashboard, e.g.
+// jsonPath=templating.list or jsonPath=panels[0].datasource. Paths that don't exist return a 404.
+//
+// Every request counts as a view of the dashboard. With withUsage=true the meta includes the number of views
+// before the request, the time of the last one and the number of stars of the dashboard.
+//
+// With withStats=true the meta includes the number of panels, the number of panels using each data source
+// type and the names of the template variables. References without a data source type, such as legacy data
+// source names, are counted under unknown.
+//
+// With redactNoAccess=true, panels using a data source the signed in user can't query are replaced by a text
+// panel saying so, keeping their id, title and position. Their ids are listed in the redactedPanels meta.
+//
+// With explainPermissions=true the meta lists the permissions of the signed in user granting each of the
+// canSave, canEdit, canAdmin and canDelete flags, and whether each was given on the dashboard itself,
+// inherited from one of its folders or given on all dashboards or folders by a wildcard.
+//
+// With export=true only the dashboard is returned, in the same portable form as the export endpoint: the
+// internal id and version are removed and data sources are replaced by import inputs listed in __inputs.
+// Exports don't count as views.
+//
+// Query parameters named var-<name>, e.g. var-env=prod, set the current value of the template variable
+// with that name in the returned dashboard. Repeat the parameter to select several values. Parameters not
+// matching a variable are ignored.
+//
+// With format=yaml, or an Accept header asking for application/yaml, the response is written as YAML with
+// the keys in the same order as in JSON.
+//
+// To read a dashboard right after saving it, send the consistencyToken of the save response in the
+// X-Grafana-Consistency-Token header. The request then waits until the saved version is visible, for
+// deployments where reads can lag behind writes, and returns a 503 if it doesn't become visible in time.
+//
+// Produces:
+// - application/json
+// - application/yaml
+//
 // Responses:
 // 200: dashboardResponse
+// 304: notModifiedResponse
+// 400: badRequestError
 // 401: unauthorisedError
 // 403: forbiddenError
 // 404: notFoundError
 // 500: internalServerError
+// 503: serviceUnavailableError
 func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response {
 	uid := web.Params(c.Req)[":uid"]
-	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, uid)
+	dash, rsp := hs.getConsistentDashboard(c, uid)
 	if rsp != nil {
 		return rsp
 	}
 
 	var (
 		publicDashboardEnabled = false
+		publicDashboardUID     string
 		err                    error
 	)
 
 	// If public dashboards is enabled and we have a public dashboard, update meta
-	// values
+	// values. The public dashboard uid is only exposed to users allowed to manage it.
 	if hs.Features.IsEnabledGlobally(featuremgmt.FlagPublicDashboards) {
 		publicDashboard, err := hs.PublicDashboardsApi.PublicDashboardService.FindByDashboardUid(c.Req.Context(), c.SignedInUser.GetOrgID(), dash.UID)
 		if err != nil && !errors.Is(err, publicdashboardModels.ErrPublicDashboardNotFound) {
@@ -101,6 +168,15 @@ func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response
 
 		if publicDashboard != nil {
 			publicDashboardEnabled = publicDashboard.IsEnabled
+
+			evaluator := accesscontrol.EvalPermission(dashboards.ActionDashboardsPublicWrite, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(dash.UID))
+			canManage, err := hs.AccessControl.Evaluate(c.Req.Context(), c.SignedInUser, evaluator)
+			if err != nil {
+				return response.Error(http.StatusInternalServerError, "Error while checking public dashboard permissions", err)
+			}
+			if canManage {
+				publicDashboardUID = publicDashboard.Uid
+			}
 		}
 	}
 
@@ -125,6 +201,102 @@ func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response
 	if canView, err := guardian.CanView(); err != nil || !canView {
 		return dashboardGuardianResponse(err)
 	}
+
+	if c.QueryBool("export") {
+		return hs.exportDashboard(c, dash)
+	}
+
+	meta, rsp := hs.getDashboardMeta(c, dash, guardian)
+	if rsp != nil {
+		return rsp
+	}
+	meta.PublicDashboardEnabled = publicDashboardEnabled
+	meta.PublicDashboardUID = publicDashboardUID
+
+	// make sure db version is in sync with json model version
+	dash.Data.Set("version", dash.Version)
+
+	if err := filterPanelDescriptions(dash.Data, c.Query("descriptions")); err != nil {
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+
+	meta.InjectedVariables, err = hs.injectOrgDashboardVariables(c.Req.Context(), dash.OrgID, dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get org dashboard variables", err)
+	}
+	applyTemplateVariableOverrides(dash.Data, c.Req.URL.Query())
+	meta.MaxVersions, err = hs.dashboardVersionCap(c.Req.Context(), dash.OrgID, dash.UID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard version cap", err)
+	}
+	meta.Protected, err = hs.isDashboardProtected(c.Req.Context(), dash.OrgID, dash.UID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard protection", err)
+	}
+	meta.ApprovedVersion, err = hs.dashboardApprovedVersion(c.Req.Context(), dash.OrgID, dash.UID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get approved dashboard version", err)
+	}
+	meta.DivergedFromApproved = meta.ApprovedVersion != 0 && meta.ApprovedVersion != dash.Version
+	if c.QueryBool("withUsage") {
+		usage, _, err := hs.usageStore.Get(c.Req.Context(), dash.OrgID, dash.UID)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to get dashboard usage", err)
+		}
+		stars, err := hs.starService.CountByDashboard(c.Req.Context(), &star.CountDashboardStarsQuery{DashboardID: dash.ID})
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to count dashboard stars", err)
+		}
+		meta.ViewCount = &usage.Views
+		if !usage.LastViewed.IsZero() {
+			meta.LastViewedAt = &usage.LastViewed
+		}
+		meta.StarCount = &stars
+	}
+	if c.QueryBool("redactNoAccess") {
+		meta.RedactedPanels, err = hs.redactNoAccessPanels(c, dash.Data)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to check data source permissions", err)
+		}
+	}
+	if c.QueryBool("withStats") {
+		setDashboardStats(&meta, dash.Data)
+	}
+	if c.QueryBool("explainPermissions") {
+		meta.EffectivePermissions = hs.explainDashboardPermissions(c.SignedInUser, dash, meta)
+	}
+
+	dto := dtos.DashboardFullWithMeta{
+		Dashboard: dash.Data,
+		Meta:      meta,
+	}
+
+	c.TimeRequest(metrics.MApiDashboardGet)
+	var body any = projectDashboard(dto, c.Query("fields"))
+	if jsonPath := c.Query("jsonPath"); jsonPath != "" {
+		body, err = evalDashboardJSONPath(dash.Data, jsonPath)
+		if err != nil {
+			return response.Error(http.StatusNotFound, err.Error(), err)
+		}
+	}
+
+	etag, err := dashboardResponseETag(dash, body)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to compute dashboard etag", err)
+	}
+	if etagMatches(c.Req.Header.Get("If-None-Match"), etag) {
+		return response.Empty(http.StatusNotModified).SetHeader("ETag", etag)
+	}
+
+	if err := hs.usageStore.RecordView(c.Req.Context(), dash.OrgID, dash.UID, time.Now()); err != nil {
+		hs.log.Warn("Failed to record dashboard view", "dashboard", dash.UID, "error", err)
+	}
+	return dashboardResponse(c, body).SetHeader("ETag", etag)
+}
+
+// getDashboardMeta returns the meta of a dashboard the signed in user can
+// view: its permissions, folder and provisioning.
+func (hs *HTTPServer) getDashboardMeta(c *contextmodel.ReqContext, dash *dashboards.Dashboard, guardian guardian.DashboardGuardian) (dtos.DashboardMeta, response.Response) {
 	canEdit, _ := guardian.CanEdit()
 	canSave, _ := guardian.CanSave()
 	canAdmin, _ := guardian.CanAdmin()
@@ -132,15 +304,17 @@ func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response
 
 	isStarred, err := hs.isDashboardStarredByUser(c, dash.ID)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Error while checking if dashboard was starred by user", err)
+		return dtos.DashboardMeta{}, response.Error(http.StatusInternalServerError, "Error while checking if dashboard was starred by user", err)
 	}
-	// Finding creator and last updater of the dashboard
+	// Finding creator and last updater of the dashboard, users that can't be
+	// found are shown as anonymous like in the versions of the dashboard
 	updater, creator := anonString, anonString
-	if dash.UpdatedBy > 0 {
-		updater = hs.getUserLogin(c.Req.Context(), dash.UpdatedBy)
+	logins := hs.getUserLogins(c.Req.Context(), []int64{dash.UpdatedBy, dash.CreatedBy})
+	if login, ok := logins[dash.UpdatedBy]; ok {
+		updater = login
 	}
-	if dash.CreatedBy > 0 {
-		creator = hs.getUserLogin(c.Req.Context(), dash.CreatedBy)
+	if login, ok := logins[dash.CreatedBy]; ok {
+		creator = login
 	}
 
 	annotationPermissions := &dtos.AnnotationPermission{}
@@ -166,8 +340,8 @@ func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response
 		FolderId:               dash.FolderID, // nolint:staticcheck
 		Url:                    dash.GetURL(),
 		FolderTitle:            "General",
+		FolderPath:             []dtos.FolderPathItem{},
 		AnnotationsPermissions: annotationPermissions,
-		PublicDashboardEnabled: publicDashboardEnabled,
 	}
 
 	// lookup folder title
@@ -178,18 +352,27 @@ func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response
 		queryResult, err := hs.DashboardService.GetDashboard(c.Req.Context(), &query)
 		if err != nil {
 			if errors.Is(err, dashboards.ErrFolderNotFound) {
-				return response.Error(http.StatusNotFound, "Folder not found", err)
+				return meta, response.Error(http.StatusNotFound, "Folder not found", err)
 			}
-			return response.Error(http.StatusInternalServerError, "Dashboard folder could not be read", err)
+			return meta, response.Error(http.StatusInternalServerError, "Dashboard folder could not be read", err)
 		}
 		meta.FolderUid = queryResult.UID
 		meta.FolderTitle = queryResult.Title
 		meta.FolderUrl = queryResult.GetURL()
+
+		parents, err := hs.folderService.GetParents(c.Req.Context(), folder.GetParentsQuery{UID: queryResult.UID, OrgID: c.SignedInUser.GetOrgID()})
+		if err != nil {
+			return meta, response.Error(http.StatusInternalServerError, "Dashboard folder path could not be read", err)
+		}
+		for _, parent := range parents {
+			meta.FolderPath = append(meta.FolderPath, dtos.FolderPathItem{UID: parent.UID, Title: parent.Title})
+		}
+		meta.FolderPath = append(meta.FolderPath, dtos.FolderPathItem{UID: queryResult.UID, Title: queryResult.Title})
 	}
 
 	provisioningData, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(c.Req.Context(), dash.ID)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned", err)
+		return meta, response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned", err)
 	}
 
 	if provisioningData != nil {
@@ -207,18 +390,55 @@ func (hs *HTTPServer) GetDashboard(c *contextmodel.ReqContext) response.Response
 			// is for better UX, showing in Save/Delete dialogs and so it won't break anything if it is empty.
 			hs.log.Warn("Failed to create ProvisionedExternalId", "err", err)
 		}
+
+		meta.ProvisioningDrift, err = hs.provisioningDrift(dash, provisioningData)
+		if err != nil {
+			// the file may have been removed since it was provisioned, which
+			// shouldn't prevent loading the dashboard
+			c.Logger.Warn("Failed to compare dashboard with its provisioned file", "dashboard", dash.UID, "error", err)
+		}
 	}
 
-	// make sure db version is in sync with json model version
-	dash.Data.Set("version", dash.Version)
+	return meta, nil
+}
 
-	dto := dtos.DashboardFullWithMeta{
-		Dashboard: dash.Data,
-		Meta:      meta,
+// dashboardETag identifies a saved state of the dashboard, as matched by
+// If-Match on save. It changes with every save, as each save bumps the version
+// and the updated timestamp.
+func dashboardETag(dash *dashboards.Dashboard) string {
+	return fmt.Sprintf(`W/"%s"`, dashboardETagState(dash))
+}
+
+func dashboardETagState(dash *dashboards.Dashboard) string {
+	return fmt.Sprintf("%d-%d", dash.Version, dash.Updated.UnixMilli())
+}
+
+// dashboardResponseETag identifies a response of GetDashboard. Besides the
+// saved state of the dashboard it covers the returned body, so it changes
+// with the meta of the dashboard too, e.g. its permissions, stars and view
+// counts or the injected org variables.
+func dashboardResponseETag(dash *dashboards.Dashboard, body any) (string, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", err
 	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf(`W/"%s-%s"`, dashboardETagState(dash), hex.EncodeToString(sum[:8])), nil
+}
 
-	c.TimeRequest(metrics.MApiDashboardGet)
-	return response.JSON(http.StatusOK, dto)
+// etagMatches reports whether the If-None-Match header matches the etag,
+// using the weak comparison of RFC 9110.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
 }
 
 func (hs *HTTPServer) getAnnotationPermissionsByScope(c *contextmodel.ReqContext, actions *dtos.AnnotationActions, scope string) {
@@ -252,6 +472,33 @@ func (hs *HTTPServer) getUserLogin(ctx context.Context, userID int64) string {
 	return user.Login
 }
 
+// getUserLogins looks up the logins of the given users at once. Users that
+// can't be found are missing from the returned map.
+func (hs *HTTPServer) getUserLogins(ctx context.Context, userIDs []int64) map[int64]string {
+	ids := make([]int64, 0, len(userIDs))
+	seen := make(map[int64]bool, len(userIDs))
+	for _, id := range userIDs {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	logins := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return logins
+	}
+	users, err := hs.userService.GetByIDs(ctx, &user.GetUsersByIDsQuery{IDs: ids})
+	if err != nil {
+		hs.log.Debug("Failed to get users", "error", err)
+		return logins
+	}
+	for _, usr := range users {
+		logins[usr.ID] = usr.Login
+	}
+	return logins
+}
+
 func (hs *HTTPServer) getDashboardHelper(ctx context.Context, orgID int64, id int64, uid string) (*dashboards.Dashboard, response.Response) {
 	var query dashboards.GetDashboardQuery
 
@@ -275,11 +522,14 @@ func (hs *HTTPServer) getDashboardHelper(ctx context.Context, orgID int64, id in
 //
 // Will delete the dashboard given the specified unique identifier (uid).
 //
+// A protected dashboard is only deleted with `confirm=true`, otherwise a 409 is returned.
+//
 // Responses:
 // 200: deleteDashboardResponse
 // 401: unauthorisedError
 // 403: forbiddenError
 // 404: notFoundError
+// 409: conflictError
 // 500: internalServerError
 func (hs *HTTPServer) DeleteDashboardByUID(c *contextmodel.ReqContext) response.Response {
 	return hs.deleteDashboard(c)
@@ -298,28 +548,17 @@ func (hs *HTTPServer) deleteDashboard(c *contextmodel.ReqContext) response.Respo
 	if canDelete, err := guardian.CanDelete(); err != nil || !canDelete {
 		return dashboardGuardianResponse(err)
 	}
-
-	namespaceID, userIDStr := c.SignedInUser.GetNamespacedID()
-
-	// disconnect all library elements for this dashboard
-	err = hs.LibraryElementService.DisconnectElementsFromDashboard(c.Req.Context(), dash.ID)
-	if err != nil {
-		hs.log.Error(
-			"Failed to disconnect library elements",
-			"dashboard", dash.ID,
-			"namespaceID", namespaceID,
-			"user", userIDStr,
-			"error", err)
-	}
-
-	// deletes all related public dashboard entities
-	err = hs.PublicDashboardsApi.PublicDashboardService.DeleteByDashboard(c.Req.Context(), dash)
-	if err != nil {
-		hs.log.Error("Failed to delete public dashboard")
+	if !c.QueryBool("confirm") {
+		protected, err := hs.isDashboardProtected(c.Req.Context(), dash.OrgID, dash.UID)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to get dashboard protection", err)
+		}
+		if protected {
+			return response.Error(http.StatusConflict, "Dashboard is protected, delete it with confirm=true", nil)
+		}
 	}
 
-	err = hs.DashboardService.DeleteDashboard(c.Req.Context(), dash.ID, c.SignedInUser.GetOrgID())
-	if err != nil {
+	if err := hs.removeDashboard(c, dash); err != nil {
 		var dashboardErr dashboards.DashboardErr
 		if ok := errors.As(err, &dashboardErr); ok {
 			if errors.Is(err, dashboards.ErrDashboardCannotDeleteProvisionedDashboard) {
@@ -340,6 +579,7 @@ func (hs *HTTPServer) deleteDashboard(c *contextmodel.ReqContext) response.Respo
 			hs.log.Error("Failed to broadcast delete info", "dashboard", dash.UID, "error", err)
 		}
 	}
+	hs.publishDashboardChange(c, dash, true)
 	return response.JSON(http.StatusOK, util.DynMap{
 		"title":   dash.Title,
 		"message": fmt.Sprintf("Dashboard %s deleted", dash.Title),
@@ -347,12 +587,144 @@ func (hs *HTTPServer) deleteDashboard(c *contextmodel.ReqContext) response.Respo
 	})
 }
 
+// removeDashboard deletes the dashboard together with the entities related to
+// it, keeping a copy in the trash when it is enabled. The caller is
+// responsible for checking that the signed in user may delete the dashboard.
+func (hs *HTTPServer) removeDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard) error {
+	namespaceID, userIDStr := c.SignedInUser.GetNamespacedID()
+
+	trashed := hs.trashStore.Enabled() && dash.Data != nil
+	if trashed {
+		entry := &dashboardtrash.Entry{
+			UID:       dash.UID,
+			Title:     dash.Title,
+			FolderUID: dash.FolderUID,
+			Data:      dash.Data,
+			Deleted:   time.Now(),
+		}
+		if namespaceID == identity.NamespaceUser || namespaceID == identity.NamespaceServiceAccount {
+			entry.DeletedBy, _ = identity.IntIdentifier(namespaceID, userIDStr)
+		}
+		if err := hs.trashStore.Add(c.Req.Context(), dash.OrgID, entry); err != nil {
+			return err
+		}
+	}
+
+	// disconnect all library elements for this dashboard
+	err := hs.LibraryElementService.DisconnectElementsFromDashboard(c.Req.Context(), dash.ID)
+	if err != nil {
+		hs.log.Error(
+			"Failed to disconnect library elements",
+			"dashboard", dash.ID,
+			"namespaceID", namespaceID,
+			"user", userIDStr,
+			"error", err)
+	}
+
+	// deletes all related public dashboard entities
+	err = hs.PublicDashboardsApi.PublicDashboardService.DeleteByDashboard(c.Req.Context(), dash)
+	if err != nil {
+		hs.log.Error("Failed to delete public dashboard")
+	}
+
+	if err := hs.DashboardService.DeleteDashboard(c.Req.Context(), dash.ID, c.SignedInUser.GetOrgID()); err != nil {
+		if trashed {
+			if err := hs.trashStore.Delete(c.Req.Context(), dash.OrgID, dash.UID); err != nil {
+				hs.log.Warn("Failed to remove dashboard from trash", "dashboard", dash.UID, "error", err)
+			}
+		}
+		return err
+	}
+	hs.dashboardIndex.remove(dash.OrgID, dash.UID)
+	hs.auditDashboard(c, dashboardaudit.ActionDeleted, dash, dash.Version, 0)
+	if err := hs.lineageStore.Delete(c.Req.Context(), dash.OrgID, dash.UID); err != nil {
+		hs.log.Warn("Failed to delete dashboard lineage", "dashboard", dash.UID, "error", err)
+	}
+	if err := hs.draftStore.DeleteDashboard(c.Req.Context(), dash.OrgID, dash.UID); err != nil {
+		hs.log.Warn("Failed to delete dashboard autosaves", "dashboard", dash.UID, "error", err)
+	}
+	if err := hs.usageStore.Delete(c.Req.Context(), dash.OrgID, dash.UID); err != nil {
+		hs.log.Warn("Failed to delete dashboard usage", "dashboard", dash.UID, "error", err)
+	}
+	return nil
+}
+
+// publishDashboardChange tells the subscribers of the org wide dashboard
+// changes channel that the signed in user saved or deleted the dashboard.
+func (hs *HTTPServer) publishDashboardChange(c *contextmodel.ReqContext, dash *dashboards.Dashboard, deleted bool) {
+	if hs.Live == nil {
+		return
+	}
+
+	event := features.DashboardChangeEvent{
+		OrgID:     dash.OrgID,
+		UID:       dash.UID,
+		FolderUID: dash.FolderUID,
+	}
+	switch {
+	case deleted:
+		event.Action = features.ActionDeleted
+		event.PreviousVersion = dash.Version
+	case dash.Version == 1:
+		event.Action = features.ActionCreated
+		event.Version = dash.Version
+	default:
+		// every save bumps the version by one
+		event.Action = features.ActionUpdated
+		event.Version = dash.Version
+		event.PreviousVersion = dash.Version - 1
+	}
+	if namespaceID, userIDStr := c.SignedInUser.GetNamespacedID(); namespaceID == identity.NamespaceUser || namespaceID == identity.NamespaceServiceAccount {
+		event.UserID, _ = identity.IntIdentifier(namespaceID, userIDStr)
+	}
+
+	if err := hs.Live.GrafanaScope.Dashboards.DashboardChanged(event); err != nil {
+		hs.log.Warn("Unable to broadcast dashboard change", "dashboard", dash.UID, "action", event.Action, "error", err)
+	}
+}
+
 // swagger:route POST /dashboards/db dashboards postDashboard
 //
 // Create / Update dashboard
 //
 // Creates a new dashboard or updates an existing dashboard.
 //
+// With `copy=true` (or `saveAsCopy` in the body) the dashboard is always saved as a new dashboard: its id,
+// uid and version are cleared, and " Copy" is appended to its title when the title is already taken in
+// the target folder. The user needs permission to create dashboards in the target folder.
+//
+// Saving a dashboard with the same content, folder and version as the stored one doesn't add a version:
+// the stored version is returned with unchanged set. Use `force=true` to save a new version anyway.
+//
+// An If-Match header with the ETag returned by the get dashboard endpoint, or with a version number, saves the
+// dashboard only when the stored dashboard still matches it. The version in the body is then ignored. On
+// mismatch a 412 is returned with the current version.
+//
+// With overwrite set, an existing dashboard is saved to the folder in the body even when it is stored in
+// another folder. Set allowFolderChange to false to fail such saves with a 412 folder-changed error
+// instead of moving the dashboard.
+//
+// Library panels connected to the dashboard that the saved dashboard no longer uses are disconnected from
+// it. The response lists them as libraryPanelDisconnected warnings.
+//
+// With `summarizeChanges=true` the response of an update counts the panels and variables the save added,
+// removed or modified compared to the previous version.
+//
+// The body can also be sent as YAML with Content-Type application/yaml.
+//
+// The consistencyToken of the response can be sent in the X-Grafana-Consistency-Token header of a following get
+// dashboard request to make sure it returns at least the saved version.
+//
+// Dashboard mutators registered on the server may change the dashboard before it is saved. When one rejects
+// the dashboard a 422 is returned with status mutation-rejected, the name of the mutator and its reason.
+//
+// Error responses have a messageId identifying the error, e.g. dashboards.nameExistsInFolder, for clients to
+// branch on instead of the message.
+//
+// Consumes:
+// - application/json
+// - application/yaml
+//
 // Responses:
 // 200: postDashboardResponse
 // 400: badRequestError
@@ -364,13 +736,70 @@ func (hs *HTTPServer) deleteDashboard(c *contextmodel.ReqContext) response.Respo
 // 500: internalServerError
 func (hs *HTTPServer) PostDashboard(c *contextmodel.ReqContext) response.Response {
 	cmd := dashboards.SaveDashboardCommand{}
+	if isYAMLRequest(c.Req) {
+		if err := yamlBodyToJSON(c.Req); err != nil {
+			return response.Error(http.StatusBadRequest, "bad request data", err)
+		}
+	}
 	if err := web.Bind(c.Req, &cmd); err != nil {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
-	return hs.postDashboard(c, cmd)
+	if c.QueryBool("copy") {
+		cmd.SaveAsCopy = true
+	}
+	// mutate before looking for changes, the mutated dashboard is the one
+	// that would be stored
+	if cmd.Dashboard != nil {
+		if rsp := hs.mutateDashboard(c, cmd.Dashboard); rsp != nil {
+			return rsp
+		}
+	}
+	if rsp := hs.applyIfMatch(c, &cmd); rsp != nil {
+		return rsp
+	}
+	if !c.QueryBool("force") {
+		if rsp := hs.unchangedDashboardResponse(c, cmd); rsp != nil {
+			return rsp
+		}
+	}
+	result, rsp := hs.storeDashboard(c, cmd)
+	if rsp != nil {
+		return rsp
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// dashboardVersionSource tells whether a dashboard is saved from the UI,
+// which is signed in with a session, or by an API client.
+func dashboardVersionSource(c *contextmodel.ReqContext) string {
+	if c.UserToken != nil {
+		return dashver.SourceUI
+	}
+	return dashver.SourceAPI
 }
 
 func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.SaveDashboardCommand) response.Response {
+	result, rsp := hs.saveDashboard(c, cmd)
+	if rsp != nil {
+		return rsp
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// saveDashboard runs the registered mutators over the dashboard and saves it.
+// It returns the body of the save response, or the response to return when
+// the dashboard wasn't saved.
+func (hs *HTTPServer) saveDashboard(c *contextmodel.ReqContext, cmd dashboards.SaveDashboardCommand) (util.DynMap, response.Response) {
+	if cmd.Dashboard != nil {
+		if rsp := hs.mutateDashboard(c, cmd.Dashboard); rsp != nil {
+			return nil, rsp
+		}
+	}
+	return hs.storeDashboard(c, cmd)
+}
+
+// storeDashboard saves the dashboard as given, without running the mutators.
+func (hs *HTTPServer) storeDashboard(c *contextmodel.ReqContext, cmd dashboards.SaveDashboardCommand) (util.DynMap, response.Response) {
 	ctx := c.Req.Context()
 	var err error
 
@@ -387,16 +816,24 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 
 	cmd.OrgID = c.SignedInUser.GetOrgID()
 	cmd.UserID = userID
+	if cmd.SaveAsCopy {
+		cmd.Dashboard.Set("id", nil)
+		cmd.Dashboard.Del("uid")
+		cmd.Dashboard.Del("version")
+		cmd.Overwrite = false
+	}
 
 	dash := cmd.GetDashboardModel()
+	cleanPanelDescriptionSources(dash.Data)
+	stripOrgDashboardVariables(dash.Data)
 	newDashboard := dash.ID == 0
 	if newDashboard {
 		limitReached, err := hs.QuotaService.QuotaReached(c, dashboards.QuotaTargetSrv)
 		if err != nil {
-			return response.Error(http.StatusInternalServerError, "Failed to get quota", err)
+			return nil, response.Error(http.StatusInternalServerError, "Failed to get quota", err)
 		}
 		if limitReached {
-			return response.Error(http.StatusForbidden, "Quota reached", nil)
+			return nil, response.Error(http.StatusForbidden, "Quota reached", nil)
 		}
 	}
 
@@ -404,13 +841,13 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 	if dash.ID != 0 {
 		data, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(c.Req.Context(), dash.ID)
 		if err != nil {
-			return response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned using ID", err)
+			return nil, response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned using ID", err)
 		}
 		provisioningData = data
 	} else if dash.UID != "" {
 		data, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardUID(c.Req.Context(), dash.OrgID, dash.UID)
 		if err != nil && !errors.Is(err, dashboards.ErrProvisionedDashboardNotFound) && !errors.Is(err, dashboards.ErrDashboardNotFound) {
-			return response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned", err)
+			return nil, response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned", err)
 		}
 		provisioningData = data
 	}
@@ -426,9 +863,18 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 		OrgID:     c.SignedInUser.GetOrgID(),
 		User:      c.SignedInUser,
 		Overwrite: cmd.Overwrite,
+		Source:    dashboardVersionSource(c),
+
+		AllowFolderChange: cmd.AllowFolderChange,
 	}
 
 	dashboard, err := hs.DashboardService.SaveDashboard(alerting.WithUAEnabled(ctx, hs.Cfg.UnifiedAlerting.IsEnabled()), dashItem, allowUiUpdate)
+	if cmd.SaveAsCopy && errors.Is(err, dashboards.ErrDashboardWithSameNameInFolderExists) {
+		dash.Title += " Copy"
+		dash.Data.Set("title", dash.Title)
+		dash.UpdateSlug()
+		dashboard, err = hs.DashboardService.SaveDashboard(alerting.WithUAEnabled(ctx, hs.Cfg.UnifiedAlerting.IsEnabled()), dashItem, allowUiUpdate)
+	}
 
 	if hs.Live != nil {
 		// Tell everyone listening that the dashboard changed
@@ -438,7 +884,7 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 
 		userDTODisplay, err := user.NewUserDisplayDTOFromRequester(c.SignedInUser)
 		if err != nil {
-			return response.Error(http.StatusInternalServerError, "Error while parsing the user DTO model", err)
+			return nil, response.Error(http.StatusInternalServerError, "Error while parsing the user DTO model", err)
 		}
 
 		// This will broadcast all save requests only if a `gitops` observer exists.
@@ -448,7 +894,7 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 
 		// When an error exists, but the value broadcast to a gitops listener return 202
 		if liveerr == nil && err != nil && channel.HasGitOpsObserver(c.SignedInUser.GetOrgID()) {
-			return response.JSON(http.StatusAccepted, util.DynMap{
+			return nil, response.JSON(http.StatusAccepted, util.DynMap{
 				"status":  "pending",
 				"message": "changes were broadcast to the gitops listener",
 			})
@@ -460,7 +906,7 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 	}
 
 	if err != nil {
-		return apierrors.ToDashboardErrorResponse(ctx, hs.pluginStore, err)
+		return nil, apierrors.ToDashboardErrorResponse(ctx, hs.pluginStore, err)
 	}
 
 	// Clear permission cache for the user who's created the dashboard, so that new permissions are fetched for their next call
@@ -469,14 +915,45 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 		hs.accesscontrolService.ClearUserPermissionCache(c.SignedInUser)
 	}
 
+	// library panels connected before the save, to warn about those the save disconnects
+	var previousLibraryPanels map[string]model.LibraryElementDTO
+	if !newDashboard {
+		previousLibraryPanels, err = hs.LibraryElementService.GetElementsForDashboard(ctx, dashboard.ID)
+		if err != nil {
+			hs.log.Warn("Failed to get the library panels of the dashboard", "dashboard", dashboard.UID, "error", err)
+		}
+	}
+
 	// connect library panels for this dashboard after the dashboard is stored and has an ID
 	err = hs.LibraryPanelService.ConnectLibraryPanelsForDashboard(ctx, c.SignedInUser, dashboard)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Error while connecting library panels", err)
+		return nil, response.Error(http.StatusInternalServerError, "Error while connecting library panels", err)
+	}
+	hs.dashboardIndex.update(dashboard)
+	// summarize before the previous version can be pruned
+	var changeSummary *dashdiffs.ChangeSummary
+	if !newDashboard && c.QueryBool("summarizeChanges") {
+		changeSummary = hs.dashboardChangeSummary(ctx, dashboard, dash.Data)
+	}
+	if err := hs.enforceDashboardVersionCap(ctx, dashboard); err != nil {
+		hs.log.Warn("Failed to delete dashboard versions beyond the version cap", "dashboard", dashboard.UID, "error", err)
+	}
+	if _, err := hs.pruneDashboardVersions(ctx, dashboard); err != nil {
+		hs.log.Warn("Failed to prune dashboard versions", "dashboard", dashboard.UID, "error", err)
+	}
+	hs.publishDashboardChange(c, dashboard, false)
+	switch {
+	case newDashboard:
+		hs.auditDashboard(c, dashboardaudit.ActionCreated, dashboard, 0, dashboard.Version)
+	case cmd.RestoredFrom != 0:
+		hs.auditDashboard(c, dashboardaudit.ActionRestored, dashboard, dashboard.Version-1, dashboard.Version)
+	default:
+		// every save bumps the version by one
+		hs.auditDashboard(c, dashboardaudit.ActionUpdated, dashboard, dashboard.Version-1, dashboard.Version)
 	}
 
 	c.TimeRequest(metrics.MApiDashboardSave)
-	return response.JSON(http.StatusOK, util.DynMap{
+	result := util.DynMap{
 		"status":    "success",
 		"slug":      dashboard.Slug,
 		"version":   dashboard.Version,
@@ -484,13 +961,37 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 		"uid":       dashboard.UID,
 		"url":       dashboard.GetURL(),
 		"folderUid": dashboard.FolderUID,
-	})
+
+		"consistencyToken": dashboardConsistencyToken(dashboard),
+	}
+	if warnings := disconnectedLibraryPanelWarnings(previousLibraryPanels, dash.Data); len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	if changeSummary != nil {
+		result["changeSummary"] = changeSummary
+	}
+	if c.QueryBool("includeQuota") {
+		usage, err := hs.dashboardQuotaUsage(c, userID)
+		if err != nil {
+			hs.log.Warn("Failed to get dashboard quota usage", "dashboard", dashboard.UID, "error", err)
+		} else {
+			result["quota"] = usage
+		}
+	}
+	return result, nil
 }
 
 // swagger:route GET /dashboards/home dashboards getHomeDashboard
 //
 // Get home dashboard.
 //
+// The home dashboard is resolved from the preferences of the user, then of the user's teams and then of the
+// organization, falling back to the configured home page or the default home dashboard.
+//
+// A home dashboard set in the preferences, or the configured home page, is returned as a pointer with its
+// url, and the uid of the dashboard, for the client to fetch it. With `redirect=true` a 302 redirect to that
+// url is returned instead. The default home dashboard is always returned inline.
+//
 // Responses:
 // 200: getHomeDashboardResponse
 // 401: unauthorisedError
@@ -508,28 +1009,27 @@ func (hs *HTTPServer) GetHomeDashboard(c *contextmodel.ReqContext) response.Resp
 		}
 	}
 
-	prefsQuery := pref.GetPreferenceWithDefaultsQuery{OrgID: c.SignedInUser.GetOrgID(), UserID: userID, Teams: c.SignedInUser.GetTeams()}
-	homePage := hs.Cfg.HomePage
-
-	preference, err := hs.preferenceService.GetWithDefaults(c.Req.Context(), &prefsQuery)
+	homeDashboard, err := hs.resolveHomeDashboard(c.Req.Context(), c.SignedInUser.GetOrgID(), userID, c.SignedInUser.GetTeams())
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Failed to get preferences", err)
 	}
-
-	if preference.HomeDashboardID == 0 && len(homePage) > 0 {
-		homePageRedirect := dtos.DashboardRedirect{RedirectUri: homePage}
-		return response.JSON(http.StatusOK, &homePageRedirect)
+	if homeDashboard != nil {
+		dashRedirect := dtos.DashboardRedirect{
+			RedirectUri: dashboards.GetDashboardURL(homeDashboard.UID, homeDashboard.Slug),
+			UID:         homeDashboard.UID,
+		}
+		if c.QueryBool("redirect") {
+			return response.Redirect(dashRedirect.RedirectUri)
+		}
+		return response.JSON(http.StatusOK, &dashRedirect)
 	}
 
-	if preference.HomeDashboardID != 0 {
-		slugQuery := dashboards.GetDashboardRefByIDQuery{ID: preference.HomeDashboardID}
-		slugQueryResult, err := hs.DashboardService.GetDashboardUIDByID(c.Req.Context(), &slugQuery)
-		if err == nil {
-			url := dashboards.GetDashboardURL(slugQueryResult.UID, slugQueryResult.Slug)
-			dashRedirect := dtos.DashboardRedirect{RedirectUri: url}
-			return response.JSON(http.StatusOK, &dashRedirect)
+	if homePage := hs.Cfg.HomePage; len(homePage) > 0 {
+		if c.QueryBool("redirect") {
+			return response.Redirect(homePage)
 		}
-		hs.log.Warn("Failed to get slug from database", "err", err)
+		homePageRedirect := dtos.DashboardRedirect{RedirectUri: homePage}
+		return response.JSON(http.StatusOK, &homePageRedirect)
 	}
 
 	filePath := hs.Cfg.DefaultHomeDashboardPath
@@ -537,34 +1037,58 @@ func (hs *HTTPServer) GetHomeDashboard(c *contextmodel.ReqContext) response.Resp
 		filePath = filepath.Join(hs.Cfg.StaticRootPath, "dashboards/home.json")
 	}
 
-	// It's safe to ignore gosec warning G304 since the variable part of the file path comes from a configuration
-	// variable
-	// nolint:gosec
-	file, err := os.Open(filePath)
+	homeDashboardData, err := hs.homeDashboard.get(filePath)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Failed to load home dashboard", err)
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			hs.log.Warn("Failed to close dashboard file", "path", filePath, "err", err)
-		}
-	}()
 
 	dash := dtos.DashboardFullWithMeta{}
 	dash.Meta.CanEdit = c.SignedInUser.HasRole(org.RoleEditor)
 	dash.Meta.FolderTitle = "General"
-	dash.Dashboard = simplejson.New()
-
-	jsonParser := json.NewDecoder(file)
-	if err := jsonParser.Decode(dash.Dashboard); err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to load home dashboard", err)
-	}
+	dash.Dashboard = homeDashboardData
 
 	hs.addGettingStartedPanelToHomeDashboard(c, dash.Dashboard)
 
 	return response.JSON(http.StatusOK, &dash)
 }
 
+// resolveHomeDashboard returns the home dashboard set in the preferences of
+// the user, then of the user's teams and then of the org, or nil when none of
+// them sets one. Among teams the team with the
+// highest id wins, the same way team preferences are merged elsewhere. A home
+// dashboard that no longer exists is skipped.
+func (hs *HTTPServer) resolveHomeDashboard(ctx context.Context, orgID, userID int64, teams []int64) (*dashboards.DashboardRef, error) {
+	queries := make([]pref.GetPreferenceQuery, 0, len(teams)+2)
+	if userID != 0 {
+		queries = append(queries, pref.GetPreferenceQuery{OrgID: orgID, UserID: userID})
+	}
+	teamIDs := append([]int64{}, teams...)
+	sort.Slice(teamIDs, func(i, j int) bool { return teamIDs[i] > teamIDs[j] })
+	for _, teamID := range teamIDs {
+		queries = append(queries, pref.GetPreferenceQuery{OrgID: orgID, TeamID: teamID})
+	}
+	queries = append(queries, pref.GetPreferenceQuery{OrgID: orgID})
+
+	for i := range queries {
+		preference, err := hs.preferenceService.Get(ctx, &queries[i])
+		if err != nil {
+			return nil, err
+		}
+		if preference.HomeDashboardID == 0 {
+			continue
+		}
+
+		slugQuery := dashboards.GetDashboardRefByIDQuery{ID: preference.HomeDashboardID}
+		slugQueryResult, err := hs.DashboardService.GetDashboardUIDByID(ctx, &slugQuery)
+		if err != nil {
+			hs.log.Warn("Failed to get slug from database", "err", err)
+			continue
+		}
+		return slugQueryResult, nil
+	}
+	return nil, nil
+}
+
 func (hs *HTTPServer) addGettingStartedPanelToHomeDashboard(c *contextmodel.ReqContext, dash *simplejson.Json) {
 	// We only add this getting started panel for Admins who have not dismissed it,
 	// and if a custom default home dashboard hasn't been configured
@@ -574,7 +1098,8 @@ func (hs *HTTPServer) addGettingStartedPanelToHomeDashboard(c *contextmodel.ReqC
 		return
 	}
 
-	panels := dash.Get("panels").MustArray()
+	// the panels are shared with the cached home dashboard
+	panels := append([]any{}, dash.Get("panels").MustArray()...)
 
 	newpanel := simplejson.NewFromAny(map[string]any{
 		"type": "gettingstarted",
@@ -606,10 +1131,23 @@ func (hs *HTTPServer) addGettingStartedPanelToHomeDashboard(c *contextmodel.ReqC
 // 404: notFoundError
 // 500: internalServerError
 
+// maxDashboardVersionsWithData is the largest number of versions that can be
+// listed together with their dashboard JSON.
+const maxDashboardVersionsWithData = 20
+
 // swagger:route GET /dashboards/uid/{uid}/versions dashboard_versions getDashboardVersionsByUID
 //
 // Gets all existing versions for the dashboard using UID.
 //
+// Versions can be filtered by author and creation time. The X-Total-Count header holds the number of
+// versions matching the filters, regardless of limit and start.
+//
+// Each version has its creation time, author, message and a source telling whether it was saved from the
+// UI, through the API or by provisioning. Versions saved before sources were recorded have an empty source.
+//
+// With `include=data` the dashboard JSON of each version is returned as well. As versions can be large,
+// this requires a limit of at most 20.
+//
 // Responses:
 // 200: dashboardVersionsResponse
 // 401: unauthorisedError
@@ -648,14 +1186,55 @@ func (hs *HTTPServer) GetDashboardVersions(c *contextmodel.ReqContext) response.
 		DashboardUID: dash.UID,
 		Limit:        c.QueryInt("limit"),
 		Start:        c.QueryInt("start"),
+		IncludeData:  c.Query("include") == "data",
+	}
+	if query.IncludeData && (query.Limit <= 0 || query.Limit > maxDashboardVersionsWithData) {
+		return response.Error(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d when including version data", maxDashboardVersionsWithData), nil)
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.UnixMilli(from)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.UnixMilli(to)
+	}
+	if createdBy := c.Query("createdBy"); createdBy != "" {
+		query.CreatedBy, err = strconv.ParseInt(createdBy, 10, 64)
+		if err != nil {
+			usr, err := hs.userService.GetByLogin(c.Req.Context(), &user.GetUserByLoginQuery{LoginOrEmail: createdBy})
+			if err != nil {
+				return response.JSON(http.StatusOK, []dashver.DashboardVersionMeta{}).SetHeader("X-Total-Count", "0")
+			}
+			query.CreatedBy = usr.ID
+		}
+	}
+	filtered := query.CreatedBy > 0 || !query.From.IsZero() || !query.To.IsZero()
+
+	total, err := hs.dashboardVersionService.Count(c.Req.Context(), &query)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to count dashboard versions", err)
 	}
 
 	versions, err := hs.dashboardVersionService.List(c.Req.Context(), &query)
 	if err != nil {
-		return response.Error(http.StatusNotFound, fmt.Sprintf("No versions found for dashboardId %d", dash.ID), err)
+		if !filtered || !errors.Is(err, dashver.ErrNoVersionsForDashboardID) {
+			return response.Error(http.StatusNotFound, fmt.Sprintf("No versions found for dashboardId %d", dash.ID), err)
+		}
+		versions = nil
 	}
 
-	loginMem := make(map[int64]string, len(versions))
+	res := hs.dashboardVersionMetas(c.Req.Context(), dash.UID, versions)
+	return response.JSON(http.StatusOK, res).SetHeader("X-Total-Count", strconv.FormatInt(total, 10))
+}
+
+// dashboardVersionMetas returns the versions as listed by the API, with the
+// login of their author and a message describing restores and initial saves.
+func (hs *HTTPServer) dashboardVersionMetas(ctx context.Context, dashUID string, versions []*dashver.DashboardVersionDTO) []dashver.DashboardVersionMeta {
+	createdBy := make([]int64, 0, len(versions))
+	for _, version := range versions {
+		createdBy = append(createdBy, version.CreatedBy)
+	}
+	logins := hs.getUserLogins(ctx, createdBy)
+
 	res := make([]dashver.DashboardVersionMeta, 0, len(versions))
 	for _, version := range versions {
 		msg := version.Message
@@ -672,22 +1251,13 @@ func (hs *HTTPServer) GetDashboardVersions(c *contextmodel.ReqContext) response.
 		}
 
 		creator := anonString
-		if version.CreatedBy > 0 {
-			login, found := loginMem[version.CreatedBy]
-			if found {
-				creator = login
-			} else {
-				creator = hs.getUserLogin(c.Req.Context(), version.CreatedBy)
-				if creator != anonString {
-					loginMem[version.CreatedBy] = creator
-				}
-			}
+		if login, ok := logins[version.CreatedBy]; ok {
+			creator = login
 		}
-
 		res = append(res, dashver.DashboardVersionMeta{
 			ID:            version.ID,
 			DashboardID:   version.DashboardID,
-			DashboardUID:  dash.UID,
+			DashboardUID:  dashUID,
 			Data:          version.Data,
 			ParentVersion: version.ParentVersion,
 			RestoredFrom:  version.RestoredFrom,
@@ -695,10 +1265,12 @@ func (hs *HTTPServer) GetDashboardVersions(c *contextmodel.ReqContext) response.
 			Created:       version.Created,
 			Message:       msg,
 			CreatedBy:     creator,
+			VersionTag:    version.VersionTag,
+			Source:        version.Source,
 		})
 	}
 
-	return response.JSON(http.StatusOK, res)
+	return res
 }
 
 // swagger:route GET /dashboards/id/{DashboardID}/versions/{DashboardVersionID} dashboard_versions getDashboardVersionByID
@@ -783,15 +1355,167 @@ func (hs *HTTPServer) GetDashboardVersion(c *contextmodel.ReqContext) response.R
 		Created:       res.Created,
 		Message:       res.Message,
 		CreatedBy:     creator,
+		VersionTag:    res.VersionTag,
+		Source:        res.Source,
 	}
 
 	return response.JSON(http.StatusOK, dashVersionMeta)
 }
 
+// swagger:route POST /dashboards/uid/{uid}/versions/{DashboardVersionID}/tag dashboard_versions tagDashboardVersion
+//
+// Tag a dashboard version.
+//
+// Attaches a name such as pre-migration to the version, so it can be found again and restored by name. A name
+// is unique among the versions of a dashboard; an empty name removes the tag of the version.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 409: conflictError
+// 500: internalServerError
+func (hs *HTTPServer) TagDashboardVersion(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.TagDashboardVersionCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	version, err := strconv.Atoi(web.Params(c.Req)[":id"])
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	err = hs.dashboardVersionService.Tag(c.Req.Context(), &dashver.TagDashboardVersionCommand{
+		DashboardID: dash.ID,
+		Version:     version,
+		Name:        cmd.Name,
+	})
+	if err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		if errors.Is(err, dashver.ErrDashboardVersionTagTaken) {
+			return response.Error(http.StatusConflict, err.Error(), err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to tag dashboard version", err)
+	}
+
+	if cmd.Name == "" {
+		return response.Success("Dashboard version tag removed")
+	}
+	return response.Success("Dashboard version tagged")
+}
+
+// swagger:route GET /dashboards/uid/{uid}/changed-since/{version} dashboards getDashboardChangedSince
+//
+// Check whether a dashboard changed since a version.
+//
+// Reports whether the stored dashboard is no longer at the given version, e.g. because someone else saved
+// it in the meantime, and returns its current version and content hash. When a hash returned by an earlier
+// call is given, the dashboard is also reported as changed if its content no longer matches the hash.
+//
+// Responses:
+// 200: dashboardChangedSinceResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardChangedSince(c *contextmodel.ReqContext) response.Response {
+	version, err := strconv.Atoi(web.Params(c.Req)[":version"])
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	hash, err := dashboardContentHash(dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to hash dashboard", err)
+	}
+
+	changed := dash.Version != version
+	if expected := c.Query("hash"); expected != "" && expected != hash {
+		changed = true
+	}
+
+	return response.JSON(http.StatusOK, dtos.DashboardChangedSince{
+		Changed:        changed,
+		CurrentVersion: dash.Version,
+		Hash:           hash,
+	})
+}
+
+// dashboardContentHash returns the SHA-256 of the dashboard body, leaving out
+// the id and version that change without the content changing.
+func dashboardContentHash(data *simplejson.Json) (string, error) {
+	body, err := data.Map()
+	if err != nil {
+		return "", err
+	}
+	content := make(map[string]any, len(body))
+	for key, value := range body {
+		if key != "id" && key != "version" {
+			content[key] = value
+		}
+	}
+
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // swagger:route POST /dashboards/validate dashboards alpha validateDashboard
 //
 // Validates a dashboard JSON against the schema.
 //
+// Besides the schema, it checks that the dashboard has a title and that every panel type is installed.
+// Each problem found is listed in errors with the JSON path of the offending field and an error code.
+//
+// With `migrate=true`, a dashboard with a schemaVersion below the supported one is first migrated to it
+// and validated afterwards. The migrated dashboard and the migrations applied are returned in the
+// response. Only dashboards from schemaVersion 34 can be migrated.
+//
+// With `checkDatasources=true`, the data sources referenced by panels and their targets must exist in the
+// organization. Template variables, the default data source and built-in data sources are not checked.
+//
+// With `provisionedUid`, the dashboard is also compared with the file the dashboard with that uid is
+// provisioned from. A warning is returned when its version is lower than the one of the file or, when
+// either has no version, when its content differs from the file. Warnings don't make the dashboard invalid.
+//
+// With `skipSchemaVersion=true`, a dashboard with a schemaVersion below the supported one is not rejected
+// with a 412. Its content is checked as usual but not against the schema, which doesn't apply to it, and the
+// schema version is returned as a warning.
+//
+// Panel types and transformations that need a feature flag which is not enabled on this instance are
+// returned as warnings, since the dashboard would render them blank. With `strictFeatureFlags=true` they
+// are errors instead.
+//
 // Produces:
 // - application/json
 //
@@ -821,49 +1545,312 @@ func (hs *HTTPServer) ValidateDashboard(c *contextmodel.ReqContext) response.Res
 
 	schemaVersion, err := dashboardJson.Get("schemaVersion").Int()
 
-	isValid := false
+	var migrations []DashboardMigrationStep
+	if err == nil && schemaVersion < dashboard.HandoffSchemaVersion && schemaVersion >= minMigratableSchemaVersion && c.QueryBool("migrate") {
+		migrator := &dashboardMigrator{ctx: c.Req.Context(), user: c.SignedInUser, dataSources: hs.DataSourcesService}
+		if migrations, err = migrator.migrate(dashboardJson); err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to migrate dashboard", err)
+		}
+		migrated, err := dashboardJson.Encode()
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to migrate dashboard", err)
+		}
+		cmd.Dashboard = string(migrated)
+		schemaVersion = dashboardJson.Get("schemaVersion").MustInt()
+	}
+
 	statusCode := http.StatusOK
 	validationMessage := ""
+	validationErrors := []DashboardValidationError{}
+	var warnings []DashboardValidationError
 
 	// Only try to validate if the schemaVersion is at least the handoff version
 	// (the minimum schemaVersion against which the dashboard schema is known to
 	// work), or if schemaVersion is absent (which will happen once the Thema
 	// schema becomes canonical).
-	if err != nil || schemaVersion >= dashboard.HandoffSchemaVersion {
-		// Schemas expect the dashboard to live in the spec field
-		k8sResource := `{"spec": ` + cmd.Dashboard + "}"
-
-		_, _, validationErr := dk.JSONValueMux([]byte(k8sResource))
-
-		if validationErr == nil {
-			isValid = true
+	supportedSchemaVersion := err != nil || schemaVersion >= dashboard.HandoffSchemaVersion
+	schemaVersionError := DashboardValidationError{
+		Path:    "schemaVersion",
+		Code:    validationCodeInvalidSchemaVersion,
+		Message: fmt.Sprintf("schema version %d is lower than the minimum supported version %d", schemaVersion, dashboard.HandoffSchemaVersion),
+	}
+	// the schema doesn't apply to older dashboards, with skipSchemaVersion only
+	// their content is checked
+	skipSchemaVersion := !supportedSchemaVersion && c.QueryBool("skipSchemaVersion")
+	if skipSchemaVersion {
+		warnings = append(warnings, schemaVersionError)
+	}
+
+	if supportedSchemaVersion || skipSchemaVersion {
+		if supportedSchemaVersion {
+			// Schemas expect the dashboard to live in the spec field
+			k8sResource := `{"spec": ` + cmd.Dashboard + "}"
+
+			_, _, validationErr := dk.JSONValueMux([]byte(k8sResource))
+
+			if validationErr != nil {
+				validationMessage = validationErr.Error()
+				validationErrors = append(validationErrors, DashboardValidationError{
+					Code:    validationCodeSchemaViolation,
+					Message: validationMessage,
+				})
+			}
+		}
+		validationErrors = append(validationErrors, hs.validateDashboardContent(c.Req.Context(), dashboardJson)...)
+		if featureFlagErrors := hs.validateDashboardFeatureFlags(dashboardJson); c.QueryBool("strictFeatureFlags") {
+			validationErrors = append(validationErrors, featureFlagErrors...)
 		} else {
-			validationMessage = validationErr.Error()
+			warnings = append(warnings, featureFlagErrors...)
+		}
+		if c.QueryBool("checkDatasources") {
+			dataSourceErrors, err := hs.validateDashboardDataSources(c.Req.Context(), c.SignedInUser.GetOrgID(), dashboardJson)
+			if err != nil {
+				return response.Error(http.StatusInternalServerError, "Failed to check data sources", err)
+			}
+			validationErrors = append(validationErrors, dataSourceErrors...)
+		}
+		if len(validationErrors) > 0 {
 			statusCode = http.StatusUnprocessableEntity
 		}
 	} else {
 		validationMessage = "invalid schema version"
 		statusCode = http.StatusPreconditionFailed
+		validationErrors = append(validationErrors, schemaVersionError)
+	}
+
+	if validationMessage == "" && len(validationErrors) > 0 {
+		validationMessage = validationErrors[0].Message
+	}
+
+	if provisionedUID := c.Query("provisionedUid"); provisionedUID != "" {
+		provisionedWarnings, rsp := hs.provisionedDashboardWarnings(c, provisionedUID, dashboardJson)
+		if rsp != nil {
+			return rsp
+		}
+		warnings = append(warnings, provisionedWarnings...)
 	}
 
 	respData := &ValidateDashboardResponse{
-		IsValid: isValid,
-		Message: validationMessage,
+		IsValid:  len(validationErrors) == 0,
+		Message:  validationMessage,
+		Errors:   validationErrors,
+		Warnings: warnings,
+	}
+	if migrations != nil {
+		respData.Dashboard = dashboardJson
+		respData.Migrations = migrations
 	}
 
 	return response.JSON(statusCode, respData)
 }
 
+// Codes of the problems reported by ValidateDashboard.
+const (
+	validationCodeInvalidSchemaVersion = "invalidSchemaVersion"
+	validationCodeSchemaViolation      = "schemaViolation"
+	validationCodeEmptyTitle           = "emptyTitle"
+	validationCodeUnknownPanelType     = "unknownPanelType"
+	validationCodeUnknownDataSource    = "unknownDataSource"
+	validationCodeOlderThanProvisioned = "olderThanProvisioned"
+	validationCodeProvisionedMismatch  = "provisionedMismatch"
+	validationCodeFeatureFlagDisabled  = "featureFlagDisabled"
+)
+
+// featureGatedPanelTypes and featureGatedTransformations map the panel types
+// and transformations the frontend only offers when a feature flag is enabled
+// to that flag. No core panel type is gated at the moment.
+var (
+	featureGatedPanelTypes      = map[string]string{}
+	featureGatedTransformations = map[string]string{
+		"formatString": featuremgmt.FlagFormatString,
+	}
+)
+
+// validateDashboardContent checks the dashboard for problems the schema does
+// not catch: a missing title and panels of plugins that are not installed.
+func (hs *HTTPServer) validateDashboardContent(ctx context.Context, data *simplejson.Json) []DashboardValidationError {
+	var validationErrors []DashboardValidationError
+	if strings.TrimSpace(data.Get("title").MustString()) == "" {
+		validationErrors = append(validationErrors, DashboardValidationError{
+			Path:    "title",
+			Code:    validationCodeEmptyTitle,
+			Message: "dashboard title cannot be empty",
+		})
+	}
+
+	var checkPanels func(path string, panels []any)
+	checkPanels = func(path string, panels []any) {
+		for i, item := range panels {
+			panel := simplejson.NewFromAny(item)
+			panelPath := fmt.Sprintf("%s[%d]", path, i)
+			if panelType := panel.Get("type").MustString(); panelType != "" && panelType != "row" {
+				if plugin, exists := hs.pluginStore.Plugin(ctx, panelType); !exists || plugin.Type != plugins.TypePanel {
+					validationErrors = append(validationErrors, DashboardValidationError{
+						Path:    panelPath + ".type",
+						Code:    validationCodeUnknownPanelType,
+						Message: fmt.Sprintf("unknown panel type %q", panelType),
+					})
+				}
+			}
+			checkPanels(panelPath+".panels", panel.Get("panels").MustArray())
+		}
+	}
+	checkPanels("panels", data.Get("panels").MustArray())
+
+	return validationErrors
+}
+
+// validateDashboardFeatureFlags lists the panels and transformations of the
+// dashboard that need a feature flag which is not enabled on this instance.
+// Those render blank in the frontend.
+func (hs *HTTPServer) validateDashboardFeatureFlags(data *simplejson.Json) []DashboardValidationError {
+	var validationErrors []DashboardValidationError
+	check := func(path, kind, name string, gated map[string]string) {
+		if flag, ok := gated[name]; ok && !hs.Features.IsEnabledGlobally(flag) {
+			validationErrors = append(validationErrors, DashboardValidationError{
+				Path:    path,
+				Code:    validationCodeFeatureFlagDisabled,
+				Message: fmt.Sprintf("%s %q requires the feature flag %q, which is not enabled", kind, name, flag),
+			})
+		}
+	}
+
+	var checkPanels func(path string, panels []any)
+	checkPanels = func(path string, panels []any) {
+		for i, item := range panels {
+			panel := simplejson.NewFromAny(item)
+			panelPath := fmt.Sprintf("%s[%d]", path, i)
+			check(panelPath+".type", "panel type", panel.Get("type").MustString(), featureGatedPanelTypes)
+			for j, transformation := range panel.Get("transformations").MustArray() {
+				id := simplejson.NewFromAny(transformation).Get("id").MustString()
+				check(fmt.Sprintf("%s.transformations[%d].id", panelPath, j), "transformation", id, featureGatedTransformations)
+			}
+			checkPanels(panelPath+".panels", panel.Get("panels").MustArray())
+		}
+	}
+	checkPanels("panels", data.Get("panels").MustArray())
+
+	return validationErrors
+}
+
+// validateDashboardDataSources checks that the data sources referenced by the
+// panels and their targets exist in the org, either by uid or by name.
+func (hs *HTTPServer) validateDashboardDataSources(ctx context.Context, orgID int64, data *simplejson.Json) ([]DashboardValidationError, error) {
+	var validationErrors []DashboardValidationError
+	found := map[string]*datasources.DataSource{}
+	lookup := func(query *datasources.GetDataSourceQuery) (*datasources.DataSource, error) {
+		key := query.UID + "/" + query.Name
+		if ds, ok := found[key]; ok {
+			return ds, nil
+		}
+		ds, err := hs.DataSourcesService.GetDataSource(ctx, query)
+		if err != nil && !errors.Is(err, datasources.ErrDataSourceNotFound) {
+			return nil, err
+		}
+		found[key] = ds
+		return ds, nil
+	}
+
+	checkRef := func(path string, ref any) error {
+		var uid, dsType, name string
+		switch v := ref.(type) {
+		case map[string]any:
+			uid, _ = v["uid"].(string)
+			dsType, _ = v["type"].(string)
+		case string:
+			name = v
+		}
+		nameOrUID := uid + name
+		if nameOrUID == "" || nameOrUID == "default" || strings.HasPrefix(nameOrUID, "$") || isBuiltInDataSource(nameOrUID) {
+			return nil
+		}
+
+		var ds *datasources.DataSource
+		var err error
+		if uid != "" {
+			ds, err = lookup(&datasources.GetDataSourceQuery{UID: uid, OrgID: orgID})
+		} else if ds, err = lookup(&datasources.GetDataSourceQuery{Name: name, OrgID: orgID}); ds == nil && err == nil {
+			ds, err = lookup(&datasources.GetDataSourceQuery{UID: name, OrgID: orgID})
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case ds == nil:
+			validationErrors = append(validationErrors, DashboardValidationError{
+				Path:    path,
+				Code:    validationCodeUnknownDataSource,
+				Message: fmt.Sprintf("data source %q not found", nameOrUID),
+			})
+		case dsType != "" && dsType != ds.Type:
+			validationErrors = append(validationErrors, DashboardValidationError{
+				Path:    path,
+				Code:    validationCodeUnknownDataSource,
+				Message: fmt.Sprintf("data source %q is of type %q, not %q", nameOrUID, ds.Type, dsType),
+			})
+		}
+		return nil
+	}
+
+	var checkPanels func(path string, panels []any) error
+	checkPanels = func(path string, panels []any) error {
+		for i, item := range panels {
+			panel := simplejson.NewFromAny(item)
+			panelPath := fmt.Sprintf("%s[%d]", path, i)
+			if err := checkRef(panelPath+".datasource", panel.Get("datasource").Interface()); err != nil {
+				return err
+			}
+			for j, target := range panel.Get("targets").MustArray() {
+				targetPath := fmt.Sprintf("%s.targets[%d].datasource", panelPath, j)
+				if err := checkRef(targetPath, simplejson.NewFromAny(target).Get("datasource").Interface()); err != nil {
+					return err
+				}
+			}
+			if err := checkPanels(panelPath+".panels", panel.Get("panels").MustArray()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := checkPanels("panels", data.Get("panels").MustArray()); err != nil {
+		return nil, err
+	}
+
+	return validationErrors, nil
+}
+
+// isBuiltInDataSource reports whether the uid is one of the data sources every
+// instance has, which are not stored as data sources of the org.
+func isBuiltInDataSource(uid string) bool {
+	switch uid {
+	case mixedDataSourceUID, expressionDataSourceUID, "-- Grafana --", "-- Dashboard --", "grafana":
+		return true
+	}
+	return false
+}
+
 // swagger:route POST /dashboards/calculate-diff dashboards calculateDashboardDiff
 //
 // Perform diff on two dashboards.
 //
+// Each side of the diff is either a stored dashboard version or, when data is set, an unsaved dashboard
+// body, e.g. to preview changes before saving them.
+//
+// For dashboards with many changes, the semantic diff can be fetched in pages with `page` and `pageSize`.
+// The X-Total-Changes header holds the number of changes on all pages. The basic diff can be capped with
+// `maxLines`, in which case it is cut between two changed blocks and the X-Diff-Truncated header is set.
+//
+// The diff type is one of basic, json, delta or semantic. Without one, the default_diff_type of the
+// [dashboards] settings is used. Other types are rejected.
+//
 // Produces:
 // - application/json
 // - text/html
 //
 // Responses:
 // 200: calculateDashboardDiffResponse
+// 400: badRequestError
 // 401: unauthorisedError
 // 403: forbiddenError
 // 500: internalServerError
@@ -872,86 +1859,480 @@ func (hs *HTTPServer) CalculateDashboardDiff(c *contextmodel.ReqContext) respons
 	if err := web.Bind(c.Req, &apiOptions); err != nil {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
-	guardianBase, err := guardian.New(c.Req.Context(), apiOptions.Base.DashboardId, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	// sides given as inline data don't read any stored dashboard
+	checkCanSave := func(dashboardID int64) response.Response {
+		guardian, err := guardian.New(c.Req.Context(), dashboardID, c.SignedInUser.GetOrgID(), c.SignedInUser)
+		if err != nil {
+			return response.Err(err)
+		}
+		if canSave, err := guardian.CanSave(); err != nil || !canSave {
+			return dashboardGuardianResponse(err)
+		}
+		return nil
+	}
+	if apiOptions.Base.Data == nil {
+		if rsp := checkCanSave(apiOptions.Base.DashboardId); rsp != nil {
+			return rsp
+		}
+	}
+	if apiOptions.New.Data == nil && (apiOptions.Base.Data != nil || apiOptions.New.DashboardId != apiOptions.Base.DashboardId) {
+		if rsp := checkCanSave(apiOptions.New.DashboardId); rsp != nil {
+			return rsp
+		}
+	}
+
+	diffType, rsp := hs.parseDiffType(apiOptions.DiffType)
+	if rsp != nil {
+		return rsp
+	}
+
+	options := dashdiffs.Options{
+		OrgId:    c.SignedInUser.GetOrgID(),
+		DiffType: diffType,
+		Page:     apiOptions.Page,
+		PageSize: apiOptions.PageSize,
+		MaxLines: apiOptions.MaxLines,
+		Base: dashdiffs.DiffTarget{
+			DashboardId:      apiOptions.Base.DashboardId,
+			Version:          apiOptions.Base.Version,
+			UnsavedDashboard: apiOptions.Base.UnsavedDashboard,
+		},
+		New: dashdiffs.DiffTarget{
+			DashboardId:      apiOptions.New.DashboardId,
+			Version:          apiOptions.New.Version,
+			UnsavedDashboard: apiOptions.New.UnsavedDashboard,
+		},
+	}
+
+	baseData, rsp := hs.diffTargetData(c.Req.Context(), options.OrgId, apiOptions.Base)
+	if rsp != nil {
+		return rsp
+	}
+	newData, rsp := hs.diffTargetData(c.Req.Context(), options.OrgId, apiOptions.New)
+	if rsp != nil {
+		return rsp
+	}
+
+	result, err := dashdiffs.CalculateDiff(c.Req.Context(), &options, baseData, newData)
+
+	if err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+
+	return diffResultResponse(&options, result)
+}
+
+// parseDiffType returns the diff type asked for by a diff request, or the
+// configured default one when none is given. Unknown types are rejected with
+// a 400 response.
+func (hs *HTTPServer) parseDiffType(diffType string) (dashdiffs.DiffType, response.Response) {
+	if diffType == "" {
+		diffType = hs.Cfg.DashboardDefaultDiffType
+	}
+	if diffType == "" {
+		return dashdiffs.DiffBasic, nil
+	}
+	parsed, err := dashdiffs.ParseDiffTypeStrict(diffType)
+	if err != nil {
+		return parsed, response.Error(http.StatusBadRequest, fmt.Sprintf("Unsupported diff type %q", diffType), err)
+	}
+	return parsed, nil
+}
+
+// diffTargetData returns the dashboard body of one side of a diff, either
+// given inline or loaded from the stored dashboard version.
+func (hs *HTTPServer) diffTargetData(ctx context.Context, orgID int64, target dtos.CalculateDiffTarget) (*simplejson.Json, response.Response) {
+	if target.Data != nil {
+		return target.Data, nil
+	}
+
+	version, err := hs.dashboardVersionService.Get(ctx, &dashver.GetDashboardVersionQuery{
+		DashboardID: target.DashboardId,
+		Version:     target.Version,
+		OrgID:       orgID,
+	})
+	if err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return nil, response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		return nil, response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	return version.Data, nil
+}
+
+// swagger:route GET /dashboards/uid/{uid}/diff-origin dashboards calculateDashboardOriginDiff
+//
+// Diff a dashboard against its first version.
+//
+// Returns every change made to the dashboard since it was created, comparing version 1 with the current
+// version.
+//
+// Produces:
+// - application/json
+// - text/html
+//
+// Responses:
+// 200: calculateDashboardDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CalculateDashboardOriginDiff(c *contextmodel.ReqContext) response.Response {
+	diffType, rsp := hs.parseDiffType(c.Query("diffType"))
+	if rsp != nil {
+		return rsp
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	origin, err := hs.dashboardVersionService.Get(c.Req.Context(), &dashver.GetDashboardVersionQuery{
+		OrgID:        dash.OrgID,
+		DashboardID:  dash.ID,
+		DashboardUID: dash.UID,
+		Version:      1,
+	})
+	if err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+
+	options := dashdiffs.Options{
+		OrgId:    dash.OrgID,
+		DiffType: diffType,
+		Base:     dashdiffs.DiffTarget{DashboardId: dash.ID, Version: origin.Version},
+		New:      dashdiffs.DiffTarget{DashboardId: dash.ID, Version: dash.Version},
+	}
+	result, err := calculateDiff(c.Req.Context(), &options, origin.Data, dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	return diffResultResponse(&options, result)
+}
+
+// swagger:route GET /dashboards/uid/{uid}/versions/{base}/diff/{new} dashboard_versions calculateDashboardVersionsDiff
+//
+// Diff two versions of a dashboard.
+//
+// Returns the same diff as the calculate diff endpoint for two stored versions of the dashboard, so the
+// diff can be linked to and cached.
+//
+// Produces:
+// - application/json
+// - text/html
+//
+// Responses:
+// 200: calculateDashboardDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CalculateDashboardVersionsDiff(c *contextmodel.ReqContext) response.Response {
+	baseVersion, err := strconv.Atoi(web.Params(c.Req)[":base"])
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "base is invalid", err)
+	}
+	newVersion, err := strconv.Atoi(web.Params(c.Req)[":new"])
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "new is invalid", err)
+	}
+	// diffType, as on the other diff endpoints, is accepted as an alias of type
+	queryDiffType := c.Query("type")
+	if queryDiffType == "" {
+		queryDiffType = c.Query("diffType")
+	}
+	diffType, rsp := hs.parseDiffType(queryDiffType)
+	if rsp != nil {
+		return rsp
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
 	if err != nil {
 		return response.Err(err)
 	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	options := dashdiffs.Options{
+		OrgId:    dash.OrgID,
+		DiffType: diffType,
+		Base:     dashdiffs.DiffTarget{DashboardId: dash.ID, Version: baseVersion},
+		New:      dashdiffs.DiffTarget{DashboardId: dash.ID, Version: newVersion},
+	}
+	baseData, rsp := hs.diffTargetData(c.Req.Context(), dash.OrgID, dtos.CalculateDiffTarget{DashboardId: dash.ID, Version: baseVersion})
+	if rsp != nil {
+		return rsp
+	}
+	newData, rsp := hs.diffTargetData(c.Req.Context(), dash.OrgID, dtos.CalculateDiffTarget{DashboardId: dash.ID, Version: newVersion})
+	if rsp != nil {
+		return rsp
+	}
+	result, err := calculateDiff(c.Req.Context(), &options, baseData, newData)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	return diffResultResponse(&options, result)
+}
+
+// swagger:route GET /dashboards/uid/{uid}/versions/{DashboardVersionID}/diff-current dashboard_versions calculateDashboardCurrentDiff
+//
+// Diff a version of a dashboard against the current dashboard.
+//
+// Returns the same diff as the calculate diff endpoint with the given stored version as the base and the
+// dashboard as it is now as the new side, without having to know the current version.
+//
+// Produces:
+// - application/json
+// - text/html
+//
+// Responses:
+// 200: calculateDashboardDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CalculateDashboardCurrentDiff(c *contextmodel.ReqContext) response.Response {
+	baseVersion, err := strconv.Atoi(web.Params(c.Req)[":id"])
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+	diffType, rsp := hs.parseDiffType(c.Query("diffType"))
+	if rsp != nil {
+		return rsp
+	}
 
-	if canSave, err := guardianBase.CanSave(); err != nil || !canSave {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
 		return dashboardGuardianResponse(err)
 	}
 
-	if apiOptions.Base.DashboardId != apiOptions.New.DashboardId {
-		guardianNew, err := guardian.New(c.Req.Context(), apiOptions.New.DashboardId, c.SignedInUser.GetOrgID(), c.SignedInUser)
-		if err != nil {
-			return response.Err(err)
-		}
-
-		if canSave, err := guardianNew.CanSave(); err != nil || !canSave {
-			return dashboardGuardianResponse(err)
+	options := dashdiffs.Options{
+		OrgId:    dash.OrgID,
+		DiffType: diffType,
+		Base:     dashdiffs.DiffTarget{DashboardId: dash.ID, Version: baseVersion},
+		New:      dashdiffs.DiffTarget{DashboardId: dash.ID, Version: dash.Version},
+	}
+	baseData, rsp := hs.diffTargetData(c.Req.Context(), dash.OrgID, dtos.CalculateDiffTarget{DashboardId: dash.ID, Version: baseVersion})
+	if rsp != nil {
+		return rsp
+	}
+	result, err := calculateDiff(c.Req.Context(), &options, baseData, dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	return diffResultResponse(&options, result)
+}
+
+// swagger:route GET /dashboards/uid/{uid}/provisioned-diff dashboards calculateDashboardProvisionedDiff
+//
+// Diff a provisioned dashboard against its source file.
+//
+// Compares the dashboard file of the provisioner with the dashboard stored in the database, showing the
+// changes made through the UI when the provisioner allows UI updates. The id and version of both sides are
+// left out of the comparison.
+//
+// Produces:
+// - application/json
+// - text/html
+//
+// Responses:
+// 200: calculateDashboardDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CalculateDashboardProvisionedDiff(c *contextmodel.ReqContext) response.Response {
+	diffType, rsp := hs.parseDiffType(c.Query("diffType"))
+	if rsp != nil {
+		return rsp
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	provisioningData, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(c.Req.Context(), dash.ID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned", err)
+	}
+	if provisioningData == nil {
+		return response.Error(http.StatusNotFound, "Dashboard is not provisioned", nil)
+	}
+
+	fileData, err := hs.readProvisionedDashboardFile(provisioningData)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return response.Error(http.StatusNotFound, "Provisioned dashboard file not found", err)
 		}
+		return response.Error(http.StatusInternalServerError, "Failed to read provisioned dashboard file", err)
+	}
+	dbData, err := cloneDashboardJSON(dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	for _, data := range []*simplejson.Json{fileData, dbData} {
+		data.Del("id")
+		data.Del("version")
 	}
 
 	options := dashdiffs.Options{
-		OrgId:    c.SignedInUser.GetOrgID(),
-		DiffType: dashdiffs.ParseDiffType(apiOptions.DiffType),
-		Base: dashdiffs.DiffTarget{
-			DashboardId:      apiOptions.Base.DashboardId,
-			Version:          apiOptions.Base.Version,
-			UnsavedDashboard: apiOptions.Base.UnsavedDashboard,
-		},
-		New: dashdiffs.DiffTarget{
-			DashboardId:      apiOptions.New.DashboardId,
-			Version:          apiOptions.New.Version,
-			UnsavedDashboard: apiOptions.New.UnsavedDashboard,
-		},
+		OrgId:    dash.OrgID,
+		DiffType: diffType,
+		Base:     dashdiffs.DiffTarget{DashboardId: dash.ID, UnsavedDashboard: fileData},
+		New:      dashdiffs.DiffTarget{DashboardId: dash.ID, Version: dash.Version},
+	}
+	result, err := calculateDiff(c.Req.Context(), &options, fileData, dbData)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
 	}
+	return diffResultResponse(&options, result)
+}
 
-	baseVersionQuery := dashver.GetDashboardVersionQuery{
-		DashboardID: options.Base.DashboardId,
-		Version:     options.Base.Version,
-		OrgID:       options.OrgId,
+// readProvisionedDashboardFile reads the dashboard file a dashboard was
+// provisioned from. Files outside of the provisioner path are rejected.
+func (hs *HTTPServer) readProvisionedDashboardFile(provisioningData *dashboards.DashboardProvisioning) (*simplejson.Json, error) {
+	resolvedPath := hs.ProvisioningService.GetDashboardProvisionerResolvedPath(provisioningData.Name)
+	relPath, err := filepath.Rel(resolvedPath, provisioningData.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("dashboard file %q is outside of the provisioner path", provisioningData.ExternalID)
 	}
 
-	baseVersionRes, err := hs.dashboardVersionService.Get(c.Req.Context(), &baseVersionQuery)
+	// It's safe to ignore gosec warning G304 since the path is checked to be
+	// within the provisioner path above
+	// nolint:gosec
+	b, err := os.ReadFile(filepath.Join(resolvedPath, relPath))
 	if err != nil {
-		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
-			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
-		}
-		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+		return nil, err
 	}
+	return simplejson.NewJson(b)
+}
 
-	newVersionQuery := dashver.GetDashboardVersionQuery{
-		DashboardID: options.New.DashboardId,
-		Version:     options.New.Version,
-		OrgID:       options.OrgId,
+// provisioningDrift reports whether the stored dashboard differs from the file
+// it is provisioned from, comparing content hashes. A file without uid gets
+// the uid of the dashboard, as it does when provisioned.
+func (hs *HTTPServer) provisioningDrift(dash *dashboards.Dashboard, provisioningData *dashboards.DashboardProvisioning) (bool, error) {
+	fileData, err := hs.readProvisionedDashboardFile(provisioningData)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := fileData.CheckGet("uid"); !ok {
+		fileData.Set("uid", dash.UID)
 	}
 
-	newVersionRes, err := hs.dashboardVersionService.Get(c.Req.Context(), &newVersionQuery)
+	hash, err := dashboardContentHash(dash.Data)
 	if err != nil {
-		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
-			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
-		}
-		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+		return false, err
+	}
+	fileHash, err := dashboardContentHash(fileData)
+	if err != nil {
+		return false, err
 	}
+	return hash != fileHash, nil
+}
 
-	baseData := baseVersionRes.Data
-	newData := newVersionRes.Data
+// provisionedDashboardWarnings compares a dashboard with the file the
+// dashboard with the given uid is provisioned from.
+func (hs *HTTPServer) provisionedDashboardWarnings(c *contextmodel.ReqContext, uid string, data *simplejson.Json) ([]DashboardValidationError, response.Response) {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, uid)
+	if rsp != nil {
+		return nil, rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return nil, dashboardGuardianResponse(err)
+	}
 
-	result, err := dashdiffs.CalculateDiff(c.Req.Context(), &options, baseData, newData)
+	provisioningData, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(c.Req.Context(), dash.ID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned", err)
+	}
+	if provisioningData == nil {
+		return nil, response.Error(http.StatusNotFound, "Dashboard is not provisioned", nil)
+	}
 
+	fileData, err := hs.readProvisionedDashboardFile(provisioningData)
 	if err != nil {
-		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
-			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, response.Error(http.StatusNotFound, "Provisioned dashboard file not found", err)
 		}
-		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+		return nil, response.Error(http.StatusInternalServerError, "Failed to read provisioned dashboard file", err)
+	}
+
+	warnings, err := compareWithProvisionedDashboard(data, fileData)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Failed to compare with provisioned dashboard", err)
 	}
+	return warnings, nil
+}
 
-	if options.DiffType == dashdiffs.DiffDelta {
-		return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "application/json")
+// compareWithProvisionedDashboard reports a dashboard that is older than its
+// provisioned file. Versions are compared when both have one, otherwise the
+// content hashes are.
+func compareWithProvisionedDashboard(data, fileData *simplejson.Json) ([]DashboardValidationError, error) {
+	version, versionErr := data.Get("version").Int()
+	fileVersion, fileVersionErr := fileData.Get("version").Int()
+	if versionErr == nil && fileVersionErr == nil {
+		if version < fileVersion {
+			return []DashboardValidationError{{
+				Path:    "version",
+				Code:    validationCodeOlderThanProvisioned,
+				Message: fmt.Sprintf("dashboard version %d is older than the provisioned version %d", version, fileVersion),
+			}}, nil
+		}
+		return nil, nil
 	}
 
-	return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "text/html")
+	hash, err := dashboardContentHash(data)
+	if err != nil {
+		return nil, err
+	}
+	fileHash, err := dashboardContentHash(fileData)
+	if err != nil {
+		return nil, err
+	}
+	if hash != fileHash {
+		return []DashboardValidationError{{
+			Code:    validationCodeProvisionedMismatch,
+			Message: "dashboard differs from the provisioned dashboard file",
+		}}, nil
+	}
+	return nil, nil
 }
 
 // swagger:route POST /dashboards/id/{DashboardID}/restore dashboard_versions restoreDashboardVersionByID
@@ -973,6 +2354,13 @@ func (hs *HTTPServer) CalculateDashboardDiff(c *contextmodel.ReqContext) respons
 //
 // Restore a dashboard to a given dashboard version using UID.
 //
+// The version is given either by number, by the name it was tagged with or by a beforeTimestamp, which
+// restores the newest version created at or before that time.
+//
+// With preserveCurrent set, the current dashboard is first saved again as a new version, so the state
+// before the restore stays available as the latest version before it. The version of this snapshot is
+// returned as snapshotVersion.
+//
 // Responses:
 // 200: postDashboardResponse
 // 401: unauthorisedError
@@ -989,6 +2377,9 @@ func (hs *HTTPServer) RestoreDashboardVersion(c *contextmodel.ReqContext) respon
 	if err := web.Bind(c.Req, &apiCmd); err != nil {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
+	if !hasSingleVersionSelector(apiCmd) {
+		return response.Error(http.StatusBadRequest, errRestoreVersionSelector, nil)
+	}
 	if dashUID == "" {
 		dashID, err = strconv.ParseInt(web.Params(c.Req)[":dashboardId"], 10, 64)
 		if err != nil {
@@ -1001,19 +2392,47 @@ func (hs *HTTPServer) RestoreDashboardVersion(c *contextmodel.ReqContext) respon
 		return rsp
 	}
 
+	result, rsp := hs.restoreDashboardVersion(c, dash, apiCmd)
+	if rsp != nil {
+		return rsp
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+const errRestoreVersionSelector = "exactly one of version, versionTag or beforeTimestamp is required"
+
+// hasSingleVersionSelector tells whether the restore command selects the
+// version in exactly one way.
+func hasSingleVersionSelector(cmd dtos.RestoreDashboardVersionCommand) bool {
+	selectors := 0
+	for _, set := range []bool{cmd.Version != 0, cmd.VersionTag != "", !cmd.BeforeTimestamp.IsZero()} {
+		if set {
+			selectors++
+		}
+	}
+	return selectors == 1
+}
+
+// restoreDashboardVersion restores the dashboard to the version selected by
+// the command and returns the body of the save response, or the response to
+// return when the dashboard wasn't restored.
+func (hs *HTTPServer) restoreDashboardVersion(c *contextmodel.ReqContext, dash *dashboards.Dashboard, apiCmd dtos.RestoreDashboardVersionCommand) (util.DynMap, response.Response) {
 	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
 	if err != nil {
-		return response.Err(err)
+		return nil, response.Err(err)
 	}
 
 	if canSave, err := guardian.CanSave(); err != nil || !canSave {
-		return dashboardGuardianResponse(err)
+		return nil, dashboardGuardianResponse(err)
 	}
 
-	versionQuery := dashver.GetDashboardVersionQuery{DashboardID: dashID, DashboardUID: dash.UID, Version: apiCmd.Version, OrgID: c.SignedInUser.GetOrgID()}
+	versionQuery := dashver.GetDashboardVersionQuery{DashboardUID: dash.UID, Version: apiCmd.Version, VersionTag: apiCmd.VersionTag, CreatedBefore: apiCmd.BeforeTimestamp, OrgID: c.SignedInUser.GetOrgID()}
 	version, err := hs.dashboardVersionService.Get(c.Req.Context(), &versionQuery)
 	if err != nil {
-		return response.Error(http.StatusNotFound, "Dashboard version not found", nil)
+		if !apiCmd.BeforeTimestamp.IsZero() {
+			return nil, response.Error(http.StatusNotFound, fmt.Sprintf("No dashboard version created at or before %s", apiCmd.BeforeTimestamp.Format(time.RFC3339)), err)
+		}
+		return nil, response.Error(http.StatusNotFound, "Dashboard version not found", err)
 	}
 
 	userID := int64(0)
@@ -1039,7 +2458,67 @@ func (hs *HTTPServer) RestoreDashboardVersion(c *contextmodel.ReqContext) respon
 	saveCmd.FolderID = dash.FolderID
 	saveCmd.FolderUID = dash.FolderUID
 
-	return hs.postDashboard(c, saveCmd)
+	if !apiCmd.PreserveCurrent {
+		return hs.saveDashboard(c, saveCmd)
+	}
+
+	snapshot, rsp := hs.saveDashboardSnapshot(c, dash, fmt.Sprintf("Snapshot before restoring v%d", version.Version))
+	if rsp != nil {
+		return nil, rsp
+	}
+
+	saveCmd.Dashboard.Set("version", snapshot.Version)
+	result, rsp := hs.saveDashboard(c, saveCmd)
+	if rsp != nil {
+		return nil, rsp
+	}
+	result["snapshotVersion"] = snapshot.Version
+	return result, nil
+}
+
+// saveDashboardSnapshot stores the current body of the dashboard as a new
+// version ahead of restoring an older one. Only the version is written: the
+// snapshot isn't audited nor broadcast, and the version cap and pruning are
+// left to the restore, which already holds the version it restores.
+func (hs *HTTPServer) saveDashboardSnapshot(c *contextmodel.ReqContext, dash *dashboards.Dashboard, message string) (*dashboards.Dashboard, response.Response) {
+	ctx := c.Req.Context()
+	current, err := cloneDashboardJSON(dash.Data)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Failed to snapshot dashboard", err)
+	}
+	current.Set("id", dash.ID)
+	current.Set("uid", dash.UID)
+	current.Set("version", dash.Version)
+	cmd := dashboards.SaveDashboardCommand{
+		Dashboard: current,
+		OrgID:     c.SignedInUser.GetOrgID(),
+		// nolint:staticcheck
+		FolderID:  dash.FolderID,
+		FolderUID: dash.FolderUID,
+	}
+
+	provisioningData, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(ctx, dash.ID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Error while checking if dashboard is provisioned using ID", err)
+	}
+	allowUiUpdate := true
+	if provisioningData != nil {
+		allowUiUpdate = hs.ProvisioningService.GetAllowUIUpdatesFromConfig(provisioningData.Name)
+	}
+
+	dashItem := &dashboards.SaveDashboardDTO{
+		Dashboard: cmd.GetDashboardModel(),
+		Message:   message,
+		OrgID:     c.SignedInUser.GetOrgID(),
+		User:      c.SignedInUser,
+		Source:    dashboardVersionSource(c),
+	}
+	snapshot, err := hs.DashboardService.SaveDashboard(alerting.WithUAEnabled(ctx, hs.Cfg.UnifiedAlerting.IsEnabled()), dashItem, allowUiUpdate)
+	if err != nil {
+		return nil, apierrors.ToDashboardErrorResponse(ctx, hs.pluginStore, err)
+	}
+	hs.dashboardIndex.update(snapshot)
+	return snapshot, nil
 }
 
 // swagger:route GET /dashboards/tags dashboards getDashboardTags
@@ -1137,6 +2616,19 @@ type GetDashboardVersionByUIDParams struct {
 	UID string `json:"uid"`
 }
 
+// swagger:parameters tagDashboardVersion
+type TagDashboardVersionParams struct {
+	// in:body
+	// required:true
+	Body dtos.TagDashboardVersionCommand
+	// in:path
+	// required:true
+	DashboardVersionID int64
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
 // swagger:parameters getDashboardVersions getDashboardVersionsByUID
 type GetDashboardVersionsParams struct {
 	// Maximum number of results to return
@@ -1150,6 +2642,27 @@ type GetDashboardVersionsParams struct {
 	// required:false
 	// default:0
 	Start int `json:"start"`
+
+	// Only return versions saved by this user, given by id or login
+	// in:query
+	// required:false
+	CreatedBy string `json:"createdBy"`
+
+	// Only return versions created at or after this time, in epoch milliseconds
+	// in:query
+	// required:false
+	From int64 `json:"from"`
+
+	// Only return versions created at or before this time, in epoch milliseconds
+	// in:query
+	// required:false
+	To int64 `json:"to"`
+
+	// Set to data to return the dashboard JSON of each version, requires a limit of at most 20
+	// in:query
+	// required:false
+	// enum: data
+	Include string `json:"include"`
 }
 
 // swagger:parameters getDashboardByUID
@@ -1157,6 +2670,63 @@ type GetDashboardByUIDParams struct {
 	// in:path
 	// required:true
 	UID string `json:"uid"`
+
+	// Which panel descriptions to return, based on whether they were generated or written manually
+	// in:query
+	// required:false
+	// enum: all,auto,manual,none
+	// default: all
+	Descriptions string `json:"descriptions"`
+
+	// Comma separated parts of the response to return: meta, dashboard or top level properties of the
+	// dashboard such as title. The whole response is returned when empty.
+	// in:query
+	// required:false
+	Fields string `json:"fields"`
+
+	// Dotted path of the dashboard value to return, with array elements selected by index, e.g.
+	// panels[0].datasource. Takes precedence over fields.
+	// in:query
+	// required:false
+	JSONPath string `json:"jsonPath"`
+
+	// Include the view count, last view time and star count of the dashboard in the meta.
+	// in:query
+	// required:false
+	WithUsage bool `json:"withUsage"`
+
+	// Include the panel count, the panels per data source type and the template variable names in the meta.
+	// in:query
+	// required:false
+	WithStats bool `json:"withStats"`
+
+	// Replace the panels using data sources the user can't query with a placeholder panel.
+	// in:query
+	// required:false
+	RedactNoAccess bool `json:"redactNoAccess"`
+
+	// List the permissions granting the Can* flags of the meta and where they come from.
+	// in:query
+	// required:false
+	ExplainPermissions bool `json:"explainPermissions"`
+
+	// Return the dashboard in its portable export form instead of the dashboard with its meta.
+	// in:query
+	// required:false
+	Export bool `json:"export"`
+
+	// Format of the response, json or yaml. Defaults to the format asked for by the Accept header.
+	// in:query
+	// required:false
+	// enum: json,yaml
+	Format string `json:"format"`
+
+	// How library panels are exported, only used with export=true.
+	// in:query
+	// required:false
+	// enum: inline,refs
+	// default: refs
+	LibraryPanels string `json:"libraryPanels"`
 }
 
 // swagger:parameters deleteDashboardByUID
@@ -1164,6 +2734,57 @@ type DeleteDashboardByUIDParams struct {
 	// in:path
 	// required:true
 	UID string `json:"uid"`
+	// Confirm deleting a protected dashboard.
+	// in:query
+	// required:false
+	Confirm bool `json:"confirm"`
+}
+
+// swagger:parameters getDashboardChangedSince
+type GetDashboardChangedSinceParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:path
+	// required:true
+	Version int `json:"version"`
+	// Content hash returned by an earlier call
+	// in:query
+	// required:false
+	Hash string `json:"hash"`
+}
+
+// swagger:response dashboardChangedSinceResponse
+type DashboardChangedSinceResponse struct {
+	// in: body
+	Body dtos.DashboardChangedSince `json:"body"`
+}
+
+// swagger:parameters validateDashboard
+type ValidateDashboardParams struct {
+	// in:body
+	// required:true
+	Body dashboards.ValidateDashboardCommand
+	// Migrate a dashboard with an old schemaVersion before validating it.
+	// in:query
+	// required:false
+	Migrate bool `json:"migrate"`
+	// Check that the data sources referenced by panels exist.
+	// in:query
+	// required:false
+	CheckDatasources bool `json:"checkDatasources"`
+	// Uid of a provisioned dashboard to compare the dashboard with.
+	// in:query
+	// required:false
+	ProvisionedUID string `json:"provisionedUid"`
+	// Report a schemaVersion below the supported one as a warning instead of failing.
+	// in:query
+	// required:false
+	SkipSchemaVersion bool `json:"skipSchemaVersion"`
+	// Report panels and transformations needing a disabled feature flag as errors instead of warnings.
+	// in:query
+	// required:false
+	StrictFeatureFlags bool `json:"strictFeatureFlags"`
 }
 
 // swagger:parameters postDashboard
@@ -1171,6 +2792,111 @@ type PostDashboardParams struct {
 	// in:body
 	// required:true
 	Body dashboards.SaveDashboardCommand
+	// Save the dashboard as a new copy.
+	// in:query
+	// required:false
+	Copy bool `json:"copy"`
+	// Save a new version even when the dashboard is unchanged.
+	// in:query
+	// required:false
+	Force bool `json:"force"`
+	// Save only when the stored dashboard matches this ETag or version.
+	// in:header
+	// required:false
+	IfMatch string `json:"If-Match"`
+	// Include the dashboard quota usage of the org and user in the response.
+	// in:query
+	// required:false
+	IncludeQuota bool `json:"includeQuota"`
+	// Include a summary of the changes compared to the previous version in the response.
+	// in:query
+	// required:false
+	SummarizeChanges bool `json:"summarizeChanges"`
+}
+
+// swagger:parameters calculateDashboardOriginDiff
+type CalculateDashboardOriginDiffParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// The type of diff to return, the configured default when not given
+	// Description:
+	// * `basic`
+	// * `json`
+	// * `delta`
+	// * `semantic`
+	// in:query
+	// required:false
+	// Enum: basic,json,delta,semantic
+	DiffType string `json:"diffType"`
+}
+
+// swagger:parameters calculateDashboardVersionsDiff
+type CalculateDashboardVersionsDiffParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// The base version of the diff
+	// in:path
+	// required:true
+	Base int `json:"base"`
+	// The new version of the diff
+	// in:path
+	// required:true
+	New int `json:"new"`
+	// The type of diff to return, the configured default when not given
+	// Description:
+	// * `basic`
+	// * `json`
+	// * `delta`
+	// * `semantic`
+	// in:query
+	// required:false
+	// Enum: basic,json,delta,semantic
+	Type string `json:"type"`
+	// Alias of type
+	// in:query
+	// required:false
+	// Enum: basic,json,delta,semantic
+	DiffType string `json:"diffType"`
+}
+
+// swagger:parameters calculateDashboardCurrentDiff
+type CalculateDashboardCurrentDiffParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// The version to diff against the current dashboard
+	// in:path
+	// required:true
+	DashboardVersionID int64
+	// The type of diff to return, the configured default when not given
+	// Description:
+	// * `basic`
+	// * `json`
+	// * `delta`
+	// * `semantic`
+	// in:query
+	// required:false
+	// Enum: basic,json,delta,semantic
+	DiffType string `json:"diffType"`
+}
+
+// swagger:parameters calculateDashboardProvisionedDiff
+type CalculateDashboardProvisionedDiffParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// The type of diff to return, the configured default when not given
+	// Description:
+	// * `basic`
+	// * `json`
+	// * `delta`
+	// * `semantic`
+	// in:query
+	// required:false
+	// Enum: basic,json,delta,semantic
+	DiffType string `json:"diffType"`
 }
 
 // swagger:parameters calculateDashboardDiff
@@ -1184,8 +2910,10 @@ type CalcDashboardDiffParams struct {
 		// Description:
 		// * `basic`
 		// * `json`
-		// Enum: basic,json
-		DiffType string `json:"diffType" binding:"Required"`
+		// * `semantic` a JSON array of changes to the dashboard properties, panels, queries and variables
+		// When not given, the configured default diff type is used.
+		// Enum: basic,json,semantic
+		DiffType string `json:"diffType"`
 	}
 }
 
@@ -1255,6 +2983,33 @@ type PostDashboardResponse struct {
 		// FolderUID The unique identifier (uid) of the folder the dashboard belongs to.
 		// required: false
 		FolderUID string `json:"folderUid"`
+
+		// Unchanged is true when the dashboard was not saved because it didn't change.
+		// required: false
+		Unchanged bool `json:"unchanged,omitempty"`
+
+		// ConsistencyToken To send in the X-Grafana-Consistency-Token header of a get dashboard request
+		// to read at least this version.
+		// required: true
+		ConsistencyToken string `json:"consistencyToken"`
+
+		// Quota The dashboard quota usage, only set when includeQuota is true.
+		// required: false
+		Quota *dtos.DashboardQuotaUsage `json:"quota,omitempty"`
+
+		// SnapshotVersion The version the dashboard was saved as before being restored, only set when
+		// restoring with preserveCurrent.
+		// required: false
+		SnapshotVersion int64 `json:"snapshotVersion,omitempty"`
+
+		// Warnings The library panels the save disconnected from the dashboard because it no longer uses them.
+		// required: false
+		Warnings []dtos.DashboardSaveWarning `json:"warnings,omitempty"`
+
+		// ChangeSummary The changes compared to the previous version, only set when updating a dashboard
+		// with summarizeChanges.
+		// required: false
+		ChangeSummary *dashdiffs.ChangeSummary `json:"changeSummary,omitempty"`
 	} `json:"body"`
 }
 
@@ -1264,6 +3019,15 @@ type CalculateDashboardDiffResponse struct {
 	Body []byte `json:"body"`
 }
 
+// swagger:parameters getHomeDashboard
+type GetHomeDashboardParams struct {
+	// Redirect to a home dashboard set in the preferences, or to the configured home page, instead of
+	// returning its url.
+	// in:query
+	// required:false
+	Redirect bool `json:"redirect"`
+}
+
 // swagger:response getHomeDashboardResponse
 type GetHomeDashboardResponse struct {
 	// in: body
@@ -1304,4 +3068,26 @@ type DashboardVersionResponse struct {
 type ValidateDashboardResponse struct {
 	IsValid bool   `json:"isValid"`
 	Message string `json:"message,omitempty"`
+	// Errors lists every problem found, empty for a valid dashboard.
+	Errors []DashboardValidationError `json:"errors"`
+	// Warnings lists the problems that don't make the dashboard invalid,
+	// such as differences with the provisioned dashboard.
+	Warnings []DashboardValidationError `json:"warnings,omitempty"`
+	// Dashboard is the migrated dashboard, only set when migrate is true
+	// and the dashboard had to be migrated.
+	Dashboard *simplejson.Json `json:"dashboard,omitempty"`
+	// Migrations lists the schema migrations applied to the dashboard.
+	Migrations []DashboardMigrationStep `json:"migrations,omitempty"`
+}
+
+type DashboardValidationError struct {
+	// Path is the JSON path of the offending field, e.g. panels[3].type.
+	// It is empty when the problem can't be attributed to a single field.
+	Path string `json:"path"`
+	// Code identifies the kind of problem, one of invalidSchemaVersion,
+	// schemaViolation, emptyTitle, unknownPanelType, unknownDataSource and
+	// featureFlagDisabled, or for warnings olderThanProvisioned,
+	// provisionedMismatch and featureFlagDisabled.
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
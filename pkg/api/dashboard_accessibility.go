@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	a11ySeverityError   = "error"
+	a11ySeverityWarning = "warning"
+	a11ySeverityInfo    = "info"
+)
+
+// Accessibility rules checked on every panel.
+const (
+	a11yRuleMissingTitle       = "missingTitle"
+	a11yRuleColorOnly          = "colorOnlyThresholds"
+	a11yRuleMissingUnit        = "missingUnit"
+	a11yRuleImageWithoutAlt    = "imageWithoutAlt"
+	a11yRuleMissingDescription = "missingDescription"
+)
+
+// numericPanelTypes are the panel plugins that display numeric values, for
+// which a unit is needed to understand the value.
+var numericPanelTypes = map[string]bool{
+	"timeseries": true,
+	"graph":      true,
+	"stat":       true,
+	"gauge":      true,
+	"bargauge":   true,
+	"barchart":   true,
+}
+
+var (
+	// markdownImageWithoutAlt matches markdown images with an empty alt text, e.g. ![](logo.png).
+	markdownImageWithoutAlt = regexp.MustCompile(`!\[\s*\]\(`)
+	htmlImage               = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlImageAlt            = regexp.MustCompile(`(?i)\balt\s*=\s*["'][^"']+["']`)
+)
+
+// swagger:route GET /dashboards/uid/{uid}/a11y dashboards getDashboardAccessibility
+//
+// Audit the accessibility of a dashboard.
+//
+// Checks the panels of the dashboard for common accessibility problems, such as panels without a title,
+// thresholds conveyed only by color, values without a unit and images without alternative text.
+//
+// Responses:
+// 200: dashboardAccessibilityResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardAccessibility(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	return response.JSON(http.StatusOK, dtos.DashboardAccessibilityReport{
+		UID:      dash.UID,
+		Title:    dash.Title,
+		Findings: auditDashboardAccessibility(dash.Data),
+	})
+}
+
+func auditDashboardAccessibility(data *simplejson.Json) []dtos.DashboardAccessibilityFinding {
+	findings := make([]dtos.DashboardAccessibilityFinding, 0)
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		panelType := panel.Get("type").MustString()
+		if panelType == "row" {
+			return
+		}
+
+		add := func(rule, severity, message string) {
+			findings = append(findings, dtos.DashboardAccessibilityFinding{
+				PanelID:    panel.Get("id").MustInt64(),
+				PanelTitle: panel.Get("title").MustString(),
+				Rule:       rule,
+				Severity:   severity,
+				Message:    message,
+			})
+		}
+
+		if panel.Get("title").MustString() == "" {
+			add(a11yRuleMissingTitle, a11ySeverityError, "Panel has no title to identify it for screen reader users")
+		}
+
+		defaults := panel.GetPath("fieldConfig", "defaults")
+		steps := defaults.GetPath("thresholds", "steps").MustArray()
+		if len(steps) > 1 && len(defaults.Get("mappings").MustArray()) == 0 &&
+			(defaults.GetPath("color", "mode").MustString() == "thresholds" || panelType == "stat" || panelType == "gauge" || panelType == "bargauge") {
+			add(a11yRuleColorOnly, a11ySeverityWarning, "Thresholds are conveyed by color only, add value mappings with text")
+		}
+
+		if numericPanelTypes[panelType] && defaults.Get("unit").MustString() == "" {
+			add(a11yRuleMissingUnit, a11ySeverityWarning, "Values are shown without a unit")
+		}
+
+		if panelType == "text" {
+			content := panel.GetPath("options", "content").MustString(panel.Get("content").MustString())
+			hasImageWithoutAlt := markdownImageWithoutAlt.MatchString(content)
+			for _, img := range htmlImage.FindAllString(content, -1) {
+				if !htmlImageAlt.MatchString(img) {
+					hasImageWithoutAlt = true
+				}
+			}
+			if hasImageWithoutAlt {
+				add(a11yRuleImageWithoutAlt, a11ySeverityError, "Images have no alternative text")
+			}
+		}
+
+		if panel.Get("description").MustString() == "" && panelType != "text" {
+			add(a11yRuleMissingDescription, a11ySeverityInfo, "Panel has no description explaining what it shows")
+		}
+	})
+	return findings
+}
+
+// swagger:parameters getDashboardAccessibility
+type GetDashboardAccessibilityParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardAccessibilityResponse
+type DashboardAccessibilityResponse struct {
+	// in: body
+	Body dtos.DashboardAccessibilityReport `json:"body"`
+}
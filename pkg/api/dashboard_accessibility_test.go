@@ -0,0 +1,33 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestAuditDashboardAccessibility(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"panels": [
+			{"id": 1, "type": "stat", "fieldConfig": {"defaults": {"thresholds": {"steps": [{"color": "green"}, {"color": "red", "value": 80}]}}}},
+			{"id": 2, "type": "timeseries", "title": "Latency", "description": "p99 latency", "fieldConfig": {"defaults": {"unit": "ms"}}},
+			{"id": 3, "type": "row", "panels": [
+				{"id": 4, "type": "text", "title": "Docs", "options": {"content": "![](logo.png) <img src=\"a.png\" alt=\"diagram\">"}}
+			]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	rules := map[int64][]string{}
+	for _, finding := range auditDashboardAccessibility(data) {
+		rules[finding.PanelID] = append(rules[finding.PanelID], finding.Rule)
+	}
+
+	assert.Equal(t, []string{a11yRuleMissingTitle, a11yRuleColorOnly, a11yRuleMissingUnit, a11yRuleMissingDescription}, rules[1])
+	assert.Empty(t, rules[2])
+	assert.Empty(t, rules[3])
+	assert.Equal(t, []string{a11yRuleImageWithoutAlt}, rules[4])
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboardaudit"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+)
+
+// auditDashboard records a mutation of the dashboard by the signed in user
+// through the dashboard audit sink. Servers without a sink record nothing.
+func (hs *HTTPServer) auditDashboard(c *contextmodel.ReqContext, action string, dash *dashboards.Dashboard, previousVersion, version int) {
+	if hs.DashboardAuditSink == nil {
+		return
+	}
+
+	entry := dashboardaudit.Entry{
+		Time:            time.Now(),
+		Action:          action,
+		OrgID:           c.SignedInUser.GetOrgID(),
+		UserLogin:       c.SignedInUser.GetLogin(),
+		DashboardUID:    dash.UID,
+		PreviousVersion: previousVersion,
+		Version:         version,
+		SourceIP:        c.RemoteAddr(),
+	}
+	if namespaceID, userIDStr := c.SignedInUser.GetNamespacedID(); namespaceID == identity.NamespaceUser || namespaceID == identity.NamespaceServiceAccount {
+		entry.UserID, _ = identity.IntIdentifier(namespaceID, userIDStr)
+	}
+	hs.DashboardAuditSink.Record(c.Req.Context(), entry)
+}
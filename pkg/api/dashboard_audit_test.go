@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboardaudit"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/api"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+type recordingAuditSink struct {
+	entries []dashboardaudit.Entry
+}
+
+func (s *recordingAuditSink) Record(_ context.Context, entry dashboardaudit.Entry) {
+	s.entries = append(s.entries, entry)
+}
+
+func TestDashboardAudit(t *testing.T) {
+	existing := dashboards.NewDashboard("Dash")
+	existing.ID = 1
+	existing.UID = "dash"
+	existing.OrgID = 1
+	existing.Version = 4
+	existing.Data = simplejson.NewFromAny(map[string]any{"id": 1, "uid": "dash", "title": "Dash", "version": 4})
+
+	sink := &recordingAuditSink{}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(existing, nil).Maybe()
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, dto *dashboards.SaveDashboardDTO, _ bool) *dashboards.Dashboard {
+			if dto.Dashboard.ID == 0 {
+				return &dashboards.Dashboard{ID: 2, UID: "new", OrgID: 1, Version: 1}
+			}
+			return &dashboards.Dashboard{ID: 1, UID: "dash", OrgID: 1, Version: 5}
+		}, nil).Maybe()
+		dashSvc.On("DeleteDashboard", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{
+			ExpectedDashboardVersion: &dashver.DashboardVersionDTO{DashboardID: 1, Version: 2, Data: simplejson.NewFromAny(map[string]any{"id": 1, "title": "Dash"})},
+		}
+
+		pubDashService := publicdashboards.NewFakePublicDashboardService(t)
+		pubDashService.On("DeleteByDashboard", mock.Anything, mock.Anything).Return(nil).Maybe()
+		hs.PublicDashboardsApi = api.ProvideApi(pubDashService, nil, hs.AccessControl, featuremgmt.WithFeatures())
+
+		hs.DashboardAuditSink = sink
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	usr := userWithPermissions(1, []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsCreate, Scope: dashboards.ScopeFoldersAll},
+		{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+		{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+		{Action: dashboards.ActionDashboardsDelete, Scope: dashboards.ScopeDashboardsAll},
+	})
+	usr.IsAnonymous = false
+	usr.UserID = 7
+	usr.Login = "editor"
+
+	send := func(t *testing.T, req *http.Request) {
+		t.Helper()
+		req.Header.Set("X-Real-IP", "10.0.0.1")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, usr))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	}
+	lastEntry := func(t *testing.T) dashboardaudit.Entry {
+		t.Helper()
+		require.NotEmpty(t, sink.entries)
+		return sink.entries[len(sink.entries)-1]
+	}
+
+	t.Run("should audit created dashboards", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboards/db", strings.NewReader(`{"dashboard": {"title": "New"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		send(t, req)
+
+		entry := lastEntry(t)
+		assert.Equal(t, dashboardaudit.ActionCreated, entry.Action)
+		assert.Equal(t, "new", entry.DashboardUID)
+		assert.EqualValues(t, 1, entry.OrgID)
+		assert.EqualValues(t, 7, entry.UserID)
+		assert.Equal(t, "editor", entry.UserLogin)
+		assert.Equal(t, 0, entry.PreviousVersion)
+		assert.Equal(t, 1, entry.Version)
+		assert.Equal(t, "10.0.0.1", entry.SourceIP)
+	})
+
+	t.Run("should audit updated dashboards", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboards/db", strings.NewReader(`{"dashboard": {"id": 1, "uid": "dash", "title": "Renamed", "version": 4}}`))
+		req.Header.Set("Content-Type", "application/json")
+		send(t, req)
+
+		entry := lastEntry(t)
+		assert.Equal(t, dashboardaudit.ActionUpdated, entry.Action)
+		assert.Equal(t, "dash", entry.DashboardUID)
+		assert.Equal(t, 4, entry.PreviousVersion)
+		assert.Equal(t, 5, entry.Version)
+	})
+
+	t.Run("should audit restored dashboards", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboards/uid/dash/restore", strings.NewReader(`{"version": 2}`))
+		req.Header.Set("Content-Type", "application/json")
+		send(t, req)
+
+		entry := lastEntry(t)
+		assert.Equal(t, dashboardaudit.ActionRestored, entry.Action)
+		assert.Equal(t, 4, entry.PreviousVersion)
+		assert.Equal(t, 5, entry.Version)
+	})
+
+	t.Run("should audit deleted dashboards", func(t *testing.T) {
+		send(t, server.NewRequest(http.MethodDelete, "/api/dashboards/uid/dash", nil))
+
+		entry := lastEntry(t)
+		assert.Equal(t, dashboardaudit.ActionDeleted, entry.Action)
+		assert.Equal(t, "dash", entry.DashboardUID)
+		assert.Equal(t, 4, entry.PreviousVersion)
+		assert.Equal(t, 0, entry.Version)
+	})
+}
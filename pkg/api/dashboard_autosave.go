@@ -0,0 +1,226 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboarddraft"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// minAutosaveInterval is how long a user has to wait between two autosaves of
+// the same dashboard.
+const minAutosaveInterval = 5 * time.Second
+
+// swagger:route POST /dashboards/uid/{uid}/autosave dashboards autosaveDashboard
+//
+// Autosave a dashboard.
+//
+// Stores a draft of the dashboard for the signed in user, replacing their previous draft. Drafts are kept
+// apart from the dashboard versions and don't change the saved dashboard. Autosaves of the same dashboard
+// are accepted at most every 5 seconds, earlier ones get a 429 with a Retry-After header.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 429: tooManyRequestsError
+// 500: internalServerError
+func (hs *HTTPServer) AutosaveDashboard(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.AutosaveDashboardCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	dash, userID, rsp := hs.getAutosaveDashboard(c)
+	if rsp != nil {
+		return rsp
+	}
+
+	previous, ok, err := hs.draftStore.Get(c.Req.Context(), dash.OrgID, dash.UID, userID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard autosave", err)
+	}
+	if ok {
+		if wait := minAutosaveInterval - time.Since(previous.Updated); wait > 0 {
+			retryAfter := strconv.Itoa(int(wait.Round(time.Second).Seconds()))
+			return response.Error(http.StatusTooManyRequests, "Dashboard was autosaved too recently", nil).SetHeader("Retry-After", retryAfter)
+		}
+	}
+
+	draft := &dashboarddraft.Draft{
+		Dashboard: cmd.Dashboard,
+		Version:   cmd.Dashboard.Get("version").MustInt(dash.Version),
+		Updated:   time.Now(),
+	}
+	if err := hs.draftStore.Set(c.Req.Context(), dash.OrgID, dash.UID, userID, draft); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to autosave dashboard", err)
+	}
+	return response.Success("Dashboard autosaved")
+}
+
+// swagger:route GET /dashboards/uid/{uid}/autosave dashboards getDashboardAutosave
+//
+// Get the autosaved draft of a dashboard.
+//
+// Returns the draft of the dashboard autosaved by the signed in user.
+//
+// Responses:
+// 200: dashboardAutosaveResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardAutosave(c *contextmodel.ReqContext) response.Response {
+	dash, userID, rsp := hs.getAutosaveDashboard(c)
+	if rsp != nil {
+		return rsp
+	}
+
+	draft, rsp := hs.getDashboardDraft(c, dash, userID)
+	if rsp != nil {
+		return rsp
+	}
+	return response.JSON(http.StatusOK, dtos.DashboardAutosave{
+		Dashboard: draft.Dashboard,
+		Version:   draft.Version,
+		Updated:   draft.Updated,
+	})
+}
+
+// swagger:route POST /dashboards/uid/{uid}/autosave/promote dashboards promoteDashboardAutosave
+//
+// Save the autosaved draft of a dashboard.
+//
+// Saves the draft of the signed in user as a new version of the dashboard, the same way as saving the
+// dashboard does, and removes the draft. Saving fails with a 412 when the dashboard changed since the
+// version the draft is based on, unless overwrite is set.
+//
+// Responses:
+// 200: postDashboardResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 412: preconditionFailedError
+// 422: unprocessableEntityError
+// 500: internalServerError
+func (hs *HTTPServer) PromoteDashboardAutosave(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.PromoteDashboardAutosaveCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	dash, userID, rsp := hs.getAutosaveDashboard(c)
+	if rsp != nil {
+		return rsp
+	}
+	draft, rsp := hs.getDashboardDraft(c, dash, userID)
+	if rsp != nil {
+		return rsp
+	}
+
+	data := draft.Dashboard
+	data.Set("id", dash.ID)
+	data.Set("uid", dash.UID)
+	data.Set("version", draft.Version)
+	message := cmd.Message
+	if message == "" {
+		message = fmt.Sprintf("Saved autosave from %s", draft.Updated.Format(time.RFC3339))
+	}
+
+	rsp = hs.postDashboard(c, dashboards.SaveDashboardCommand{
+		Dashboard: data,
+		FolderUID: dash.FolderUID,
+		Message:   message,
+		Overwrite: cmd.Overwrite,
+	})
+	if rsp.Status() != http.StatusOK {
+		return rsp
+	}
+
+	if err := hs.draftStore.Delete(c.Req.Context(), dash.OrgID, dash.UID, userID); err != nil {
+		hs.log.Warn("Failed to delete saved dashboard autosave", "dashboard", dash.UID, "error", err)
+	}
+	return rsp
+}
+
+// getAutosaveDashboard returns the dashboard of the uid route parameter and
+// the id of the signed in user, provided the user may save the dashboard.
+func (hs *HTTPServer) getAutosaveDashboard(c *contextmodel.ReqContext) (*dashboards.Dashboard, int64, response.Response) {
+	namespaceID, userIDStr := c.SignedInUser.GetNamespacedID()
+	if namespaceID != identity.NamespaceUser && namespaceID != identity.NamespaceServiceAccount {
+		return nil, 0, response.Error(http.StatusForbidden, "Autosave is only available to users", nil)
+	}
+	userID, err := identity.IntIdentifier(namespaceID, userIDStr)
+	if err != nil {
+		return nil, 0, response.Error(http.StatusInternalServerError, "Failed to parse user id", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return nil, 0, rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return nil, 0, response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return nil, 0, dashboardGuardianResponse(err)
+	}
+	return dash, userID, nil
+}
+
+func (hs *HTTPServer) getDashboardDraft(c *contextmodel.ReqContext, dash *dashboards.Dashboard, userID int64) (*dashboarddraft.Draft, response.Response) {
+	draft, ok, err := hs.draftStore.Get(c.Req.Context(), dash.OrgID, dash.UID, userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Failed to get dashboard autosave", err)
+	}
+	if !ok {
+		return nil, response.Error(http.StatusNotFound, "No autosave found for this dashboard", nil)
+	}
+	return draft, nil
+}
+
+// swagger:parameters autosaveDashboard
+type AutosaveDashboardParams struct {
+	// in:body
+	// required:true
+	Body dtos.AutosaveDashboardCommand
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:parameters getDashboardAutosave
+type GetDashboardAutosaveParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:parameters promoteDashboardAutosave
+type PromoteDashboardAutosaveParams struct {
+	// in:body
+	// required:true
+	Body dtos.PromoteDashboardAutosaveCommand
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardAutosaveResponse
+type DashboardAutosaveResponse struct {
+	// in: body
+	Body dtos.DashboardAutosave `json:"body"`
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboarddraft"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestDashboardAutosave(t *testing.T) {
+	dash := dashboards.NewDashboard("Dash")
+	dash.ID = 1
+	dash.UID = "dash"
+	dash.OrgID = 1
+	dash.FolderUID = "folder"
+	dash.Version = 4
+
+	store := dashboarddraft.NewStore(kvstore.NewFakeKVStore())
+	var saved []*dashboards.SaveDashboardDTO
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO))
+		}).Return(&dashboards.Dashboard{ID: 1, UID: "dash", Title: "Draft", FolderUID: "folder", Version: 5}, nil)
+		hs.DashboardService = dashSvc
+		hs.draftStore = store
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	usr := userWithPermissions(1, []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"},
+	})
+	usr.UserID, usr.IsAnonymous = 1, false
+	send := func(req *http.Request) *http.Response {
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, usr))
+		require.NoError(t, err)
+		return res
+	}
+	autosave := func(title string) *http.Response {
+		body := `{"dashboard": {"uid": "dash", "title": "` + title + `", "version": 4}}`
+		res := send(server.NewPostRequest("/api/dashboards/uid/dash/autosave", strings.NewReader(body)))
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("should store the draft of the user", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, autosave("Draft").StatusCode)
+
+		res := send(server.NewGetRequest("/api/dashboards/uid/dash/autosave"))
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var draft dtos.DashboardAutosave
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&draft))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, "Draft", draft.Dashboard.Get("title").MustString())
+		assert.Equal(t, 4, draft.Version)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("should reject autosaves in quick succession", func(t *testing.T) {
+		res := autosave("Too soon")
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.NotEmpty(t, res.Header.Get("Retry-After"))
+	})
+
+	t.Run("should save the draft as a new version", func(t *testing.T) {
+		draft := &dashboarddraft.Draft{
+			Dashboard: simplejson.NewFromAny(map[string]any{"title": "Draft"}),
+			Version:   4,
+			Updated:   time.Now().Add(-time.Minute),
+		}
+		require.NoError(t, store.Set(context.Background(), 1, "dash", 1, draft))
+
+		res := send(server.NewPostRequest("/api/dashboards/uid/dash/autosave/promote", strings.NewReader(`{"message": "From draft"}`)))
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, saved, 1)
+		assert.Equal(t, int64(1), saved[0].Dashboard.ID)
+		assert.Equal(t, "dash", saved[0].Dashboard.UID)
+		assert.Equal(t, 4, saved[0].Dashboard.Version)
+		assert.Equal(t, "folder", saved[0].Dashboard.FolderUID)
+		assert.Equal(t, "From draft", saved[0].Message)
+
+		res = send(server.NewGetRequest("/api/dashboards/uid/dash/autosave"))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -0,0 +1,554 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/metrics"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/util"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// Per dashboard outcomes of bulk dashboard operations.
+const (
+	bulkResultUpdated   = "updated"
+	bulkResultUnchanged = "unchanged"
+	bulkResultDryRun    = "dryRun"
+	bulkResultSkipped   = "skipped"
+	bulkResultFailed    = "failed"
+	bulkResultDeleted   = "deleted"
+	bulkResultForbidden = "forbidden"
+	bulkResultNotFound  = "notFound"
+	bulkResultFound     = "found"
+	bulkResultRestored  = "restored"
+	bulkResultImported  = "imported"
+)
+
+// swagger:route POST /dashboards/bulk-fix-time dashboards bulkFixDashboardTime
+//
+// Convert absolute dashboard time ranges to a relative range.
+//
+// Replaces the absolute time range of each selected dashboard with the given relative range and removes
+// absolute panel time overrides, saving every changed dashboard as a new version. Dashboards are selected
+// by uid or, when no uids are given, by a search query. With dryRun set nothing is saved.
+//
+// Responses:
+// 200: bulkDashboardResultsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) BulkFixDashboardTime(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.BulkFixTimeCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if !relativeTimeExpr.MatchString(cmd.From) || !relativeTimeExpr.MatchString(cmd.To) {
+		return response.Error(http.StatusBadRequest, "from and to must be relative time expressions such as now-6h", nil)
+	}
+
+	results, dashes, err := hs.bulkDashboards(c, cmd.DashboardUIDs, cmd.Query)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Failed to select dashboards", err)
+	}
+
+	for _, dash := range dashes {
+		result := hs.bulkUpdateDashboard(c, dash, cmd.DryRun, "Converted absolute time ranges to relative", func(data *simplejson.Json) bool {
+			return relativizeDashboardTime(data, cmd.From, cmd.To)
+		})
+		results = append(results, result)
+	}
+
+	return response.JSON(http.StatusOK, results)
+}
+
+// swagger:route POST /dashboards/bulk-delete dashboards bulkDeleteDashboards
+//
+// Delete dashboards by uid.
+//
+// Deletes each of the given dashboards the signed in user is allowed to delete, together with their public
+// dashboards. The outcome is reported per uid as deleted, forbidden, notFound or failed; a dashboard that
+// cannot be deleted does not stop the others from being deleted. Protected dashboards are skipped unless
+// `confirm=true` is given.
+//
+// Responses:
+// 200: bulkDashboardResultsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) BulkDeleteDashboards(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.BulkDeleteDashboardsCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if len(cmd.DashboardUIDs) == 0 {
+		return response.Error(http.StatusBadRequest, "dashboardUids is required", nil)
+	}
+
+	dashes, err := hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd.DashboardUIDs)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboards", err)
+	}
+	byUID := make(map[string]*dashboards.Dashboard, len(dashes))
+	for _, dash := range dashes {
+		byUID[dash.UID] = dash
+	}
+
+	userDTODisplay, err := user.NewUserDisplayDTOFromRequester(c.SignedInUser)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Error while parsing the user DTO model", err)
+	}
+
+	results := make([]dtos.BulkDashboardResult, 0, len(cmd.DashboardUIDs))
+	for _, uid := range cmd.DashboardUIDs {
+		dash, ok := byUID[uid]
+		if !ok {
+			results = append(results, dtos.BulkDashboardResult{UID: uid, Status: bulkResultNotFound, Message: "Dashboard not found"})
+			continue
+		}
+		// a uid listed twice is only deleted once
+		delete(byUID, uid)
+
+		result := hs.bulkDeleteDashboard(c, dash)
+		if result.Status == bulkResultDeleted && hs.Live != nil {
+			if err := hs.Live.GrafanaScope.Dashboards.DashboardDeleted(c.SignedInUser.GetOrgID(), userDTODisplay, dash.UID); err != nil {
+				hs.log.Error("Failed to broadcast delete info", "dashboard", dash.UID, "error", err)
+			}
+			hs.publishDashboardChange(c, dash, true)
+		}
+		results = append(results, result)
+	}
+
+	return response.JSON(http.StatusOK, results)
+}
+
+// swagger:route POST /dashboards/bulk-restore dashboards bulkRestoreDashboards
+//
+// Restore dashboards to given versions.
+//
+// Restores each of the given dashboards to its selected version the same way restoring a single dashboard
+// does, for instance to roll back a bad change to many dashboards. Every dashboard is checked on its own
+// for permission to save it. The outcome is reported per entry as restored, forbidden, notFound or failed;
+// a dashboard that cannot be restored does not stop the others from being restored.
+//
+// Responses:
+// 200: bulkDashboardResultsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) BulkRestoreDashboards(c *contextmodel.ReqContext) response.Response {
+	var entries []dtos.BulkRestoreDashboardEntry
+	if err := web.Bind(c.Req, &entries); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if len(entries) == 0 {
+		return response.Error(http.StatusBadRequest, "at least one dashboard is required", nil)
+	}
+	uids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !hasSingleVersionSelector(entry.RestoreDashboardVersionCommand) {
+			return response.Error(http.StatusBadRequest, fmt.Sprintf("dashboard %s: %s", entry.UID, errRestoreVersionSelector), nil)
+		}
+		uids = append(uids, entry.UID)
+	}
+
+	dashes, err := hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), uids)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboards", err)
+	}
+	byUID := make(map[string]*dashboards.Dashboard, len(dashes))
+	for _, dash := range dashes {
+		byUID[dash.UID] = dash
+	}
+
+	results := make([]dtos.BulkDashboardResult, 0, len(entries))
+	for _, entry := range entries {
+		dash, ok := byUID[entry.UID]
+		if !ok {
+			results = append(results, dtos.BulkDashboardResult{UID: entry.UID, Status: bulkResultNotFound, Message: "Dashboard not found"})
+			continue
+		}
+		results = append(results, hs.bulkRestoreDashboard(c, dash, entry.RestoreDashboardVersionCommand))
+	}
+
+	return response.JSON(http.StatusOK, results)
+}
+
+// swagger:route POST /dashboards/batch dashboards batchGetDashboards
+//
+// Get dashboards by uid.
+//
+// Returns the dashboard and meta of each of the given uids, in the order requested, checking the
+// permissions of the signed in user on every dashboard the same way getting a single dashboard does.
+// Dashboards that cannot be returned are reported as forbidden, notFound or failed instead. Requesting
+// more dashboards than the server allows returns a 413.
+//
+// Responses:
+// 200: batchGetDashboardsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 413: contentTooLargeError
+// 500: internalServerError
+func (hs *HTTPServer) BatchGetDashboards(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.BatchGetDashboardsCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if len(cmd.DashboardUIDs) == 0 {
+		return response.Error(http.StatusBadRequest, "dashboardUids is required", nil)
+	}
+	if limit := hs.Cfg.DashboardBatchMaxDashboards; limit > 0 && len(cmd.DashboardUIDs) > limit {
+		return response.Error(http.StatusRequestEntityTooLarge, fmt.Sprintf("At most %d dashboards can be requested at once", limit), nil)
+	}
+
+	dashes, err := hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd.DashboardUIDs)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboards", err)
+	}
+	byUID := make(map[string]*dashboards.Dashboard, len(dashes))
+	for _, dash := range dashes {
+		byUID[dash.UID] = dash
+	}
+
+	results := make([]dtos.BatchDashboardResult, 0, len(cmd.DashboardUIDs))
+	for _, uid := range cmd.DashboardUIDs {
+		dash, ok := byUID[uid]
+		if !ok {
+			results = append(results, dtos.BatchDashboardResult{UID: uid, Status: bulkResultNotFound, Message: "Dashboard not found"})
+			continue
+		}
+		results = append(results, hs.batchGetDashboard(c, dash))
+	}
+
+	c.TimeRequest(metrics.MApiDashboardGet)
+	return response.JSON(http.StatusOK, results)
+}
+
+// batchGetDashboard returns a single dashboard of a batch with its meta,
+// checking that the signed in user may view it the same way a single get does.
+func (hs *HTTPServer) batchGetDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard) dtos.BatchDashboardResult {
+	result := dtos.BatchDashboardResult{UID: dash.UID}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		result.Status, result.Message = bulkResultFailed, err.Error()
+		return result
+	}
+	if canView, err := guardian.CanView(); err != nil || !canView {
+		result.Status, result.Message = bulkResultForbidden, "Access denied to this dashboard"
+		return result
+	}
+
+	meta, rsp := hs.getDashboardMeta(c, dash, guardian)
+	if rsp != nil {
+		// the dashboard itself was found, e.g. its folder may be missing
+		result.Status, result.Message = bulkResultFailed, responseErrorMessage(rsp)
+		return result
+	}
+
+	// make sure db version is in sync with json model version
+	dash.Data.Set("version", dash.Version)
+
+	result.Status = bulkResultFound
+	result.DashboardFullWithMeta = &dtos.DashboardFullWithMeta{Dashboard: dash.Data, Meta: meta}
+	return result
+}
+
+// Actions that can be checked with CheckDashboardPermissions.
+const (
+	dashboardActionRead   = "read"
+	dashboardActionWrite  = "write"
+	dashboardActionDelete = "delete"
+	dashboardActionAdmin  = "admin"
+)
+
+// swagger:route POST /dashboards/permissions/check dashboards checkDashboardPermissions
+//
+// Check the permissions of the signed in user on dashboards.
+//
+// Returns for each of the given dashboard uids which of the given actions (read, write, delete and admin)
+// the signed in user is allowed to perform. The list is empty for dashboards that do not exist.
+//
+// Responses:
+// 200: checkDashboardPermissionsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) CheckDashboardPermissions(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.CheckDashboardPermissionsCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if len(cmd.DashboardUIDs) == 0 || len(cmd.Actions) == 0 {
+		return response.Error(http.StatusBadRequest, "dashboardUids and actions are required", nil)
+	}
+	for _, action := range cmd.Actions {
+		switch action {
+		case dashboardActionRead, dashboardActionWrite, dashboardActionDelete, dashboardActionAdmin:
+		default:
+			return response.Error(http.StatusBadRequest, fmt.Sprintf("unknown action %q", action), nil)
+		}
+	}
+
+	dashes, err := hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd.DashboardUIDs)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboards", err)
+	}
+
+	allowed := make(map[string][]string, len(cmd.DashboardUIDs))
+	for _, uid := range cmd.DashboardUIDs {
+		allowed[uid] = []string{}
+	}
+	for _, dash := range dashes {
+		guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+		if err != nil {
+			return response.Err(err)
+		}
+		for _, action := range cmd.Actions {
+			var ok bool
+			switch action {
+			case dashboardActionRead:
+				ok, err = guardian.CanView()
+			case dashboardActionWrite:
+				ok, err = guardian.CanSave()
+			case dashboardActionDelete:
+				ok, err = guardian.CanDelete()
+			case dashboardActionAdmin:
+				ok, err = guardian.CanAdmin()
+			}
+			if err != nil {
+				return response.Error(http.StatusInternalServerError, "Failed to check dashboard permissions", err)
+			}
+			if ok {
+				allowed[dash.UID] = append(allowed[dash.UID], action)
+			}
+		}
+	}
+
+	return response.JSON(http.StatusOK, allowed)
+}
+
+// bulkDeleteDashboard deletes a single dashboard of a bulk deletion, checking
+// that the signed in user may delete it the same way a single delete does.
+func (hs *HTTPServer) bulkDeleteDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard) dtos.BulkDashboardResult {
+	result := dtos.BulkDashboardResult{UID: dash.UID, Title: dash.Title, Version: dash.Version}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		result.Status, result.Message = bulkResultFailed, err.Error()
+		return result
+	}
+	if canDelete, err := guardian.CanDelete(); err != nil || !canDelete {
+		result.Status, result.Message = bulkResultForbidden, "Access denied to this dashboard"
+		return result
+	}
+	if !c.QueryBool("confirm") {
+		protected, err := hs.isDashboardProtected(c.Req.Context(), dash.OrgID, dash.UID)
+		if err != nil {
+			result.Status, result.Message = bulkResultFailed, err.Error()
+			return result
+		}
+		if protected {
+			result.Status, result.Message = bulkResultSkipped, "Dashboard is protected, delete it with confirm=true"
+			return result
+		}
+	}
+
+	if err := hs.removeDashboard(c, dash); err != nil {
+		result.Status, result.Message = bulkResultFailed, err.Error()
+		return result
+	}
+	result.Status = bulkResultDeleted
+	return result
+}
+
+// bulkRestoreDashboard restores a single dashboard of a bulk restore the same
+// way a single restore does.
+func (hs *HTTPServer) bulkRestoreDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard, cmd dtos.RestoreDashboardVersionCommand) dtos.BulkDashboardResult {
+	result := dtos.BulkDashboardResult{UID: dash.UID, Title: dash.Title, Version: dash.Version}
+
+	saved, rsp := hs.restoreDashboardVersion(c, dash, cmd)
+	if rsp != nil {
+		result.Status, result.Message = bulkResponseResult(rsp)
+		return result
+	}
+	result.Status = bulkResultRestored
+	result.Version, _ = saved["version"].(int)
+	return result
+}
+
+// bulkDashboards resolves the dashboards targeted by a bulk operation, given
+// either explicit uids or a search query. Uids that cannot be found are
+// returned as failed results.
+func (hs *HTTPServer) bulkDashboards(c *contextmodel.ReqContext, uids []string, query string) ([]dtos.BulkDashboardResult, []*dashboards.Dashboard, error) {
+	results := make([]dtos.BulkDashboardResult, 0)
+	if len(uids) == 0 {
+		if query == "" {
+			return nil, nil, errors.New("either dashboardUids or query is required")
+		}
+		dashes, err := hs.searchDashboards(c, &search.Query{Title: query, Permission: dashboards.PERMISSION_EDIT})
+		return results, dashes, err
+	}
+
+	dashes, err := hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), uids)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := make(map[string]bool, len(dashes))
+	for _, dash := range dashes {
+		found[dash.UID] = true
+	}
+	for _, uid := range uids {
+		if !found[uid] {
+			results = append(results, dtos.BulkDashboardResult{UID: uid, Status: bulkResultNotFound, Message: "Dashboard not found"})
+		}
+	}
+	return results, dashes, nil
+}
+
+// bulkUpdateDashboard applies mutate to the dashboard body and, unless in dry
+// run mode, saves the changed dashboard through the regular save path. The
+// signed in user must be allowed to save the dashboard.
+func (hs *HTTPServer) bulkUpdateDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard, dryRun bool, message string, mutate func(data *simplejson.Json) bool) dtos.BulkDashboardResult {
+	result := dtos.BulkDashboardResult{UID: dash.UID, Title: dash.Title, Version: dash.Version}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		result.Status, result.Message = bulkResultFailed, err.Error()
+		return result
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		result.Status, result.Message = bulkResultForbidden, "Access denied to this dashboard"
+		return result
+	}
+
+	if !mutate(dash.Data) {
+		result.Status = bulkResultUnchanged
+		return result
+	}
+	if dryRun {
+		result.Status = bulkResultDryRun
+		return result
+	}
+
+	saved, rsp := hs.saveDashboardChanges(c, dash, message)
+	if rsp != nil {
+		result.Status, result.Message = bulkResponseResult(rsp)
+		return result
+	}
+	result.Status = bulkResultUpdated
+	result.Version, _ = saved["version"].(int)
+	return result
+}
+
+// bulkResponseResult returns the status and message of the result of a
+// dashboard a bulk operation got an error response for.
+func bulkResponseResult(rsp response.Response) (string, string) {
+	status := bulkResultFailed
+	switch rsp.Status() {
+	case http.StatusForbidden:
+		status = bulkResultForbidden
+	case http.StatusNotFound:
+		status = bulkResultNotFound
+	}
+	return status, responseErrorMessage(rsp)
+}
+
+// responseErrorMessage returns the message of an error response without
+// decoding its body, falling back to the text of its status code.
+func responseErrorMessage(rsp response.Response) string {
+	if normal, ok := rsp.(*response.NormalResponse); ok && normal.ErrMessage() != "" {
+		return normal.ErrMessage()
+	}
+	return http.StatusText(rsp.Status())
+}
+
+// saveDashboardChanges stores the modified body of an existing dashboard as a
+// new version the same way a save from the dashboard editor would, returning
+// the body of the save response or the response to return when it wasn't
+// saved.
+func (hs *HTTPServer) saveDashboardChanges(c *contextmodel.ReqContext, dash *dashboards.Dashboard, message string) (util.DynMap, response.Response) {
+	cmd := dashboards.SaveDashboardCommand{
+		Dashboard: dash.Data,
+		Message:   message,
+		// nolint:staticcheck
+		FolderID:  dash.FolderID,
+		FolderUID: dash.FolderUID,
+	}
+	cmd.Dashboard.Set("id", dash.ID)
+	cmd.Dashboard.Set("uid", dash.UID)
+	cmd.Dashboard.Set("version", dash.Version)
+
+	return hs.saveDashboard(c, cmd)
+}
+
+// swagger:parameters bulkFixDashboardTime
+type BulkFixDashboardTimeParams struct {
+	// in:body
+	// required:true
+	Body dtos.BulkFixTimeCommand
+}
+
+// swagger:parameters bulkDeleteDashboards
+type BulkDeleteDashboardsParams struct {
+	// in:body
+	// required:true
+	Body dtos.BulkDeleteDashboardsCommand
+	// Also delete protected dashboards.
+	// in:query
+	// required:false
+	Confirm bool `json:"confirm"`
+}
+
+// swagger:parameters bulkRestoreDashboards
+type BulkRestoreDashboardsParams struct {
+	// in:body
+	// required:true
+	Body []dtos.BulkRestoreDashboardEntry
+}
+
+// swagger:parameters batchGetDashboards
+type BatchGetDashboardsParams struct {
+	// in:body
+	// required:true
+	Body dtos.BatchGetDashboardsCommand
+}
+
+// swagger:parameters checkDashboardPermissions
+type CheckDashboardPermissionsParams struct {
+	// in:body
+	// required:true
+	Body dtos.CheckDashboardPermissionsCommand
+}
+
+// swagger:response bulkDashboardResultsResponse
+type BulkDashboardResultsResponse struct {
+	// in: body
+	Body []dtos.BulkDashboardResult `json:"body"`
+}
+
+// swagger:response checkDashboardPermissionsResponse
+type CheckDashboardPermissionsResponse struct {
+	// The allowed actions by dashboard uid.
+	// in: body
+	Body map[string][]string `json:"body"`
+}
+
+// swagger:response batchGetDashboardsResponse
+type BatchGetDashboardsResponse struct {
+	// in: body
+	Body []dtos.BatchDashboardResult `json:"body"`
+}
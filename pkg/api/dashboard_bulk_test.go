@@ -0,0 +1,279 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/api"
+	"github.com/grafana/grafana/pkg/services/star/startest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestBulkDeleteDashboards(t *testing.T) {
+	newDash := func(uid string, id int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = id
+		dash.UID = uid
+		dash.OrgID = 1
+		return dash
+	}
+
+	var pubDashService *publicdashboards.FakePublicDashboardService
+	var dashSvc *dashboards.FakeDashboardService
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc = dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", 1), newDash("b", 2)}, nil)
+		dashSvc.On("DeleteDashboard", mock.Anything, int64(1), int64(1)).Return(nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.LibraryElementService = &mockLibraryElementService{}
+
+		pubDashService = publicdashboards.NewFakePublicDashboardService(t)
+		pubDashService.On("DeleteByDashboard", mock.Anything, mock.Anything).Return(nil)
+		hs.PublicDashboardsApi = api.ProvideApi(pubDashService, nil, hs.AccessControl, featuremgmt.WithFeatures())
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsDelete, Scope: "dashboards:uid:a"}}
+	req := server.NewPostRequest("/api/dashboards/bulk-delete", strings.NewReader(`{"dashboardUids": ["a", "b", "c"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var results []dtos.BulkDashboardResult
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&results))
+	require.NoError(t, res.Body.Close())
+
+	require.Len(t, results, 3)
+	assert.Equal(t, dtos.BulkDashboardResult{UID: "a", Title: "a", Status: bulkResultDeleted}, results[0])
+	assert.Equal(t, "b", results[1].UID)
+	assert.Equal(t, bulkResultForbidden, results[1].Status)
+	assert.Equal(t, "c", results[2].UID)
+	assert.Equal(t, bulkResultNotFound, results[2].Status)
+
+	dashSvc.AssertNumberOfCalls(t, "DeleteDashboard", 1)
+	pubDashService.AssertNumberOfCalls(t, "DeleteByDashboard", 1)
+}
+
+func TestCheckDashboardPermissions(t *testing.T) {
+	newDash := func(uid string, id int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = id
+		dash.UID = uid
+		dash.OrgID = 1
+		return dash
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", 1), newDash("b", 2)}, nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:b"},
+	}
+
+	t.Run("should return the allowed actions by uid", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboards/permissions/check", strings.NewReader(`{"dashboardUids": ["a", "b", "c"], "actions": ["read", "write", "delete"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var allowed map[string][]string
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&allowed))
+		require.NoError(t, res.Body.Close())
+
+		assert.Equal(t, map[string][]string{
+			"a": {"read", "write"},
+			"b": {"read"},
+			"c": {},
+		}, allowed)
+	})
+
+	t.Run("should reject unknown actions", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboards/permissions/check", strings.NewReader(`{"dashboardUids": ["a"], "actions": ["share"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestBatchGetDashboards(t *testing.T) {
+	newDash := func(uid string, id int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = id
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.Version = 2
+		dash.Data = simplejson.NewFromAny(map[string]any{"id": id, "uid": uid, "title": uid})
+		return dash
+	}
+
+	var cfg *setting.Cfg
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", 1), newDash("b", 2)}, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.Cfg.DashboardBatchMaxDashboards = 3
+		cfg = hs.Cfg
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.starService = startest.NewStarServiceFake()
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:a"},
+	}
+	batch := func(t *testing.T, body string) *http.Response {
+		t.Helper()
+		req := server.NewPostRequest("/api/dashboards/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should return the viewable dashboards with their meta in request order", func(t *testing.T) {
+		res := batch(t, `{"dashboardUids": ["c", "a", "b"]}`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var results []dtos.BatchDashboardResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&results))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, results, 3)
+		assert.Equal(t, "c", results[0].UID)
+		assert.Equal(t, bulkResultNotFound, results[0].Status)
+		assert.Nil(t, results[0].DashboardFullWithMeta)
+
+		assert.Equal(t, "a", results[1].UID)
+		assert.Equal(t, bulkResultFound, results[1].Status)
+		require.NotNil(t, results[1].DashboardFullWithMeta)
+		assert.True(t, results[1].Meta.CanSave)
+		assert.False(t, results[1].Meta.CanDelete)
+		assert.Equal(t, 2, results[1].Dashboard.Get("version").MustInt())
+
+		assert.Equal(t, "b", results[2].UID)
+		assert.Equal(t, bulkResultForbidden, results[2].Status)
+		assert.Nil(t, results[2].DashboardFullWithMeta)
+	})
+
+	t.Run("should reject requests for more dashboards than allowed", func(t *testing.T) {
+		res := batch(t, `{"dashboardUids": ["a", "b", "c", "d"]}`)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+
+	t.Run("should not cap the batch when the limit is 0", func(t *testing.T) {
+		cfg.DashboardBatchMaxDashboards = 0
+		t.Cleanup(func() { cfg.DashboardBatchMaxDashboards = 3 })
+		res := batch(t, `{"dashboardUids": ["a", "b", "c", "d"]}`)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
+
+func TestBulkRestoreDashboards(t *testing.T) {
+	newDash := func(uid string, id int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = id
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.Version = 4
+		return dash
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", 1), newDash("b", 2)}, nil)
+		saved := newDash("a", 1)
+		saved.Version = 5
+		dashSvc.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).Return(saved, nil)
+		hs.DashboardService = dashSvc
+
+		versionSvc := dashvertest.NewDashboardVersionServiceFake()
+		versionSvc.ExpectedDashboardVersions = []*dashver.DashboardVersionDTO{{DashboardID: 1, Version: 2, Data: simplejson.NewFromAny(map[string]any{"id": 1, "title": "a"})}}
+		hs.dashboardVersionService = versionSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:b"},
+	}
+	restore := func(t *testing.T, body string) *http.Response {
+		t.Helper()
+		req := server.NewPostRequest("/api/dashboards/bulk-restore", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should restore each dashboard it is allowed to save", func(t *testing.T) {
+		res := restore(t, `[{"uid": "a", "version": 2}, {"uid": "b", "version": 2}, {"uid": "c", "version": 2}]`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var results []dtos.BulkDashboardResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&results))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, results, 3)
+		assert.Equal(t, dtos.BulkDashboardResult{UID: "a", Title: "a", Status: bulkResultRestored, Version: 5}, results[0])
+		assert.Equal(t, "b", results[1].UID)
+		assert.Equal(t, bulkResultForbidden, results[1].Status)
+		assert.Equal(t, "c", results[2].UID)
+		assert.Equal(t, bulkResultNotFound, results[2].Status)
+	})
+
+	t.Run("should reject entries without a single version selector", func(t *testing.T) {
+		res := restore(t, `[{"uid": "a", "version": 2, "versionTag": "stable"}]`)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// canonicalDashboardDefaults are top level dashboard properties whose value
+// equals the default assumed when the property is missing.
+var canonicalDashboardDefaults = map[string]any{
+	"fiscalYearStartMonth": 0,
+	"graphTooltip":         0,
+	"links":                []any{},
+	"liveNow":              false,
+	"tags":                 []any{},
+}
+
+// canonicalPanelDefaults are panel properties whose value equals the default
+// assumed when the property is missing.
+var canonicalPanelDefaults = map[string]any{
+	"description":      "",
+	"hideTimeOverride": false,
+	"links":            []any{},
+	"transformations":  []any{},
+	"transparent":      false,
+}
+
+// swagger:route POST /folders/{folder_uid}/canonicalize folders canonicalizeFolderDashboards
+//
+// Rewrite the dashboards of a folder in canonical form.
+//
+// Removes properties set to their default value from every dashboard in the folder and saves the changed
+// dashboards as new versions. Stored dashboards always have their keys sorted, so the only remaining
+// differences between equivalent dashboards are the explicit defaults. Provisioned dashboards are skipped.
+// With dryRun set nothing is saved.
+//
+// Responses:
+// 200: bulkDashboardResultsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CanonicalizeFolderDashboards(c *contextmodel.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	if _, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser}); err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	dashes, err := hs.readableDashboards(c, uid)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	dryRun := c.QueryBool("dryRun")
+	results := make([]dtos.BulkDashboardResult, 0, len(dashes))
+	for _, dash := range dashes {
+		provisioning, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(c.Req.Context(), dash.ID)
+		if err != nil {
+			results = append(results, dtos.BulkDashboardResult{UID: dash.UID, Title: dash.Title, Status: bulkResultFailed, Message: err.Error()})
+			continue
+		}
+		if provisioning != nil {
+			results = append(results, dtos.BulkDashboardResult{UID: dash.UID, Title: dash.Title, Status: bulkResultSkipped, Message: "Dashboard is provisioned"})
+			continue
+		}
+
+		results = append(results, hs.bulkUpdateDashboard(c, dash, dryRun, "Rewritten in canonical form", canonicalizeDashboardJSON))
+	}
+
+	return response.JSON(http.StatusOK, results)
+}
+
+// canonicalizeDashboardJSON removes dashboard and panel properties that are
+// set to their default value. It reports whether the body was changed.
+func canonicalizeDashboardJSON(data *simplejson.Json) bool {
+	changed := stripDefaults(data, canonicalDashboardDefaults)
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if stripDefaults(panel, canonicalPanelDefaults) {
+			changed = true
+		}
+	})
+	return changed
+}
+
+func stripDefaults(obj *simplejson.Json, defaults map[string]any) bool {
+	changed := false
+	for key, def := range defaults {
+		value, ok := obj.CheckGet(key)
+		if !ok {
+			continue
+		}
+		actual, err := value.Encode()
+		if err != nil {
+			continue
+		}
+		expected, err := json.Marshal(def)
+		if err != nil {
+			continue
+		}
+		if string(actual) == string(expected) {
+			obj.Del(key)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// swagger:parameters canonicalizeFolderDashboards
+type CanonicalizeFolderDashboardsParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+	// Report the changes without saving them.
+	// in:query
+	// required:false
+	DryRun bool `json:"dryRun"`
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestCanonicalizeDashboardJSON(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"title": "dash",
+		"graphTooltip": 0,
+		"links": [],
+		"tags": ["prod"],
+		"panels": [
+			{"id": 1, "transparent": false, "links": [], "description": "", "transformations": [{"id": "merge"}]},
+			{"id": 2, "type": "row", "panels": [{"id": 3, "hideTimeOverride": false, "transparent": true}]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	require.True(t, canonicalizeDashboardJSON(data))
+
+	b, err := data.Encode()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"title": "dash",
+		"tags": ["prod"],
+		"panels": [
+			{"id": 1, "transformations": [{"id": "merge"}]},
+			{"id": 2, "type": "row", "panels": [{"id": 3, "transparent": true}]}
+		]
+	}`, string(b))
+
+	assert.False(t, canonicalizeDashboardJSON(data))
+}
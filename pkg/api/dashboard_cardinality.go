@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+const (
+	cardinalityRiskLow    = "low"
+	cardinalityRiskMedium = "medium"
+	cardinalityRiskHigh   = "high"
+)
+
+var (
+	// queryGroupingExpr matches PromQL style aggregation clauses, e.g. sum by (pod).
+	queryGroupingExpr = regexp.MustCompile(`\b(by|without)\s*\(([^)]*)\)`)
+	// queryMatchAllExpr matches label matchers selecting every value, e.g. {pod=~".*"}.
+	queryMatchAllExpr = regexp.MustCompile(`=~\s*"\.[*+]"`)
+)
+
+// highCardinalityLabels are labels that usually have one value per instance,
+// container or request and therefore multiply the number of returned series.
+var highCardinalityLabels = map[string]bool{
+	"pod":          true,
+	"pod_name":     true,
+	"container":    true,
+	"container_id": true,
+	"instance":     true,
+	"id":           true,
+	"ip":           true,
+	"user":         true,
+	"user_id":      true,
+	"session_id":   true,
+	"request_id":   true,
+	"trace_id":     true,
+	"path":         true,
+	"url":          true,
+	"uri":          true,
+}
+
+// swagger:route GET /dashboards/cardinality-risk dashboards getCardinalityRiskDashboards
+//
+// Group dashboards by estimated query cardinality risk.
+//
+// Classifies the dashboards the signed in user can read as high, medium or low risk based on heuristics
+// applied to their panel queries, such as grouping by labels known to have many values. The risky panels
+// of each dashboard are returned with the reasons for their classification.
+//
+// Responses:
+// 200: cardinalityRiskDashboardsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetCardinalityRiskDashboards(c *contextmodel.ReqContext) response.Response {
+	dashes, err := hs.readableIndexedDashboards(c)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	result := dtos.CardinalityRiskDashboards{
+		High:   []dtos.CardinalityRiskDashboard{},
+		Medium: []dtos.CardinalityRiskDashboard{},
+		Low:    []dtos.CardinalityRiskDashboard{},
+	}
+	for _, dash := range dashes {
+		entry := dash.entry
+
+		item := dtos.CardinalityRiskDashboard{
+			UID:       dash.UID,
+			Title:     dash.Title,
+			URL:       dash.GetURL(),
+			FolderUID: dash.FolderUID,
+			Risk:      cardinalityRiskLow,
+			Panels:    []dtos.CardinalityRiskPanel{},
+		}
+		for _, panel := range entry.Panels {
+			risk, reasons := panelCardinalityRisk(panel.Queries)
+			if risk == cardinalityRiskLow {
+				continue
+			}
+			item.Panels = append(item.Panels, dtos.CardinalityRiskPanel{ID: panel.ID, Title: panel.Title, Risk: risk, Reasons: reasons})
+			item.Risk = maxCardinalityRisk(item.Risk, risk)
+		}
+
+		switch item.Risk {
+		case cardinalityRiskHigh:
+			result.High = append(result.High, item)
+		case cardinalityRiskMedium:
+			result.Medium = append(result.Medium, item)
+		default:
+			result.Low = append(result.Low, item)
+		}
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// panelCardinalityRisk estimates the cardinality risk of a panel's queries and
+// returns the reasons for any risk above low.
+func panelCardinalityRisk(queries []string) (string, []string) {
+	risk := cardinalityRiskLow
+	var reasons []string
+	for _, query := range queries {
+		for _, match := range queryGroupingExpr.FindAllStringSubmatch(query, -1) {
+			if match[1] == "without" {
+				risk = maxCardinalityRisk(risk, cardinalityRiskMedium)
+				reasons = append(reasons, fmt.Sprintf("aggregation without (%s) keeps all other labels", strings.TrimSpace(match[2])))
+				continue
+			}
+			for _, label := range strings.Split(match[2], ",") {
+				label = strings.TrimSpace(label)
+				if highCardinalityLabels[label] {
+					risk = maxCardinalityRisk(risk, cardinalityRiskHigh)
+					reasons = append(reasons, fmt.Sprintf("groups by high cardinality label %q", label))
+				}
+			}
+		}
+		if queryMatchAllExpr.MatchString(query) {
+			risk = maxCardinalityRisk(risk, cardinalityRiskMedium)
+			reasons = append(reasons, "label matcher selects all values")
+		}
+	}
+	return risk, reasons
+}
+
+func maxCardinalityRisk(a, b string) string {
+	rank := map[string]int{cardinalityRiskLow: 0, cardinalityRiskMedium: 1, cardinalityRiskHigh: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// swagger:response cardinalityRiskDashboardsResponse
+type CardinalityRiskDashboardsResponse struct {
+	// in: body
+	Body dtos.CardinalityRiskDashboards `json:"body"`
+}
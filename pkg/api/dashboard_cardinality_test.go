@@ -0,0 +1,31 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanelCardinalityRisk(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		queries []string
+		risk    string
+		reasons int
+	}{
+		{desc: "plain aggregation", queries: []string{`sum by (job) (rate(http_requests_total[5m]))`}, risk: cardinalityRiskLow},
+		{desc: "grouping by pod", queries: []string{`sum by (job, pod) (rate(http_requests_total[5m]))`}, risk: cardinalityRiskHigh, reasons: 1},
+		{desc: "aggregation without", queries: []string{`sum without (le) (rate(x[5m]))`}, risk: cardinalityRiskMedium, reasons: 1},
+		{desc: "match all regex", queries: []string{`up{instance=~".*"}`}, risk: cardinalityRiskMedium, reasons: 1},
+		{desc: "highest risk wins", queries: []string{`up{job=~".+"}`, `count by(user_id)(x)`}, risk: cardinalityRiskHigh, reasons: 2},
+		{desc: "no queries", risk: cardinalityRiskLow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			risk, reasons := panelCardinalityRisk(tc.queries)
+			assert.Equal(t, tc.risk, risk)
+			assert.Len(t, reasons, tc.reasons)
+		})
+	}
+}
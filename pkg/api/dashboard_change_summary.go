@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+)
+
+// dashboardChangeSummary summarizes the changes of the saved dashboard data
+// against the version before the save. It returns nil when that version
+// can't be read.
+func (hs *HTTPServer) dashboardChangeSummary(ctx context.Context, dash *dashboards.Dashboard, data *simplejson.Json) *dashdiffs.ChangeSummary {
+	previous, err := hs.dashboardVersionService.Get(ctx, &dashver.GetDashboardVersionQuery{
+		OrgID:       dash.OrgID,
+		DashboardID: dash.ID,
+		Version:     dash.Version - 1,
+	})
+	if err != nil {
+		hs.log.Warn("Failed to get the previous dashboard version to summarize changes", "dashboard", dash.UID, "version", dash.Version-1, "error", err)
+		return nil
+	}
+
+	summary := dashdiffs.SummarizeChanges(dashdiffs.SemanticDiff(previous.Data, data))
+	return &summary
+}
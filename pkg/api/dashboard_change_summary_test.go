@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestPostDashboard_SummarizeChanges(t *testing.T) {
+	previous, err := simplejson.NewJson([]byte(`{
+		"id": 1, "uid": "dash", "title": "Dash", "version": 1,
+		"panels": [{"id": 1, "title": "CPU"}, {"id": 2, "title": "Memory"}],
+		"templating": {"list": [{"name": "env"}]}
+	}`))
+	require.NoError(t, err)
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(&dashboards.Dashboard{ID: 1, UID: "dash", OrgID: 1, Title: "Dash", Version: 1, Data: previous}, nil)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Return(&dashboards.Dashboard{ID: 1, UID: "dash", OrgID: 1, Title: "Dash", Version: 2}, nil)
+		hs.DashboardService = dashSvc
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{
+			ExpectedDashboardVersion: &dashver.DashboardVersionDTO{DashboardID: 1, Version: 1, Data: previous},
+		}
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	save := func(t *testing.T, url string) map[string]json.RawMessage {
+		t.Helper()
+		body := `{"dashboard": {
+			"id": 1, "uid": "dash", "title": "Dash", "version": 1,
+			"panels": [{"id": 1, "title": "CPU usage"}, {"id": 3, "title": "Disk"}],
+			"templating": {"list": [{"name": "env"}, {"name": "region"}]}
+		}}`
+		req := server.NewPostRequest(url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+		})))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		result := map[string]json.RawMessage{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		return result
+	}
+
+	t.Run("should summarize the changes to the previous version when asked", func(t *testing.T) {
+		result := save(t, "/api/dashboards/db?summarizeChanges=true")
+		require.Contains(t, result, "changeSummary")
+
+		var summary dashdiffs.ChangeSummary
+		require.NoError(t, json.Unmarshal(result["changeSummary"], &summary))
+		assert.Equal(t, dashdiffs.ChangeSummary{PanelsAdded: 1, PanelsRemoved: 1, PanelsModified: 1, VariablesAdded: 1}, summary)
+	})
+
+	t.Run("should not summarize the changes by default", func(t *testing.T) {
+		result := save(t, "/api/dashboards/db")
+		assert.NotContains(t, result, "changeSummary")
+	})
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardChangedSince(t *testing.T) {
+	dash := dashboards.NewDashboard("Dash")
+	dash.ID = 1
+	dash.UID = "dash"
+	dash.OrgID = 1
+	dash.Version = 3
+	dash.Data = simplejson.NewFromAny(map[string]any{"title": "Dash", "version": 3})
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"}}
+
+	changedSince := func(t *testing.T, path string) dtos.DashboardChangedSince {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(path), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardChangedSince
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		return result
+	}
+
+	current := changedSince(t, "/api/dashboards/uid/dash/changed-since/3")
+	assert.False(t, current.Changed)
+	assert.Equal(t, 3, current.CurrentVersion)
+	assert.NotEmpty(t, current.Hash)
+
+	t.Run("should report an older version as changed", func(t *testing.T) {
+		result := changedSince(t, "/api/dashboards/uid/dash/changed-since/2")
+		assert.True(t, result.Changed)
+		assert.Equal(t, 3, result.CurrentVersion)
+	})
+
+	t.Run("should compare the content hash when given", func(t *testing.T) {
+		assert.False(t, changedSince(t, "/api/dashboards/uid/dash/changed-since/3?hash="+current.Hash).Changed)
+		assert.True(t, changedSince(t, "/api/dashboards/uid/dash/changed-since/3?hash=other").Changed)
+	})
+
+	t.Run("should reject an invalid version", func(t *testing.T) {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/dash/changed-since/latest"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /dashboards/uid/{uid}/changelog dashboard_versions getDashboardChangelog
+//
+// Get the changelog of a dashboard.
+//
+// Lists the versions of the dashboard, newest first, with their author, creation time, message and a one
+// line summary of what changed since the previous version, such as "changed 1 property, added 2 panels".
+// The summary is derived from the semantic diff. Versions without any change, for example forced saves, are
+// left out. The summary of a version whose previous version is no longer kept is empty.
+//
+// limit and start select the versions to look at before unchanged ones are left out. limit defaults to and
+// can't exceed 20.
+//
+// Responses:
+// 200: dashboardChangelogResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardChangelog(c *contextmodel.ReqContext) response.Response {
+	limit := c.QueryInt("limit")
+	if limit == 0 {
+		limit = maxDashboardVersionsWithData
+	}
+	if limit < 0 || limit > maxDashboardVersionsWithData {
+		return response.Error(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxDashboardVersionsWithData), nil)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	// one more version than listed is needed to summarize the oldest one
+	versions, err := hs.dashboardVersionService.List(c.Req.Context(), &dashver.ListDashboardVersionsQuery{
+		OrgID:        dash.OrgID,
+		DashboardID:  dash.ID,
+		DashboardUID: dash.UID,
+		Limit:        limit + 1,
+		Start:        c.QueryInt("start"),
+		IncludeData:  true,
+	})
+	if err != nil {
+		return response.Error(http.StatusNotFound, fmt.Sprintf("No versions found for dashboard %s", dash.UID), err)
+	}
+
+	metas := hs.dashboardVersionMetas(c.Req.Context(), dash.UID, versions)
+	changelog := make([]dtos.DashboardChangelogEntry, 0, len(versions))
+	for i, version := range versions {
+		if i == limit {
+			break
+		}
+		entry := dtos.DashboardChangelogEntry{
+			Version:   version.Version,
+			Created:   version.Created,
+			CreatedBy: metas[i].CreatedBy,
+			Message:   metas[i].Message,
+		}
+		switch {
+		case version.ParentVersion == 0:
+			entry.Summary = "created the dashboard"
+		case i+1 < len(versions) && versions[i+1].Version == version.ParentVersion:
+			entry.Summary = dashdiffs.SummarizeChanges(dashdiffs.SemanticDiff(versions[i+1].Data, version.Data)).String()
+			if entry.Summary == "" {
+				continue
+			}
+		}
+		changelog = append(changelog, entry)
+	}
+
+	return response.JSON(http.StatusOK, changelog)
+}
+
+// swagger:parameters getDashboardChangelog
+type GetDashboardChangelogParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// Maximum number of versions to look at.
+	// in:query
+	// required:false
+	// default:20
+	Limit int `json:"limit"`
+	// Number of versions to skip, starting from the newest.
+	// in:query
+	// required:false
+	Start int `json:"start"`
+}
+
+// swagger:response dashboardChangelogResponse
+type DashboardChangelogResponse struct {
+	// in: body
+	Body []dtos.DashboardChangelogEntry `json:"body"`
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestHTTPServer_GetDashboardChangelog(t *testing.T) {
+	version := func(v, parent int, data string) *dashver.DashboardVersionDTO {
+		d, err := simplejson.NewJson([]byte(data))
+		require.NoError(t, err)
+		return &dashver.DashboardVersionDTO{Version: v, ParentVersion: parent, Message: "save", Data: d}
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("Dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{
+			ExpectedListDashboarVersions: []*dashver.DashboardVersionDTO{
+				version(4, 3, `{"title": "Dash", "panels": [{"id": 1}]}`),
+				version(3, 2, `{"title": "Dash", "panels": [{"id": 1}]}`),
+				version(2, 1, `{"title": "Old", "panels": [{"id": 1}]}`),
+				version(1, 0, `{"title": "Old", "panels": []}`),
+			},
+		}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	get := func(t *testing.T, url string) *http.Response {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"},
+			{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1"},
+		})))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should summarize the changed versions", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/1/changelog")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var changelog []dtos.DashboardChangelogEntry
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&changelog))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, changelog, 3)
+		assert.Equal(t, dtos.DashboardChangelogEntry{Version: 3, CreatedBy: anonString, Message: "save", Summary: "changed 1 property"}, changelog[0])
+		assert.Equal(t, "added 1 panel", changelog[1].Summary)
+		assert.Equal(t, dtos.DashboardChangelogEntry{Version: 1, CreatedBy: anonString, Message: "Initial save", Summary: "created the dashboard"}, changelog[2])
+	})
+
+	t.Run("should only look at limit versions", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/1/changelog?limit=2")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var changelog []dtos.DashboardChangelogEntry
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&changelog))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, changelog, 1)
+		assert.Equal(t, 3, changelog[0].Version)
+	})
+
+	t.Run("should reject a limit above the maximum", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/1/changelog?limit=21")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/live"
+	"github.com/grafana/grafana/pkg/services/live/features"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+type dashboardChangesRecorder struct {
+	live.DashboardActivityChannel
+	events []features.DashboardChangeEvent
+}
+
+func (r *dashboardChangesRecorder) DashboardChanged(event features.DashboardChangeEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestPublishDashboardChange(t *testing.T) {
+	changes := &dashboardChangesRecorder{}
+	hs := &HTTPServer{
+		Live: &live.GrafanaLive{GrafanaScope: live.CoreGrafanaScope{Dashboards: changes}},
+		log:  log.New("test-logger"),
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, "", nil)
+	require.NoError(t, err)
+	c := &contextmodel.ReqContext{SignedInUser: &user.SignedInUser{OrgID: 1, UserID: 7}, Context: &web.Context{Req: httpReq}}
+
+	dash := &dashboards.Dashboard{OrgID: 1, UID: "dash", FolderUID: "folder", Version: 1}
+	hs.publishDashboardChange(c, dash, false)
+	dash.Version = 2
+	hs.publishDashboardChange(c, dash, false)
+	hs.publishDashboardChange(c, dash, true)
+
+	assert.Equal(t, []features.DashboardChangeEvent{
+		{OrgID: 1, UID: "dash", Action: features.ActionCreated, Version: 1, PreviousVersion: 0, FolderUID: "folder", UserID: 7},
+		{OrgID: 1, UID: "dash", Action: features.ActionUpdated, Version: 2, PreviousVersion: 1, FolderUID: "folder", UserID: 7},
+		{OrgID: 1, UID: "dash", Action: features.ActionDeleted, Version: 0, PreviousVersion: 2, FolderUID: "folder", UserID: 7},
+	}, changes.events)
+}
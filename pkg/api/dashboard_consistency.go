@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+)
+
+// consistencyTokenHeader carries the consistency token of a dashboard save to
+// a later read of the dashboard.
+const consistencyTokenHeader = "X-Grafana-Consistency-Token"
+
+var (
+	// consistencyTokenWait is how long a read waits for the saved version to
+	// become visible before giving up.
+	consistencyTokenWait         = 2 * time.Second
+	consistencyTokenPollInterval = 50 * time.Millisecond
+)
+
+// dashboardConsistencyToken returns the token identifying the given saved
+// version of a dashboard.
+func dashboardConsistencyToken(dash *dashboards.Dashboard) string {
+	return fmt.Sprintf("%s:%d", dash.UID, dash.Version)
+}
+
+func parseConsistencyToken(token string) (string, int, error) {
+	i := strings.LastIndex(token, ":")
+	if i < 0 {
+		return "", 0, fmt.Errorf("invalid consistency token %q", token)
+	}
+	version, err := strconv.Atoi(token[i+1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid consistency token %q", token)
+	}
+	return token[:i], version, nil
+}
+
+// getConsistentDashboard reads the dashboard like getDashboardHelper. When the
+// request has the consistency token of a save of this dashboard, it reads
+// again until the saved version is visible, so a read served by a store that
+// lags behind the write doesn't return an older version.
+func (hs *HTTPServer) getConsistentDashboard(c *contextmodel.ReqContext, uid string) (*dashboards.Dashboard, response.Response) {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, uid)
+	token := c.Req.Header.Get(consistencyTokenHeader)
+	if token == "" {
+		return dash, rsp
+	}
+	tokenUID, version, err := parseConsistencyToken(token)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "Invalid consistency token", err)
+	}
+	if tokenUID != uid {
+		// the token of another dashboard says nothing about this one
+		return dash, rsp
+	}
+
+	deadline := time.Now().Add(consistencyTokenWait)
+	for rsp != nil || dash.Version < version {
+		if time.Now().After(deadline) {
+			return nil, response.Error(http.StatusServiceUnavailable, "Saved dashboard version is not visible yet", nil).
+				SetHeader("Retry-After", "1")
+		}
+		select {
+		case <-c.Req.Context().Done():
+			return nil, response.Error(http.StatusServiceUnavailable, "Saved dashboard version is not visible yet", c.Req.Context().Err())
+		case <-time.After(consistencyTokenPollInterval):
+		}
+		dash, rsp = hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, uid)
+	}
+	return dash, nil
+}
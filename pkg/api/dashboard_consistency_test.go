@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+// laggingDashboardService returns the previous version of the dashboard for
+// the first lag reads, like a replica that hasn't caught up with a save.
+type laggingDashboardService struct {
+	*dashboards.FakeDashboardService
+	dash  *dashboards.Dashboard
+	lag   int
+	reads int
+}
+
+func (s *laggingDashboardService) GetDashboard(ctx context.Context, query *dashboards.GetDashboardQuery) (*dashboards.Dashboard, error) {
+	s.reads++
+	dash := *s.dash
+	if s.reads <= s.lag {
+		dash.Version--
+	}
+	return &dash, nil
+}
+
+func TestGetDashboard_ConsistencyToken(t *testing.T) {
+	dash := dashboards.NewDashboard("Dash")
+	dash.ID = 1
+	dash.UID = "dash"
+	dash.OrgID = 1
+	dash.Version = 5
+
+	dashSvc := &laggingDashboardService{FakeDashboardService: dashboards.NewFakeDashboardService(t), dash: dash}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.DashboardService = dashSvc
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	get := func(t *testing.T, token string) *http.Response {
+		t.Helper()
+		req := server.NewGetRequest("/api/dashboards/uid/dash")
+		if token != "" {
+			req.Header.Set(consistencyTokenHeader, token)
+		}
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+		})))
+		require.NoError(t, err)
+		return res
+	}
+	version := func(t *testing.T, res *http.Response) int {
+		t.Helper()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data.Meta.Version
+	}
+
+	t.Run("should wait for the version of the token", func(t *testing.T) {
+		dashSvc.reads, dashSvc.lag = 0, 2
+		assert.Equal(t, 5, version(t, get(t, dashboardConsistencyToken(dash))))
+		assert.Equal(t, 3, dashSvc.reads)
+	})
+
+	t.Run("should read once without a token", func(t *testing.T) {
+		dashSvc.reads, dashSvc.lag = 0, 2
+		assert.Equal(t, 4, version(t, get(t, "")))
+	})
+
+	t.Run("should ignore the token of another dashboard", func(t *testing.T) {
+		dashSvc.reads, dashSvc.lag = 0, 2
+		assert.Equal(t, 4, version(t, get(t, "other:9")))
+	})
+
+	t.Run("should give up when the version doesn't become visible", func(t *testing.T) {
+		wait := consistencyTokenWait
+		consistencyTokenWait = 100 * time.Millisecond
+		t.Cleanup(func() { consistencyTokenWait = wait })
+
+		dashSvc.reads, dashSvc.lag = 0, 0
+		res := get(t, "dash:6")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "1", res.Header.Get("Retry-After"))
+	})
+
+	t.Run("should reject an invalid token", func(t *testing.T) {
+		res := get(t, "dash")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
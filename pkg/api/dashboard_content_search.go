@@ -0,0 +1,266 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+// contentSnippetContext is the number of bytes of context kept on each side
+// of a match in the snippets of SearchDashboardContent.
+const contentSnippetContext = 40
+
+// swagger:route GET /dashboards/search-content dashboards searchDashboardContent
+//
+// Search the content of dashboards.
+//
+// Returns the dashboards the signed in user can read whose JSON contains the text given by q, e.g. the uid
+// of a deprecated datasource or the name of a metric, with a snippet of every matching value. Matching is
+// case sensitive and only applies to values, not keys. The field query parameter limits the search to a
+// path of the dashboard, where [*] selects every element of an array, e.g. panels[*].targets[*].expr.
+//
+// Responses:
+// 200: searchDashboardContentResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) SearchDashboardContent(c *contextmodel.ReqContext) response.Response {
+	q := c.Query("q")
+	if q == "" {
+		return response.Error(http.StatusBadRequest, "q is required", nil)
+	}
+	field := c.Query("field")
+	var steps []jsonPathStep
+	if field != "" {
+		var err error
+		if steps, err = parseJSONPathPattern(field); err != nil {
+			return response.Error(http.StatusBadRequest, err.Error(), err)
+		}
+	}
+
+	dashes, err := hs.readableDashboards(c)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	result := make([]dtos.DashboardContentMatch, 0)
+	for _, dash := range dashes {
+		if dash.Data == nil {
+			continue
+		}
+
+		var snippets []dtos.DashboardContentSnippet
+		for _, node := range selectJSONPath(dash.Data.Interface(), "", steps) {
+			snippets = append(snippets, searchJSONValues(node.value, node.path, q)...)
+		}
+		if len(snippets) > 0 {
+			result = append(result, dtos.DashboardContentMatch{
+				UID:       dash.UID,
+				Title:     dash.Title,
+				URL:       dash.GetURL(),
+				FolderUID: dash.FolderUID,
+				Matches:   snippets,
+			})
+		}
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// jsonPathStep is a single step of a JSON path pattern: an object key, an
+// array index, or every element of an array.
+type jsonPathStep struct {
+	key      string
+	index    int
+	wildcard bool
+}
+
+// parseJSONPathPattern parses a dotted path like the ones accepted by
+// evalDashboardJSONPath, where * or [*] additionally selects every element of
+// an array, e.g. panels[*].targets[*].expr.
+func parseJSONPathPattern(path string) ([]jsonPathStep, error) {
+	var steps []jsonPathStep
+	for _, element := range strings.Split(path, ".") {
+		key, rest, hasIndex := strings.Cut(element, "[")
+		if key == "" && !hasIndex {
+			return nil, fmt.Errorf("invalid path %q: empty path element", path)
+		}
+		switch {
+		case key == "*":
+			steps = append(steps, jsonPathStep{wildcard: true})
+		case key != "":
+			steps = append(steps, jsonPathStep{key: key, index: -1})
+		}
+
+		for hasIndex {
+			var value string
+			var closed bool
+			value, rest, closed = strings.Cut(rest, "]")
+			if !closed {
+				return nil, fmt.Errorf("invalid path %q: invalid index in %q", path, element)
+			}
+			if value == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+			} else {
+				index, err := strconv.Atoi(value)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid path %q: invalid index in %q", path, element)
+				}
+				steps = append(steps, jsonPathStep{index: index})
+			}
+
+			if rest == "" {
+				break
+			}
+			if !strings.HasPrefix(rest, "[") {
+				return nil, fmt.Errorf("invalid path %q: unexpected %q after index in %q", path, rest, element)
+			}
+			rest = rest[1:]
+		}
+	}
+	return steps, nil
+}
+
+// jsonPathNode is a value selected by a JSON path pattern, with its concrete
+// path.
+type jsonPathNode struct {
+	path  string
+	value any
+}
+
+// selectJSONPath returns every value of the decoded JSON matching the path
+// steps. Parts of the path that don't exist select nothing.
+func selectJSONPath(value any, path string, steps []jsonPathStep) []jsonPathNode {
+	if len(steps) == 0 {
+		return []jsonPathNode{{path: path, value: value}}
+	}
+
+	step := steps[0]
+	switch v := value.(type) {
+	case map[string]any:
+		if step.key == "" {
+			return nil
+		}
+		next, ok := v[step.key]
+		if !ok {
+			return nil
+		}
+		return selectJSONPath(next, joinJSONPath(path, step.key), steps[1:])
+	case []any:
+		if step.wildcard {
+			var nodes []jsonPathNode
+			for i, next := range v {
+				nodes = append(nodes, selectJSONPath(next, fmt.Sprintf("%s[%d]", path, i), steps[1:])...)
+			}
+			return nodes
+		}
+		index := step.index
+		if step.key != "" {
+			// a numeric path element selects an array element, e.g. panels.0
+			var err error
+			if index, err = strconv.Atoi(step.key); err != nil {
+				return nil
+			}
+		}
+		if index < 0 || index >= len(v) {
+			return nil
+		}
+		return selectJSONPath(v[index], fmt.Sprintf("%s[%d]", path, index), steps[1:])
+	}
+	return nil
+}
+
+// searchJSONValues returns a snippet of every string or number value under
+// the decoded JSON value that contains q.
+func searchJSONValues(value any, path string, q string) []dtos.DashboardContentSnippet {
+	var snippets []dtos.DashboardContentSnippet
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			snippets = append(snippets, searchJSONValues(v[key], joinJSONPath(path, key), q)...)
+		}
+	case []any:
+		for i, next := range v {
+			snippets = append(snippets, searchJSONValues(next, fmt.Sprintf("%s[%d]", path, i), q)...)
+		}
+	case string:
+		if i := strings.Index(v, q); i >= 0 {
+			snippets = append(snippets, dtos.DashboardContentSnippet{Path: path, Snippet: contentSnippet(v, i, len(q))})
+		}
+	case json.Number:
+		if strings.Contains(v.String(), q) {
+			snippets = append(snippets, dtos.DashboardContentSnippet{Path: path, Snippet: v.String()})
+		}
+	}
+	return snippets
+}
+
+// contentSnippet returns the match at s[start:start+length] with up to
+// contentSnippetContext bytes of context on each side, marking cut off text
+// with an ellipsis.
+func contentSnippet(s string, start, length int) string {
+	from := start - contentSnippetContext
+	if from <= 0 {
+		from = 0
+	} else {
+		for from < start && !utf8.RuneStart(s[from]) {
+			from++
+		}
+	}
+	to := start + length + contentSnippetContext
+	if to >= len(s) {
+		to = len(s)
+	} else {
+		for to > start+length && !utf8.RuneStart(s[to]) {
+			to--
+		}
+	}
+
+	snippet := s[from:to]
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(s) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// swagger:parameters searchDashboardContent
+type SearchDashboardContentParams struct {
+	// The text to search for.
+	// in:query
+	// required:true
+	Q string `json:"q"`
+	// Path of the dashboard to search in, where [*] selects every element of an array.
+	// in:query
+	// required:false
+	Field string `json:"field"`
+}
+
+// swagger:response searchDashboardContentResponse
+type SearchDashboardContentResponse struct {
+	// in: body
+	Body []dtos.DashboardContentMatch `json:"body"`
+}
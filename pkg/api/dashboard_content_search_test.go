@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/search/model"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestSearchDashboardContent(t *testing.T) {
+	newDash := func(uid string, data string) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.UID = uid
+		dash.OrgID = 1
+		var err error
+		dash.Data, err = simplejson.NewJson([]byte(data))
+		require.NoError(t, err)
+		return dash
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.SearchService = &mockSearchService{ExpectedResult: model.HitList{{UID: "a"}, {UID: "b"}}}
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{
+			newDash("a", `{"title": "a", "panels": [
+				{"id": 1, "title": "Requests", "targets": [{"expr": "rate(http_requests_total[5m])"}]},
+				{"id": 2, "targets": [{"expr": "up"}, {"expr": "sum(http_requests_total)"}]}
+			]}`),
+			newDash("b", `{"title": "b", "description": "Shows http_requests_total", "panels": [{"id": 1, "targets": [{"expr": "up"}]}]}`),
+		}, nil).Maybe()
+		hs.DashboardService = dashSvc
+	})
+
+	search := func(t *testing.T, query string) (*http.Response, []dtos.DashboardContentMatch) {
+		t.Helper()
+		permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/search-content?"+query), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		var matches []dtos.DashboardContentMatch
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&matches))
+		}
+		require.NoError(t, res.Body.Close())
+		return res, matches
+	}
+
+	t.Run("should find values anywhere in the dashboard", func(t *testing.T) {
+		res, matches := search(t, "q=http_requests_total")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, matches, 2)
+		assert.Equal(t, "a", matches[0].UID)
+		assert.Equal(t, []dtos.DashboardContentSnippet{
+			{Path: "panels[0].targets[0].expr", Snippet: "rate(http_requests_total[5m])"},
+			{Path: "panels[1].targets[1].expr", Snippet: "sum(http_requests_total)"},
+		}, matches[0].Matches)
+		assert.Equal(t, "b", matches[1].UID)
+		assert.Equal(t, []dtos.DashboardContentSnippet{{Path: "description", Snippet: "Shows http_requests_total"}}, matches[1].Matches)
+	})
+
+	t.Run("should limit the search to the field", func(t *testing.T) {
+		res, matches := search(t, "q=http_requests_total&field=panels[*].targets[*].expr")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, matches, 1)
+		assert.Equal(t, "a", matches[0].UID)
+		assert.Len(t, matches[0].Matches, 2)
+	})
+
+	t.Run("should require q", func(t *testing.T) {
+		res, _ := search(t, "field=panels")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should reject invalid fields", func(t *testing.T) {
+		res, _ := search(t, "q=up&field=panels[x]")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestSelectJSONPath(t *testing.T) {
+	data := map[string]any{
+		"panels": []any{
+			map[string]any{"targets": []any{map[string]any{"expr": "a"}, map[string]any{"expr": "b"}}},
+			map[string]any{"title": "no targets"},
+			map[string]any{"targets": []any{map[string]any{"expr": "c"}}},
+		},
+	}
+
+	for _, tc := range []struct {
+		path     string
+		expected []jsonPathNode
+	}{
+		{path: "panels[*].targets[*].expr", expected: []jsonPathNode{
+			{path: "panels[0].targets[0].expr", value: "a"},
+			{path: "panels[0].targets[1].expr", value: "b"},
+			{path: "panels[2].targets[0].expr", value: "c"},
+		}},
+		{path: "panels.*.targets.0.expr", expected: []jsonPathNode{
+			{path: "panels[0].targets[0].expr", value: "a"},
+			{path: "panels[2].targets[0].expr", value: "c"},
+		}},
+		{path: "panels[2].targets[0]", expected: []jsonPathNode{
+			{path: "panels[2].targets[0]", value: map[string]any{"expr": "c"}},
+		}},
+		{path: "panels[5].title"},
+		{path: "rows[*]"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			steps, err := parseJSONPathPattern(tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, selectJSONPath(data, "", steps))
+		})
+	}
+
+	for _, path := range []string{"panels..title", "panels[x]", "panels[*", "panels[0]x"} {
+		_, err := parseJSONPathPattern(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestContentSnippet(t *testing.T) {
+	long := strings.Repeat("a", 50) + "MATCH" + strings.Repeat("é", 30)
+	snippet := contentSnippet(long, 50, 5)
+	assert.True(t, strings.HasPrefix(snippet, "…"+strings.Repeat("a", 40)+"MATCH"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.True(t, strings.Contains(snippet, strings.Repeat("é", 20)))
+
+	assert.Equal(t, "short MATCH", contentSnippet("short MATCH", 6, 5))
+}
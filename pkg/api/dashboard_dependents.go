@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/playlist"
+	publicdashboardModels "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	dashboardDependentPlaylist        = "playlist"
+	dashboardDependentPublicDashboard = "public_dashboard"
+
+	// dependentPlaylistsLimit matches the default limit of the playlist search.
+	dependentPlaylistsLimit = 1000
+)
+
+// swagger:route GET /dashboards/uid/{uid}/dependents dashboards getDashboardDependents
+//
+// Get the constructs that depend on a dashboard.
+//
+// Returns the playlists that include the dashboard, either directly or through one of its tags, and the
+// public dashboard sharing it. These stop working when the dashboard is deleted.
+//
+// Responses:
+// 200: dashboardDependentsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardDependents(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	dependents, err := hs.dependentPlaylists(c, dash)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get playlists", err)
+	}
+
+	if hs.Features.IsEnabledGlobally(featuremgmt.FlagPublicDashboards) {
+		publicDashboard, err := hs.PublicDashboardsApi.PublicDashboardService.FindByDashboardUid(c.Req.Context(), dash.OrgID, dash.UID)
+		if err != nil && !errors.Is(err, publicdashboardModels.ErrPublicDashboardNotFound) {
+			return response.Error(http.StatusInternalServerError, "Error while retrieving public dashboards", err)
+		}
+		if publicDashboard != nil {
+			dependents = append(dependents, dtos.DashboardDependent{
+				Type: dashboardDependentPublicDashboard,
+				UID:  publicDashboard.Uid,
+				Name: dash.Title,
+				URL:  setting.AppSubUrl + "/public-dashboards/" + publicDashboard.AccessToken,
+			})
+		}
+	}
+
+	return response.JSON(http.StatusOK, dependents)
+}
+
+// dependentPlaylists returns the playlists with an item resolving to the dashboard.
+func (hs *HTTPServer) dependentPlaylists(c *contextmodel.ReqContext, dash *dashboards.Dashboard) ([]dtos.DashboardDependent, error) {
+	playlists, err := hs.playlistService.Search(c.Req.Context(), &playlist.GetPlaylistsQuery{
+		OrgId: dash.OrgID,
+		Limit: dependentPlaylistsLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]bool)
+	for _, tag := range dash.GetTags() {
+		tags[tag] = true
+	}
+
+	dependents := make([]dtos.DashboardDependent, 0)
+	for _, p := range playlists {
+		dto, err := hs.playlistService.Get(c.Req.Context(), &playlist.GetPlaylistByUidQuery{UID: p.UID, OrgId: dash.OrgID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range dto.Items {
+			if playlistItemMatchesDashboard(item, dash, tags) {
+				dependents = append(dependents, dtos.DashboardDependent{
+					Type: dashboardDependentPlaylist,
+					UID:  p.UID,
+					Name: p.Name,
+					URL:  setting.AppSubUrl + "/playlists/edit/" + p.UID,
+				})
+				break
+			}
+		}
+	}
+	return dependents, nil
+}
+
+func playlistItemMatchesDashboard(item playlist.PlaylistItemDTO, dash *dashboards.Dashboard, tags map[string]bool) bool {
+	switch item.Type {
+	case "dashboard_by_uid":
+		return item.Value == dash.UID
+	case "dashboard_by_id":
+		return item.Value == strconv.FormatInt(dash.ID, 10)
+	case "dashboard_by_tag":
+		return tags[item.Value]
+	}
+	return false
+}
+
+// swagger:parameters getDashboardDependents
+type GetDashboardDependentsParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardDependentsResponse
+type DashboardDependentsResponse struct {
+	// in: body
+	Body []dtos.DashboardDependent `json:"body"`
+}
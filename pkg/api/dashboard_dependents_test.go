@@ -0,0 +1,26 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/playlist"
+)
+
+func TestPlaylistItemMatchesDashboard(t *testing.T) {
+	dash := dashboards.NewDashboardFromJson(simplejson.NewFromAny(map[string]any{
+		"uid":  "abc",
+		"tags": []any{"prod"},
+	}))
+	dash.ID = 7
+	tags := map[string]bool{"prod": true}
+
+	assert.True(t, playlistItemMatchesDashboard(playlist.PlaylistItemDTO{Type: "dashboard_by_uid", Value: "abc"}, dash, tags))
+	assert.True(t, playlistItemMatchesDashboard(playlist.PlaylistItemDTO{Type: "dashboard_by_id", Value: "7"}, dash, tags))
+	assert.True(t, playlistItemMatchesDashboard(playlist.PlaylistItemDTO{Type: "dashboard_by_tag", Value: "prod"}, dash, tags))
+	assert.False(t, playlistItemMatchesDashboard(playlist.PlaylistItemDTO{Type: "dashboard_by_uid", Value: "other"}, dash, tags))
+	assert.False(t, playlistItemMatchesDashboard(playlist.PlaylistItemDTO{Type: "dashboard_by_tag", Value: "dev"}, dash, tags))
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+const deprecatedOptionsDefaultPerPage = 100
+
+// deprecatedPanelOption is a panel option that is no longer supported by a
+// panel plugin. Options with a Value are only deprecated when set to it.
+type deprecatedPanelOption struct {
+	Path        string
+	Value       string
+	Replacement string
+}
+
+// deprecatedPanelOptions is the registry of deprecated options per panel plugin.
+var deprecatedPanelOptions = map[string][]deprecatedPanelOption{
+	"timeseries": {
+		{Path: "options.tooltipOptions", Replacement: "options.tooltip"},
+		{Path: "options.legend.displayMode", Value: "hidden", Replacement: "options.legend.showLegend"},
+		{Path: "fieldConfig.defaults.custom.hideFrom.graph", Replacement: "fieldConfig.defaults.custom.hideFrom.viz"},
+	},
+	"table": {
+		{Path: "fieldConfig.defaults.custom.displayMode", Replacement: "fieldConfig.defaults.custom.cellOptions"},
+	},
+	"text": {
+		{Path: "content", Replacement: "options.content"},
+		{Path: "mode", Replacement: "options.mode"},
+	},
+	"piechart": {
+		{Path: "options.legend.displayMode", Value: "hidden", Replacement: "options.legend.showLegend"},
+	},
+}
+
+// swagger:route GET /dashboards/deprecated-options dashboards getDeprecatedOptionDashboards
+//
+// Find dashboards using deprecated panel options.
+//
+// Returns the dashboards the signed in user can read with panels of the given plugin that set options
+// the plugin has deprecated, together with the options to migrate.
+//
+// Responses:
+// 200: deprecatedOptionDashboardsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetDeprecatedOptionDashboards(c *contextmodel.ReqContext) response.Response {
+	pluginID := c.Query("plugin")
+	if pluginID == "" {
+		return response.Error(http.StatusBadRequest, "plugin is required", nil)
+	}
+	page := c.QueryInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	perPage := c.QueryInt("perpage")
+	if perPage <= 0 {
+		perPage = deprecatedOptionsDefaultPerPage
+	}
+
+	dashes, err := hs.readableIndexedDashboards(c)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+	sort.Slice(dashes, func(i, j int) bool { return dashes[i].Title < dashes[j].Title })
+
+	matches := make([]dtos.DeprecatedOptionDashboard, 0)
+	for _, dash := range dashes {
+		var panels []dtos.DeprecatedOptionPanel
+		for _, panel := range dash.entry.Panels {
+			if panel.Type != pluginID {
+				continue
+			}
+			if options := findDeprecatedPanelOptions(pluginID, panel.Options); len(options) > 0 {
+				panels = append(panels, dtos.DeprecatedOptionPanel{ID: panel.ID, Title: panel.Title, Options: options})
+			}
+		}
+		if len(panels) > 0 {
+			matches = append(matches, dtos.DeprecatedOptionDashboard{
+				UID:       dash.UID,
+				Title:     dash.Title,
+				URL:       dash.GetURL(),
+				FolderUID: dash.FolderUID,
+				Panels:    panels,
+			})
+		}
+	}
+
+	result := dtos.DeprecatedOptionDashboards{
+		TotalCount: len(matches),
+		Page:       page,
+		PerPage:    perPage,
+		Dashboards: []dtos.DeprecatedOptionDashboard{},
+	}
+	if start := (page - 1) * perPage; start < len(matches) {
+		end := start + perPage
+		if end > len(matches) {
+			end = len(matches)
+		}
+		result.Dashboards = matches[start:end]
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// findDeprecatedPanelOptions returns the deprecated options of the plugin
+// that are set in the flattened panel options.
+func findDeprecatedPanelOptions(pluginID string, options map[string]string) []dtos.DeprecatedPanelOption {
+	var found []dtos.DeprecatedPanelOption
+	for _, deprecated := range deprecatedPanelOptions[pluginID] {
+		for path, value := range options {
+			if path != deprecated.Path && !strings.HasPrefix(path, deprecated.Path+".") {
+				continue
+			}
+			if deprecated.Value != "" && value != deprecated.Value {
+				continue
+			}
+			found = append(found, dtos.DeprecatedPanelOption{Path: deprecated.Path, Replacement: deprecated.Replacement})
+			break
+		}
+	}
+	return found
+}
+
+// swagger:parameters getDeprecatedOptionDashboards
+type GetDeprecatedOptionDashboardsParams struct {
+	// Panel plugin id, e.g. timeseries.
+	// in:query
+	// required:true
+	Plugin string `json:"plugin"`
+	// in:query
+	// required:false
+	Page int `json:"page"`
+	// in:query
+	// required:false
+	// default:100
+	PerPage int `json:"perpage"`
+}
+
+// swagger:response deprecatedOptionDashboardsResponse
+type DeprecatedOptionDashboardsResponse struct {
+	// in: body
+	Body dtos.DeprecatedOptionDashboards `json:"body"`
+}
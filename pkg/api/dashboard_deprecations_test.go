@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+)
+
+func TestFindDeprecatedPanelOptions(t *testing.T) {
+	dash := newIndexTestDashboard(t, `{
+		"panels": [
+			{"id": 1, "type": "timeseries", "options": {"tooltipOptions": {"mode": "single"}, "legend": {"displayMode": "hidden"}}},
+			{"id": 2, "type": "timeseries", "options": {"tooltip": {"mode": "single"}, "legend": {"displayMode": "list"}}},
+			{"id": 3, "type": "text", "content": "# hello", "targets": [{"expr": "up"}]}
+		]
+	}`)
+	entry := buildDashboardIndexEntry(dash)
+	require.Len(t, entry.Panels, 3)
+
+	assert.Equal(t, "hidden", entry.Panels[0].Options["options.legend.displayMode"])
+	assert.NotContains(t, entry.Panels[2].Options, "targets")
+
+	assert.ElementsMatch(t, []dtos.DeprecatedPanelOption{
+		{Path: "options.tooltipOptions", Replacement: "options.tooltip"},
+		{Path: "options.legend.displayMode", Replacement: "options.legend.showLegend"},
+	}, findDeprecatedPanelOptions("timeseries", entry.Panels[0].Options))
+	assert.Empty(t, findDeprecatedPanelOptions("timeseries", entry.Panels[1].Options))
+	assert.Equal(t, []dtos.DeprecatedPanelOption{{Path: "content", Replacement: "options.content"}},
+		findDeprecatedPanelOptions("text", entry.Panels[2].Options))
+	assert.Empty(t, findDeprecatedPanelOptions("unknown", entry.Panels[0].Options))
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// Panels record who authored their description in the descriptionSource
+// field. Tooling that generates descriptions sets it to "auto"; descriptions
+// without the marker are written manually. Only the auto marker is stored.
+const (
+	panelDescriptionSourceKey    = "descriptionSource"
+	panelDescriptionSourceAuto   = "auto"
+	panelDescriptionSourceManual = "manual"
+)
+
+// Values of the descriptions query parameter of GetDashboard.
+const (
+	descriptionsFilterAll    = "all"
+	descriptionsFilterAuto   = "auto"
+	descriptionsFilterManual = "manual"
+	descriptionsFilterNone   = "none"
+)
+
+// cleanPanelDescriptionSources drops the authorship markers other than auto,
+// and the markers of panels without a description, before a save.
+func cleanPanelDescriptionSources(data *simplejson.Json) {
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if panel.Get("description").MustString() == "" || panel.Get(panelDescriptionSourceKey).MustString() != panelDescriptionSourceAuto {
+			panel.Del(panelDescriptionSourceKey)
+		}
+	})
+}
+
+// filterPanelDescriptions removes the panel descriptions not matching the
+// filter from the dashboard body.
+func filterPanelDescriptions(data *simplejson.Json, filter string) error {
+	switch filter {
+	case "", descriptionsFilterAll:
+		return nil
+	case descriptionsFilterAuto, descriptionsFilterManual, descriptionsFilterNone:
+	default:
+		return fmt.Errorf("invalid descriptions filter %q, must be one of auto, manual, all or none", filter)
+	}
+
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if _, ok := panel.CheckGet("description"); !ok {
+			return
+		}
+		source := panelDescriptionSourceManual
+		if panel.Get(panelDescriptionSourceKey).MustString() == panelDescriptionSourceAuto {
+			source = panelDescriptionSourceAuto
+		}
+		if filter == descriptionsFilterNone || filter != source {
+			panel.Del("description")
+			panel.Del(panelDescriptionSourceKey)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestPanelDescriptions(t *testing.T) {
+	newDashboard := func(t *testing.T) *simplejson.Json {
+		data, err := simplejson.NewJson([]byte(`{"panels": [
+			{"id": 1, "description": "generated", "descriptionSource": "auto"},
+			{"id": 2, "description": "written"},
+			{"id": 3, "descriptionSource": "auto"},
+			{"id": 4, "description": "typed", "descriptionSource": "manual"}
+		]}`))
+		require.NoError(t, err)
+		return data
+	}
+
+	descriptions := func(data *simplejson.Json) []string {
+		var res []string
+		forEachDashboardPanel(data, func(panel *simplejson.Json) {
+			res = append(res, panel.Get("description").MustString()+"/"+panel.Get(panelDescriptionSourceKey).MustString())
+		})
+		return res
+	}
+
+	t.Run("should only keep the auto marker on save", func(t *testing.T) {
+		data := newDashboard(t)
+		cleanPanelDescriptionSources(data)
+		assert.Equal(t, []string{"generated/auto", "written/", "/", "typed/"}, descriptions(data))
+	})
+
+	for filter, expected := range map[string][]string{
+		"":       {"generated/auto", "written/", "/auto", "typed/manual"},
+		"all":    {"generated/auto", "written/", "/auto", "typed/manual"},
+		"auto":   {"generated/auto", "/", "/auto", "/"},
+		"manual": {"/", "written/", "/auto", "typed/manual"},
+		"none":   {"/", "/", "/auto", "/"},
+	} {
+		t.Run("should filter descriptions with "+filter, func(t *testing.T) {
+			data := newDashboard(t)
+			require.NoError(t, filterPanelDescriptions(data, filter))
+			assert.Equal(t, expected, descriptions(data))
+		})
+	}
+
+	t.Run("should reject unknown filters", func(t *testing.T) {
+		assert.Error(t, filterPanelDescriptions(newDashboard(t), "some"))
+	})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const editorTeamDashboardsDefaultPerPage = 100
+
+// swagger:route GET /dashboards/by-editor-team/{team_id} dashboards getDashboardsByEditorTeam
+//
+// Find dashboards last edited by members of a team.
+//
+// Returns the dashboards the signed in user can read whose latest version was saved by a member of the
+// given team, most recently edited first.
+//
+// Responses:
+// 200: editorTeamDashboardsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardsByEditorTeam(c *contextmodel.ReqContext) response.Response {
+	teamID, err := strconv.ParseInt(web.Params(c.Req)[":teamId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
+	}
+	page := c.QueryInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	perPage := c.QueryInt("perpage")
+	if perPage <= 0 {
+		perPage = editorTeamDashboardsDefaultPerPage
+	}
+
+	members, err := hs.teamService.GetTeamMembers(c.Req.Context(), &team.GetTeamMembersQuery{
+		OrgID:        c.SignedInUser.GetOrgID(),
+		TeamID:       teamID,
+		SignedInUser: c.SignedInUser,
+	})
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get team members", err)
+	}
+	logins := make(map[int64]string, len(members))
+	for _, member := range members {
+		logins[member.UserID] = member.Login
+	}
+
+	dashes, err := hs.readableDashboards(c)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	matches := make([]dtos.EditorTeamDashboard, 0)
+	for _, dash := range dashes {
+		// The dashboard records the author of its latest version, which avoids
+		// loading the version of dashboards no team member touched.
+		if _, ok := logins[dash.UpdatedBy]; !ok {
+			continue
+		}
+
+		editedBy, edited := dash.UpdatedBy, dash.Updated
+		version, err := hs.dashboardVersionService.Get(c.Req.Context(), &dashver.GetDashboardVersionQuery{
+			OrgID:        dash.OrgID,
+			DashboardID:  dash.ID,
+			DashboardUID: dash.UID,
+			Version:      dash.Version,
+		})
+		if err == nil {
+			editedBy, edited = version.CreatedBy, version.Created
+		}
+		login, ok := logins[editedBy]
+		if !ok {
+			continue
+		}
+
+		matches = append(matches, dtos.EditorTeamDashboard{
+			UID:          dash.UID,
+			Title:        dash.Title,
+			URL:          dash.GetURL(),
+			FolderUID:    dash.FolderUID,
+			Version:      dash.Version,
+			LastEditedBy: login,
+			LastEdited:   edited,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].LastEdited.After(matches[j].LastEdited) })
+
+	result := dtos.EditorTeamDashboards{
+		TotalCount: len(matches),
+		Page:       page,
+		PerPage:    perPage,
+		Dashboards: []dtos.EditorTeamDashboard{},
+	}
+	if start := (page - 1) * perPage; start < len(matches) {
+		end := start + perPage
+		if end > len(matches) {
+			end = len(matches)
+		}
+		result.Dashboards = matches[start:end]
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:parameters getDashboardsByEditorTeam
+type GetDashboardsByEditorTeamParams struct {
+	// in:path
+	// required:true
+	TeamID int64 `json:"team_id"`
+	// in:query
+	// required:false
+	Page int `json:"page"`
+	// in:query
+	// required:false
+	// default:100
+	PerPage int `json:"perpage"`
+}
+
+// swagger:response editorTeamDashboardsResponse
+type EditorTeamDashboardsResponse struct {
+	// in: body
+	Body dtos.EditorTeamDashboards `json:"body"`
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/search/model"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardsByEditorTeam(t *testing.T) {
+	edited := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	newDash := func(uid string, updatedBy int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.Version = 3
+		dash.UpdatedBy = updatedBy
+		return dash
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.SearchService = &mockSearchService{ExpectedResult: model.HitList{{UID: "a"}, {UID: "b"}, {UID: "c"}}}
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", 1), newDash("b", 2), newDash("c", 3)}, nil)
+		hs.DashboardService = dashSvc
+		hs.teamService = &teamtest.FakeService{ExpectedMembers: []*team.TeamMemberDTO{{UserID: 1, Login: "alice"}, {UserID: 2, Login: "bob"}}}
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{ExpectedDashboardVersions: []*dashver.DashboardVersionDTO{
+			{Version: 3, CreatedBy: 1, Created: edited},
+			{Version: 3, CreatedBy: 2, Created: edited.Add(time.Hour)},
+		}}
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+		{Action: accesscontrol.ActionTeamsRead, Scope: "teams:id:1"},
+	}
+	res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/by-editor-team/1?perpage=1"), userWithPermissions(1, permissions)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var result dtos.EditorTeamDashboards
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+	require.NoError(t, res.Body.Close())
+
+	assert.Equal(t, 2, result.TotalCount)
+	require.Len(t, result.Dashboards, 1)
+	assert.Equal(t, "b", result.Dashboards[0].UID)
+	assert.Equal(t, "bob", result.Dashboards[0].LastEditedBy)
+	assert.True(t, edited.Add(time.Hour).Equal(result.Dashboards[0].LastEdited))
+
+	t.Run("should require read access to the team", func(t *testing.T) {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/by-editor-team/2"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+)
+
+const (
+	permissionSourceDirect    = "direct"
+	permissionSourceInherited = "inherited"
+	permissionSourceWildcard  = "wildcard"
+)
+
+// explainDashboardPermissions lists the permissions of the user that grant
+// each of the Can* flags of the dashboard meta, using the same actions as the
+// dashboard guardian. Folder scopes are matched against the folders of meta,
+// which must already be set.
+func (hs *HTTPServer) explainDashboardPermissions(user identity.Requester, dash *dashboards.Dashboard, meta dtos.DashboardMeta) []dtos.EffectivePermission {
+	editActions := []string{dashboards.ActionDashboardsWrite}
+	if hs.Cfg.ViewersCanEdit {
+		editActions = []string{dashboards.ActionDashboardsRead}
+	}
+	flags := []struct {
+		name    string
+		actions []string
+	}{
+		{"canSave", []string{dashboards.ActionDashboardsWrite}},
+		{"canEdit", editActions},
+		{"canAdmin", []string{dashboards.ActionDashboardsPermissionsRead, dashboards.ActionDashboardsPermissionsWrite}},
+		{"canDelete", []string{dashboards.ActionDashboardsDelete}},
+	}
+
+	folderUIDs := []string{accesscontrol.GeneralFolderUID}
+	if len(meta.FolderPath) > 0 {
+		folderUIDs = make([]string, 0, len(meta.FolderPath))
+		for _, f := range meta.FolderPath {
+			folderUIDs = append(folderUIDs, f.UID)
+		}
+	}
+	wildcards := accesscontrol.WildcardsFromPrefixes([]string{
+		dashboards.ScopeDashboardsProvider.GetResourceScopeUID(""),
+		dashboards.ScopeFoldersProvider.GetResourceScopeUID(""),
+	})
+
+	permissions := user.GetPermissions()
+	result := []dtos.EffectivePermission{}
+	for _, flag := range flags {
+		for _, action := range flag.actions {
+			for _, scope := range permissions[action] {
+				grant := dtos.EffectivePermission{Flag: flag.name, Action: action, Scope: scope}
+				switch {
+				case scope == dashboards.ScopeDashboardsProvider.GetResourceScopeUID(dash.UID):
+					grant.Source = permissionSourceDirect
+				case wildcards.Contains(scope):
+					grant.Source = permissionSourceWildcard
+				default:
+					for _, uid := range folderUIDs {
+						if scope == dashboards.ScopeFoldersProvider.GetResourceScopeUID(uid) {
+							grant.Source, grant.FolderUID = permissionSourceInherited, uid
+							break
+						}
+					}
+				}
+				if grant.Source != "" {
+					result = append(result, grant)
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardusage"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestHTTPServer_GetDashboard_ExplainPermissions(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.usageStore = dashboardusage.NewStore(kvstore.NewFakeKVStore())
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:other"},
+		{Action: dashboards.ActionDashboardsDelete, Scope: "folders:uid:general"},
+		{Action: dashboards.ActionDashboardsPermissionsRead, Scope: dashboards.ScopeDashboardsAll},
+	}
+	get := func(t *testing.T, url string) dtos.DashboardFullWithMeta {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data
+	}
+
+	t.Run("should not explain permissions by default", func(t *testing.T) {
+		data := get(t, "/api/dashboards/uid/1")
+		assert.Empty(t, data.Meta.EffectivePermissions)
+	})
+
+	t.Run("should list the permissions granting each flag with their source", func(t *testing.T) {
+		data := get(t, "/api/dashboards/uid/1?explainPermissions=true")
+		assert.True(t, data.Meta.CanSave)
+		assert.False(t, data.Meta.CanAdmin)
+		assert.Equal(t, []dtos.EffectivePermission{
+			{Flag: "canSave", Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1", Source: permissionSourceDirect},
+			{Flag: "canEdit", Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1", Source: permissionSourceDirect},
+			{Flag: "canAdmin", Action: dashboards.ActionDashboardsPermissionsRead, Scope: dashboards.ScopeDashboardsAll, Source: permissionSourceWildcard},
+			{Flag: "canDelete", Action: dashboards.ActionDashboardsDelete, Scope: "folders:uid:general", Source: permissionSourceInherited, FolderUID: "general"},
+		}, data.Meta.EffectivePermissions)
+	})
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// Values of the libraryPanels query parameter of ExportDashboard.
+const (
+	libraryPanelsInline = "inline"
+	libraryPanelsRefs   = "refs"
+)
+
+// swagger:route GET /dashboards/uid/{uid}/export dashboards exportDashboard
+//
+// Export a dashboard.
+//
+// Returns the dashboard in the form used to share it with other instances: data sources are replaced by
+// import inputs listed in __inputs. With libraryPanels=inline, library panels are replaced by their
+// definition. With libraryPanels=refs, the default, panels keep referencing the library panels and the
+// definitions are listed in __elements, so that importing the dashboard creates the missing library panels.
+//
+// The descriptions query parameter filters the panel descriptions like for getting the dashboard.
+//
+// Responses:
+// 200: dashboardExportResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) ExportDashboard(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+	return hs.exportDashboard(c, dash)
+}
+
+// exportDashboard returns the shareable form of a dashboard the signed in
+// user can view, handling library panels as asked by the libraryPanels query
+// parameter.
+func (hs *HTTPServer) exportDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard) response.Response {
+	mode := c.Query("libraryPanels")
+	if mode == "" {
+		mode = libraryPanelsRefs
+	}
+	if mode != libraryPanelsInline && mode != libraryPanelsRefs {
+		return response.Error(http.StatusBadRequest, fmt.Sprintf("invalid libraryPanels %q, must be one of inline or refs", mode), nil)
+	}
+
+	elements, err := hs.LibraryElementService.GetElementsForDashboard(c.Req.Context(), dash.ID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get library panels of dashboard", err)
+	}
+	data, err := cloneDashboardJSON(dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to copy dashboard", err)
+	}
+	if mode == libraryPanelsInline {
+		err = inlineLibraryPanels(data, elements)
+	} else {
+		err = listLibraryPanels(data, elements)
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to read library panel", err)
+	}
+	if err := filterPanelDescriptions(data, c.Query("descriptions")); err != nil {
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+
+	export, err := hs.exportDashboardJSON(c.Req.Context(), dash.OrgID, data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to export dashboard", err)
+	}
+	return response.JSON(http.StatusOK, export)
+}
+
+// inlineLibraryPanels replaces the library panels of the dashboard with their
+// definition, keeping the id and position of the dashboard panel. Library
+// panels missing from elements are left as they are.
+func inlineLibraryPanels(data *simplejson.Json, elements map[string]model.LibraryElementDTO) error {
+	var inlineErr error
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		element, ok := elements[panel.GetPath("libraryPanel", "uid").MustString()]
+		if !ok || inlineErr != nil {
+			return
+		}
+		definition, err := simplejson.NewJson(element.Model)
+		if err != nil {
+			inlineErr = err
+			return
+		}
+
+		fields := panel.MustMap()
+		id, gridPos := fields["id"], fields["gridPos"]
+		for key := range fields {
+			delete(fields, key)
+		}
+		for key, value := range definition.MustMap() {
+			fields[key] = value
+		}
+		delete(fields, "libraryPanel")
+		fields["id"], fields["gridPos"] = id, gridPos
+	})
+	return inlineErr
+}
+
+// listLibraryPanels lists the definitions of the library panels used by the
+// dashboard in __elements, in the format expected by the dashboard import.
+func listLibraryPanels(data *simplejson.Json, elements map[string]model.LibraryElementDTO) error {
+	listed := make(map[string]bool)
+	list := make([]any, 0)
+	var listErr error
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		element, ok := elements[panel.GetPath("libraryPanel", "uid").MustString()]
+		if !ok || listErr != nil {
+			return
+		}
+		// the import needs the name to create a missing library panel
+		panel.Set("libraryPanel", map[string]any{"uid": element.UID, "name": element.Name})
+		if listed[element.UID] {
+			return
+		}
+
+		definition, err := simplejson.NewJson(element.Model)
+		if err != nil {
+			listErr = err
+			return
+		}
+		listed[element.UID] = true
+		list = append(list, map[string]any{
+			"name":  element.Name,
+			"uid":   element.UID,
+			"kind":  element.Kind,
+			"model": definition.Interface(),
+		})
+	})
+	data.Set("__elements", list)
+	return listErr
+}
+
+// swagger:parameters exportDashboard
+type ExportDashboardParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// How to export the library panels of the dashboard.
+	// in:query
+	// required:false
+	// enum: inline,refs
+	// default: refs
+	LibraryPanels string `json:"libraryPanels"`
+	// Which panel descriptions to return, based on whether they were generated or written manually
+	// in:query
+	// required:false
+	// enum: all,auto,manual,none
+	// default: all
+	Descriptions string `json:"descriptions"`
+}
+
+// swagger:response dashboardExportResponse
+type DashboardExportResponse struct {
+	// in: body
+	Body map[string]any `json:"body"`
+}
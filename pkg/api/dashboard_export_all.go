@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+// swagger:route GET /dashboards/export-all dashboards exportAllDashboards
+//
+// Export all dashboards.
+//
+// Streams newline-delimited JSON with one line per dashboard of the organization the signed in user can
+// view, holding its uid, folder uid and the dashboard JSON. Dashboards are read in pages, so the export
+// never holds the whole organization in memory. With `folderUid`, only the dashboards of that folder and
+// its subfolders are exported.
+//
+// Produces:
+// - application/x-ndjson
+//
+// Responses:
+// 200: exportAllDashboardsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) ExportAllDashboards(c *contextmodel.ReqContext) response.Response {
+	query := &search.Query{Permission: dashboards.PERMISSION_VIEW}
+	if folderUID := c.Query("folderUid"); folderUID != "" {
+		folderUIDs, err := hs.folderSubtreeUIDs(c.Req.Context(), c.SignedInUser, folderUID)
+		if err != nil {
+			return apierrors.ToFolderErrorResponse(err)
+		}
+		query.FolderUIDs = folderUIDs
+	}
+
+	uids, err := hs.searchDashboardUIDs(c, query)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	return &dashboardExportAllResponse{hs: hs, uids: uids}
+}
+
+// folderSubtreeUIDs returns the uid of the folder and of all its subfolders.
+func (hs *HTTPServer) folderSubtreeUIDs(ctx context.Context, signedInUser *user.SignedInUser, uid string) ([]string, error) {
+	if _, err := hs.folderService.Get(ctx, &folder.GetFolderQuery{OrgID: signedInUser.GetOrgID(), UID: &uid, SignedInUser: signedInUser}); err != nil {
+		return nil, err
+	}
+
+	uids := []string{uid}
+	for i := 0; i < len(uids); i++ {
+		children, err := hs.folderService.GetChildren(ctx, &folder.GetChildrenQuery{
+			UID:          uids[i],
+			OrgID:        signedInUser.GetOrgID(),
+			SignedInUser: signedInUser,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			uids = append(uids, child.UID)
+		}
+	}
+	return uids, nil
+}
+
+// dashboardExportAllResponse streams the dashboards with the given uids as
+// NDJSON, fetching them a page at a time.
+type dashboardExportAllResponse struct {
+	hs   *HTTPServer
+	uids []string
+}
+
+func (r *dashboardExportAllResponse) Status() int {
+	return http.StatusOK
+}
+
+func (r *dashboardExportAllResponse) Body() []byte {
+	return nil
+}
+
+func (r *dashboardExportAllResponse) WriteTo(ctx *contextmodel.ReqContext) {
+	ctx.Resp.Header().Set("Content-Type", "application/x-ndjson")
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	if err := r.hs.writeDashboardExport(ctx, ctx.Resp, r.uids); err != nil {
+		ctx.Logger.Error("Error writing dashboard export", "err", err)
+	}
+}
+
+// writeDashboardExport writes a line per dashboard the signed in user can
+// view. Dashboards are skipped silently when they are not viewable or were
+// deleted since the uids were listed.
+func (hs *HTTPServer) writeDashboardExport(c *contextmodel.ReqContext, w io.Writer, uids []string) error {
+	enc := json.NewEncoder(w)
+	for start := 0; start < len(uids); start += readableDashboardsPageSize {
+		end := start + readableDashboardsPageSize
+		if end > len(uids) {
+			end = len(uids)
+		}
+
+		dashes, err := hs.DashboardService.GetDashboards(c.Req.Context(), &dashboards.GetDashboardsQuery{
+			DashboardUIDs: uids[start:end],
+			OrgID:         c.SignedInUser.GetOrgID(),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, dash := range dashes {
+			if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+				continue
+			}
+			if err := enc.Encode(dtos.DashboardExportLine{UID: dash.UID, FolderUID: dash.FolderUID, Dashboard: dash.Data}); err != nil {
+				return err
+			}
+		}
+
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
+
+// swagger:parameters exportAllDashboards
+type ExportAllDashboardsParams struct {
+	// Only export the dashboards of this folder and its subfolders.
+	// in:query
+	// required:false
+	FolderUID string `json:"folderUid"`
+}
+
+// swagger:response exportAllDashboardsResponse
+type ExportAllDashboardsResponse struct {
+	// in: body
+	Body []byte `json:"body"`
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/search/model"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+type exportSearchService struct {
+	mockSearchService
+	queries []*search.Query
+}
+
+func (s *exportSearchService) SearchHandler(ctx context.Context, q *search.Query) (model.HitList, error) {
+	s.queries = append(s.queries, q)
+	return s.mockSearchService.SearchHandler(ctx, q)
+}
+
+type folderTreeService struct {
+	*foldertest.FakeService
+	children map[string][]*folder.Folder
+}
+
+func (s *folderTreeService) GetChildren(ctx context.Context, q *folder.GetChildrenQuery) ([]*folder.Folder, error) {
+	return s.children[q.UID], nil
+}
+
+func TestExportAllDashboards(t *testing.T) {
+	newDash := func(uid string, folderUID string) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.FolderUID = folderUID
+		dash.Data = simplejson.NewFromAny(map[string]any{"uid": uid, "title": uid})
+		return dash
+	}
+
+	searchSvc := &exportSearchService{mockSearchService: mockSearchService{ExpectedResult: model.HitList{{UID: "a"}, {UID: "b"}}}}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.SearchService = searchSvc
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", "f1"), newDash("b", "")}, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.folderService = &folderTreeService{
+			FakeService: &foldertest.FakeService{ExpectedFolder: &folder.Folder{UID: "f1"}},
+			children: map[string][]*folder.Folder{
+				"f1": {{UID: "f2"}},
+				"f2": {{UID: "f3"}},
+			},
+		}
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	export := func(t *testing.T, query string) []dtos.DashboardExportLine {
+		t.Helper()
+		permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/export-all"+query), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+		var lines []dtos.DashboardExportLine
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			var line dtos.DashboardExportLine
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.NoError(t, scanner.Err())
+		require.NoError(t, res.Body.Close())
+		return lines
+	}
+
+	t.Run("should stream a line per viewable dashboard", func(t *testing.T) {
+		lines := export(t, "")
+		require.Len(t, lines, 1)
+		assert.Equal(t, "a", lines[0].UID)
+		assert.Equal(t, "f1", lines[0].FolderUID)
+		assert.Equal(t, "a", lines[0].Dashboard.Get("title").MustString())
+	})
+
+	t.Run("should limit the export to the folder subtree", func(t *testing.T) {
+		searchSvc.queries = nil
+		export(t, "?folderUid=f1")
+		require.NotEmpty(t, searchSvc.queries)
+		assert.Equal(t, []string{"f1", "f2", "f3"}, searchSvc.queries[0].FolderUIDs)
+	})
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+)
+
+func TestExportLibraryPanels(t *testing.T) {
+	elements := map[string]model.LibraryElementDTO{
+		"lib": {
+			UID:   "lib",
+			Name:  "Shared panel",
+			Kind:  int64(model.PanelElement),
+			Model: []byte(`{"type": "stat", "title": "Shared", "libraryPanel": {"uid": "lib"}, "gridPos": {"w": 1}}`),
+		},
+	}
+	dashboard := func() *simplejson.Json {
+		return simplejson.NewFromAny(map[string]any{
+			"panels": []any{
+				map[string]any{"id": 1, "gridPos": map[string]any{"x": 0}, "libraryPanel": map[string]any{"uid": "lib"}},
+				map[string]any{"id": 2, "type": "row", "collapsed": true, "panels": []any{
+					map[string]any{"id": 3, "gridPos": map[string]any{"x": 12}, "libraryPanel": map[string]any{"uid": "lib"}},
+				}},
+				map[string]any{"id": 4, "libraryPanel": map[string]any{"uid": "missing"}},
+			},
+		})
+	}
+
+	t.Run("should inline library panels", func(t *testing.T) {
+		data := dashboard()
+		require.NoError(t, inlineLibraryPanels(data, elements))
+
+		panel := data.Get("panels").GetIndex(0)
+		assert.Equal(t, 1, panel.Get("id").MustInt())
+		assert.Equal(t, map[string]any{"x": 0}, panel.Get("gridPos").MustMap())
+		assert.Equal(t, "Shared", panel.Get("title").MustString())
+		_, hasRef := panel.CheckGet("libraryPanel")
+		assert.False(t, hasRef)
+		nested := data.Get("panels").GetIndex(1).Get("panels").GetIndex(0)
+		assert.Equal(t, "stat", nested.Get("type").MustString())
+		assert.Equal(t, 3, nested.Get("id").MustInt())
+		assert.Equal(t, "missing", data.Get("panels").GetIndex(2).GetPath("libraryPanel", "uid").MustString())
+		_, hasElements := data.CheckGet("__elements")
+		assert.False(t, hasElements)
+	})
+
+	t.Run("should list library panels once", func(t *testing.T) {
+		data := dashboard()
+		require.NoError(t, listLibraryPanels(data, elements))
+
+		list := data.Get("__elements").MustArray()
+		require.Len(t, list, 1)
+		element := simplejson.NewFromAny(list[0])
+		assert.Equal(t, "lib", element.Get("uid").MustString())
+		assert.Equal(t, "Shared panel", element.Get("name").MustString())
+		assert.Equal(t, "Shared", element.GetPath("model", "title").MustString())
+		assert.Equal(t, "Shared panel", data.Get("panels").GetIndex(0).GetPath("libraryPanel", "name").MustString())
+		assert.Equal(t, "missing", data.Get("panels").GetIndex(2).GetPath("libraryPanel", "uid").MustString())
+	})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// Values of the fields query parameter of GetDashboard selecting a whole part
+// of the response. Any other field selects a top level property of the
+// dashboard body, e.g. title.
+const (
+	dashboardFieldMeta      = "meta"
+	dashboardFieldDashboard = "dashboard"
+)
+
+// projectDashboard returns the parts of the dashboard response selected by the
+// comma separated fields, or the whole response when no field is given.
+func projectDashboard(dto dtos.DashboardFullWithMeta, fields string) any {
+	if strings.TrimSpace(fields) == "" {
+		return dto
+	}
+
+	projection := make(map[string]any)
+	dashboard := make(map[string]any)
+	for _, field := range strings.Split(fields, ",") {
+		switch field = strings.TrimSpace(field); field {
+		case "":
+		case dashboardFieldMeta:
+			projection[dashboardFieldMeta] = dto.Meta
+		case dashboardFieldDashboard:
+			projection[dashboardFieldDashboard] = dto.Dashboard
+		default:
+			if value, ok := dto.Dashboard.CheckGet(field); ok {
+				dashboard[field] = value.Interface()
+			}
+		}
+	}
+
+	if _, ok := projection[dashboardFieldDashboard]; !ok && len(dashboard) > 0 {
+		projection[dashboardFieldDashboard] = dashboard
+	}
+	return projection
+}
+
+// evalDashboardJSONPath returns the subtree of the dashboard at the given
+// path. Path elements are separated by dots and array elements are selected by
+// index, either as a path element or in brackets, e.g. panels[0].datasource or
+// panels.0.datasource.
+func evalDashboardJSONPath(data *simplejson.Json, path string) (*simplejson.Json, error) {
+	current := data
+	walked := ""
+	for _, element := range strings.Split(path, ".") {
+		key, indexes, err := parseJSONPathElement(element)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", path, err)
+		}
+
+		if _, err := current.Array(); err == nil && key != "" {
+			// a numeric path element selects an array element, e.g. panels.0
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("path %q not found in dashboard", strings.TrimPrefix(walked+"."+key, "."))
+			}
+			key, indexes = "", append([]int{index}, indexes...)
+		}
+		if key != "" {
+			walked = strings.TrimPrefix(walked+"."+key, ".")
+			next, ok := current.CheckGet(key)
+			if !ok {
+				return nil, fmt.Errorf("path %q not found in dashboard", walked)
+			}
+			current = next
+		}
+		for _, index := range indexes {
+			walked = fmt.Sprintf("%s[%d]", walked, index)
+			array, err := current.Array()
+			if err != nil || index >= len(array) {
+				return nil, fmt.Errorf("path %q not found in dashboard", walked)
+			}
+			current = current.GetIndex(index)
+		}
+	}
+	return current, nil
+}
+
+// parseJSONPathElement splits a path element such as panels[0][1] into its key
+// and indexes.
+func parseJSONPathElement(element string) (string, []int, error) {
+	key, rest, hasIndex := strings.Cut(element, "[")
+	if key == "" && !hasIndex {
+		return "", nil, fmt.Errorf("empty path element")
+	}
+
+	var indexes []int
+	for hasIndex {
+		var value string
+		var closed bool
+		value, rest, closed = strings.Cut(rest, "]")
+		index, err := strconv.Atoi(value)
+		if !closed || err != nil || index < 0 {
+			return "", nil, fmt.Errorf("invalid index in %q", element)
+		}
+		indexes = append(indexes, index)
+
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, "[") {
+			return "", nil, fmt.Errorf("unexpected %q after index in %q", rest, element)
+		}
+		rest = rest[1:]
+	}
+	return key, indexes, nil
+}
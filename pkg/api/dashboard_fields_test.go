@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestProjectDashboard(t *testing.T) {
+	dto := dtos.DashboardFullWithMeta{
+		Meta: dtos.DashboardMeta{CanEdit: true, FolderTitle: "General"},
+		Dashboard: simplejson.NewFromAny(map[string]any{
+			"title":  "Dash",
+			"tags":   []any{"prod"},
+			"panels": []any{map[string]any{"id": 1}},
+		}),
+	}
+
+	project := func(fields string) map[string]any {
+		body, err := json.Marshal(projectDashboard(dto, fields))
+		require.NoError(t, err)
+		var projection map[string]any
+		require.NoError(t, json.Unmarshal(body, &projection))
+		return projection
+	}
+
+	t.Run("should return the whole response without fields", func(t *testing.T) {
+		projection := project("")
+		assert.Contains(t, projection, "meta")
+		assert.Contains(t, projection["dashboard"], "panels")
+	})
+
+	t.Run("should return only the meta", func(t *testing.T) {
+		projection := project("meta")
+		assert.NotContains(t, projection, "dashboard")
+		assert.Equal(t, true, projection["meta"].(map[string]any)["canEdit"])
+	})
+
+	t.Run("should return the meta and the selected dashboard properties", func(t *testing.T) {
+		projection := project("meta, title,tags,unknown")
+		assert.Contains(t, projection, "meta")
+		assert.Equal(t, map[string]any{"title": "Dash", "tags": []any{"prod"}}, projection["dashboard"])
+	})
+}
+
+func TestEvalDashboardJSONPath(t *testing.T) {
+	data := simplejson.NewFromAny(map[string]any{
+		"templating": map[string]any{"list": []any{map[string]any{"name": "env"}}},
+		"panels": []any{
+			map[string]any{"id": 1, "datasource": map[string]any{"uid": "prom"}},
+			map[string]any{"id": 2, "targets": []any{[]any{"a", "b"}}},
+		},
+	})
+
+	for path, expected := range map[string]any{
+		"templating.list":         []any{map[string]any{"name": "env"}},
+		"templating.list[0].name": "env",
+		"panels[0].datasource":    map[string]any{"uid": "prom"},
+		"panels.0.datasource.uid": "prom",
+		"panels[1].targets[0][1]": "b",
+	} {
+		value, err := evalDashboardJSONPath(data, path)
+		require.NoError(t, err, path)
+		assert.Equal(t, expected, value.Interface(), path)
+	}
+
+	for path, message := range map[string]string{
+		"templating.missing": `path "templating.missing" not found in dashboard`,
+		"panels[5]":          `path "panels[5]" not found in dashboard`,
+		"panels.first":       `path "panels.first" not found in dashboard`,
+		"panels[x]":          `invalid path "panels[x]": invalid index in "panels[x]"`,
+		"templating..list":   `invalid path "templating..list": empty path element`,
+	} {
+		_, err := evalDashboardJSONPath(data, path)
+		require.Error(t, err, path)
+		assert.Equal(t, message, err.Error(), path)
+	}
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /dashboards/single-version dashboard_versions getSingleVersionDashboards
+//
+// Find dashboards that were never saved after their creation.
+//
+// Returns the dashboards the signed in user can read that have exactly one version, together with
+// when and by whom that version was created.
+//
+// Responses:
+// 200: singleVersionDashboardsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetSingleVersionDashboards(c *contextmodel.ReqContext) response.Response {
+	dashes, err := hs.readableDashboards(c)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	type singleVersion struct {
+		dash    *dashboards.Dashboard
+		version *dashver.DashboardVersionDTO
+	}
+	found := make([]singleVersion, 0)
+	createdBy := make([]int64, 0)
+	for _, dash := range dashes {
+		// Two versions are enough to tell whether the dashboard was ever updated.
+		versions, err := hs.dashboardVersionService.List(c.Req.Context(), &dashver.ListDashboardVersionsQuery{
+			OrgID:        dash.OrgID,
+			DashboardID:  dash.ID,
+			DashboardUID: dash.UID,
+			Limit:        2,
+		})
+		if err != nil {
+			if errors.Is(err, dashver.ErrNoVersionsForDashboardID) {
+				continue
+			}
+			return response.Error(http.StatusInternalServerError, "Failed to list dashboard versions", err)
+		}
+		if len(versions) != 1 {
+			continue
+		}
+		found = append(found, singleVersion{dash: dash, version: versions[0]})
+		createdBy = append(createdBy, versions[0].CreatedBy)
+	}
+
+	logins := hs.getUserLogins(c.Req.Context(), createdBy)
+	result := make([]dtos.SingleVersionDashboard, 0, len(found))
+	for _, f := range found {
+		creator := anonString
+		if login, ok := logins[f.version.CreatedBy]; ok {
+			creator = login
+		}
+		result = append(result, dtos.SingleVersionDashboard{
+			UID:       f.dash.UID,
+			Title:     f.dash.Title,
+			URL:       f.dash.GetURL(),
+			FolderUID: f.dash.FolderUID,
+			Created:   f.version.Created,
+			CreatedBy: creator,
+		})
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route GET /dashboards/uid/{uid}/panels/{panelId}/versions dashboard_versions getDashboardPanelVersions
+//
+// Get the history of a panel.
+//
+// Walks the versions of the dashboard from the oldest to the newest and returns the states of the panel
+// with the given id, each with the version in which the panel got that state. Versions in which the panel
+// is unchanged or absent are left out.
+//
+// limit and start select the versions to walk, starting from the newest. limit defaults to and can't exceed
+// 20.
+//
+// Responses:
+// 200: dashboardPanelVersionsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardPanelVersions(c *contextmodel.ReqContext) response.Response {
+	panelID, err := strconv.ParseInt(web.Params(c.Req)[":panelId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "panelId is invalid", err)
+	}
+	limit := c.QueryInt("limit")
+	if limit == 0 {
+		limit = maxDashboardVersionsWithData
+	}
+	if limit < 0 || limit > maxDashboardVersionsWithData {
+		return response.Error(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxDashboardVersionsWithData), nil)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	// one more version than walked is needed to tell whether the oldest one
+	// changed the panel
+	versions, err := hs.dashboardVersionService.List(c.Req.Context(), &dashver.ListDashboardVersionsQuery{
+		OrgID:        dash.OrgID,
+		DashboardID:  dash.ID,
+		DashboardUID: dash.UID,
+		Limit:        limit + 1,
+		Start:        c.QueryInt("start"),
+		IncludeData:  true,
+	})
+	if err != nil {
+		if errors.Is(err, dashver.ErrNoVersionsForDashboardID) {
+			return response.JSON(http.StatusOK, []dtos.DashboardPanelVersion{})
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboard versions", err)
+	}
+
+	var previous *dashver.DashboardVersionDTO
+	if len(versions) > limit {
+		previous, versions = versions[limit], versions[:limit]
+	}
+	changes := panelChanges(versions, previous, panelID)
+	createdBy := make([]int64, 0, len(changes))
+	for _, version := range changes {
+		createdBy = append(createdBy, version.CreatedBy)
+	}
+	logins := hs.getUserLogins(c.Req.Context(), createdBy)
+
+	result := make([]dtos.DashboardPanelVersion, 0, len(changes))
+	for _, version := range changes {
+		creator := anonString
+		if login, ok := logins[version.CreatedBy]; ok {
+			creator = login
+		}
+		result = append(result, dtos.DashboardPanelVersion{
+			Version:   version.Version,
+			Created:   version.Created,
+			CreatedBy: creator,
+			Message:   version.Message,
+			Panel:     findDashboardPanel(version.Data, panelID),
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route POST /dashboards/uid/{uid}/panels/{panelId}/revert dashboard_versions revertDashboardPanel
+//
+// Revert a panel to a previous version.
+//
+// Replaces the panel with the given id by its state in the given version of the dashboard and saves the
+// dashboard as a new version. The panel keeps its current position; a panel removed since that version is
+// added back at its former position. The rest of the dashboard is left unchanged.
+//
+// Responses:
+// 200: postDashboardResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 412: preconditionFailedError
+// 500: internalServerError
+func (hs *HTTPServer) RevertDashboardPanel(c *contextmodel.ReqContext) response.Response {
+	panelID, err := strconv.ParseInt(web.Params(c.Req)[":panelId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "panelId is invalid", err)
+	}
+	cmd := dtos.RevertDashboardPanelCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if cmd.Version < 1 {
+		return response.Error(http.StatusBadRequest, "version is required", nil)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	version, err := hs.dashboardVersionService.Get(c.Req.Context(), &dashver.GetDashboardVersionQuery{
+		OrgID:        dash.OrgID,
+		DashboardID:  dash.ID,
+		DashboardUID: dash.UID,
+		Version:      cmd.Version,
+	})
+	if err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard version", err)
+	}
+	previous := findDashboardPanel(version.Data, panelID)
+	if previous == nil {
+		return response.Error(http.StatusNotFound, fmt.Sprintf("Panel %d not found in version %d", panelID, cmd.Version), nil)
+	}
+
+	data, err := cloneDashboardJSON(dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to revert panel", err)
+	}
+	revertDashboardPanel(data, panelID, previous.MustMap())
+	data.Set("version", dash.Version)
+	data.Set("uid", dash.UID)
+
+	return hs.postDashboard(c, dashboards.SaveDashboardCommand{
+		Dashboard: data,
+		Message:   fmt.Sprintf("Reverted panel %d to version %d", panelID, cmd.Version),
+		FolderID:  dash.FolderID, // nolint:staticcheck
+		FolderUID: dash.FolderUID,
+	})
+}
+
+// revertDashboardPanel replaces the panel with the given id by its previous
+// state, keeping its current position, or adds it back when it was removed.
+func revertDashboardPanel(data *simplejson.Json, panelID int64, previous map[string]any) {
+	current := findDashboardPanel(data, panelID)
+	if current == nil {
+		data.Set("panels", append(data.Get("panels").MustArray(), previous))
+		return
+	}
+
+	gridPos, hasGridPos := current.CheckGet("gridPos")
+	panel := current.MustMap()
+	for key := range panel {
+		delete(panel, key)
+	}
+	for key, value := range previous {
+		panel[key] = value
+	}
+	if hasGridPos {
+		panel["gridPos"] = gridPos.Interface()
+	}
+}
+
+// panelChanges returns the versions, oldest first, in which the panel differs
+// from its state in the previous version containing it. The versions are
+// expected newest first, as listed by the dashboard version service, and
+// previous, when given, is the version preceding the oldest of them.
+func panelChanges(versions []*dashver.DashboardVersionDTO, previous *dashver.DashboardVersionDTO, panelID int64) []*dashver.DashboardVersionDTO {
+	changes := make([]*dashver.DashboardVersionDTO, 0)
+	var last any
+	if previous != nil {
+		if panel := findDashboardPanel(previous.Data, panelID); panel != nil {
+			last = panel.Interface()
+		}
+	}
+	for i := len(versions) - 1; i >= 0; i-- {
+		panel := findDashboardPanel(versions[i].Data, panelID)
+		if panel == nil {
+			continue
+		}
+		if state := panel.Interface(); last == nil || !reflect.DeepEqual(last, state) {
+			changes = append(changes, versions[i])
+			last = state
+		}
+	}
+	return changes
+}
+
+// findDashboardPanel returns the panel with the given id, including panels
+// nested in rows, or nil when the dashboard has no such panel.
+func findDashboardPanel(data *simplejson.Json, panelID int64) *simplejson.Json {
+	if data == nil {
+		return nil
+	}
+
+	var found *simplejson.Json
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if found == nil && panel.Get("id").MustInt64(-1) == panelID {
+			found = panel
+		}
+	})
+	return found
+}
+
+// swagger:parameters getDashboardPanelVersions
+type GetDashboardPanelVersionsParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:path
+	// required:true
+	PanelID int64 `json:"panelId"`
+	// Maximum number of versions to walk.
+	// in:query
+	// required:false
+	// default:20
+	Limit int `json:"limit"`
+	// Number of versions to skip, starting from the newest.
+	// in:query
+	// required:false
+	Start int `json:"start"`
+}
+
+// swagger:parameters revertDashboardPanel
+type RevertDashboardPanelParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:path
+	// required:true
+	PanelID int64 `json:"panelId"`
+	// in:body
+	// required:true
+	Body dtos.RevertDashboardPanelCommand
+}
+
+// swagger:response dashboardPanelVersionsResponse
+type DashboardPanelVersionsResponse struct {
+	// in: body
+	Body []dtos.DashboardPanelVersion `json:"body"`
+}
+
+// swagger:response singleVersionDashboardsResponse
+type SingleVersionDashboardsResponse struct {
+	// in: body
+	Body []dtos.SingleVersionDashboard `json:"body"`
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/search/model"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+// searchQueryRecorder records the queries of the dashboards search.
+type searchQueryRecorder struct {
+	mockSearchService
+	queries []search.Query
+}
+
+func (r *searchQueryRecorder) SearchHandler(ctx context.Context, q *search.Query) (model.HitList, error) {
+	r.queries = append(r.queries, *q)
+	return r.mockSearchService.SearchHandler(ctx, q)
+}
+
+// versionsByUID lists the versions of each dashboard by its uid.
+type versionsByUID struct {
+	dashvertest.FakeDashboardVersionService
+	versions map[string][]*dashver.DashboardVersionDTO
+}
+
+func (v *versionsByUID) List(ctx context.Context, query *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersionDTO, error) {
+	versions, ok := v.versions[query.DashboardUID]
+	if !ok {
+		return nil, dashver.ErrNoVersionsForDashboardID
+	}
+	return versions, nil
+}
+
+func TestGetSingleVersionDashboards(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	newDash := func(uid string) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.UID = uid
+		dash.OrgID = 1
+		return dash
+	}
+
+	searchService := &searchQueryRecorder{mockSearchService: mockSearchService{ExpectedResult: model.HitList{{UID: "once"}, {UID: "twice"}, {UID: "none"}}}}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.SearchService = searchService
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardsQuery) bool {
+			return assert.ObjectsAreEqual([]string{"once", "twice", "none"}, q.DashboardUIDs)
+		})).Return([]*dashboards.Dashboard{newDash("once"), newDash("twice"), newDash("none")}, nil)
+		hs.DashboardService = dashSvc
+		hs.dashboardVersionService = &versionsByUID{versions: map[string][]*dashver.DashboardVersionDTO{
+			"once":   {{Version: 1, CreatedBy: 2, Created: created}},
+			"twice":  {{Version: 2, CreatedBy: 2}, {Version: 1, CreatedBy: 3}},
+			"hidden": {{Version: 1, CreatedBy: 3}},
+		}}
+		hs.userService = &usertest.FakeUserService{ExpectedUsers: []*user.User{{ID: 2, Login: "bob"}}}
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+	res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/single-version"), userWithPermissions(1, permissions)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var result []dtos.SingleVersionDashboard
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+	require.NoError(t, res.Body.Close())
+
+	// only the dashboards found by the search, which the user can view, are looked at
+	require.NotEmpty(t, searchService.queries)
+	assert.Equal(t, dashboards.PERMISSION_VIEW, searchService.queries[0].Permission)
+
+	require.Len(t, result, 1)
+	assert.Equal(t, "once", result[0].UID)
+	assert.Equal(t, "bob", result[0].CreatedBy)
+	assert.True(t, created.Equal(result[0].Created))
+}
+
+func TestPanelChanges(t *testing.T) {
+	version := func(v int, panels ...map[string]any) *dashver.DashboardVersionDTO {
+		list := make([]any, 0, len(panels))
+		for _, panel := range panels {
+			list = append(list, panel)
+		}
+		return &dashver.DashboardVersionDTO{Version: v, Data: simplejson.NewFromAny(map[string]any{"panels": list})}
+	}
+	panel := func(id int, title string) map[string]any {
+		return map[string]any{"id": id, "title": title}
+	}
+	row := func(panels ...map[string]any) map[string]any {
+		nested := make([]any, 0, len(panels))
+		for _, panel := range panels {
+			nested = append(nested, panel)
+		}
+		return map[string]any{"id": 100, "type": "row", "collapsed": true, "panels": nested}
+	}
+
+	// newest first, as listed by the dashboard version service
+	versions := []*dashver.DashboardVersionDTO{
+		version(7, row(panel(1, "C"))),
+		version(6, panel(1, "B")),
+		version(5),
+		version(4, panel(1, "B"), panel(2, "other")),
+		version(3, panel(1, "B")),
+		version(2, panel(1, "A"), panel(2, "other")),
+		version(1, panel(2, "other")),
+	}
+
+	changes := panelChanges(versions, nil, 1)
+	require.Len(t, changes, 3)
+	assert.Equal(t, 2, changes[0].Version)
+	assert.Equal(t, 3, changes[1].Version)
+	assert.Equal(t, 7, changes[2].Version)
+	assert.Equal(t, "C", findDashboardPanel(changes[2].Data, 1).Get("title").MustString())
+
+	assert.Empty(t, panelChanges(versions, nil, 3))
+
+	// the oldest version is only listed when it changed the panel since the previous one
+	changes = panelChanges(versions[:4], versions[4], 1)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 7, changes[0].Version)
+}
+
+func TestRevertDashboardPanel(t *testing.T) {
+	previous := map[string]any{"id": 1, "title": "Old", "gridPos": map[string]any{"x": 0, "y": 0}}
+
+	t.Run("should replace the panel keeping its position", func(t *testing.T) {
+		data := simplejson.NewFromAny(map[string]any{"panels": []any{
+			map[string]any{"id": 2, "title": "Other"},
+			map[string]any{"type": "row", "panels": []any{
+				map[string]any{"id": 1, "title": "New", "description": "added later", "gridPos": map[string]any{"x": 12, "y": 8}},
+			}},
+		}})
+
+		revertDashboardPanel(data, 1, previous)
+		panel := findDashboardPanel(data, 1)
+		assert.Equal(t, map[string]any{"id": 1, "title": "Old", "gridPos": map[string]any{"x": 12, "y": 8}}, panel.MustMap())
+		assert.Equal(t, "Other", findDashboardPanel(data, 2).Get("title").MustString())
+	})
+
+	t.Run("should add back a removed panel", func(t *testing.T) {
+		data := simplejson.NewFromAny(map[string]any{"panels": []any{map[string]any{"id": 2}}})
+
+		revertDashboardPanel(data, 1, previous)
+		require.Len(t, data.Get("panels").MustArray(), 2)
+		assert.Equal(t, previous, findDashboardPanel(data, 1).MustMap())
+	})
+}
+
+func TestHTTPServer_RevertDashboardPanel(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("Dash")
+		dash.ID = 1
+		dash.UID = "dash"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{
+			ExpectedDashboardVersion: &dashver.DashboardVersionDTO{
+				Version: 2,
+				Data:    simplejson.NewFromAny(map[string]any{"panels": []any{map[string]any{"id": 2}}}),
+			},
+		}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	revert := func(body string) *http.Response {
+		req := server.NewPostRequest("/api/dashboards/uid/dash/panels/1/revert", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+			{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"},
+		})))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("should fail when the panel is not in the version", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, revert(`{"version": 2}`).StatusCode)
+	})
+
+	t.Run("should require a version", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, revert(`{}`).StatusCode)
+	})
+}
+
+type listVersionsRecorder struct {
+	dashvertest.FakeDashboardVersionService
+	queries []*dashver.ListDashboardVersionsQuery
+}
+
+func (r *listVersionsRecorder) List(ctx context.Context, query *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersionDTO, error) {
+	r.queries = append(r.queries, query)
+	return []*dashver.DashboardVersionDTO{}, nil
+}
+
+func TestGetDashboardPanelVersions(t *testing.T) {
+	versions := &listVersionsRecorder{}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("Dash")
+		dash.ID = 1
+		dash.UID = "dash"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardVersionService = versions
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	get := func(url string) *http.Response {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+			{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"},
+		})))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("should list a bounded page of versions", func(t *testing.T) {
+		res := get("/api/dashboards/uid/dash/panels/1/versions?start=20")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, versions.queries, 1)
+		assert.Equal(t, maxDashboardVersionsWithData+1, versions.queries[0].Limit)
+		assert.Equal(t, 20, versions.queries[0].Start)
+	})
+
+	t.Run("should reject a limit beyond the versions loaded with data", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/api/dashboards/uid/dash/panels/1/versions?limit=21").StatusCode)
+	})
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// homeDashboardCache keeps the parsed default home dashboard, so that the
+// file is only read and parsed again when the configured path changes.
+type homeDashboardCache struct {
+	mu   sync.Mutex
+	path string
+	data map[string]any
+}
+
+// get returns the home dashboard stored at path. Only the top level of the
+// returned dashboard is copied: nested values are shared with the cache and
+// must be replaced rather than modified in place.
+func (c *homeDashboardCache) get(path string) (*simplejson.Json, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data == nil || c.path != path {
+		data, err := readHomeDashboard(path)
+		if err != nil {
+			return nil, err
+		}
+		c.path, c.data = path, data
+	}
+
+	dash := make(map[string]any, len(c.data))
+	for key, value := range c.data {
+		dash[key] = value
+	}
+	return simplejson.NewFromAny(dash), nil
+}
+
+func readHomeDashboard(path string) (map[string]any, error) {
+	// It's safe to ignore gosec warning G304 since the variable part of the file path comes from a configuration
+	// variable
+	// nolint:gosec
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	dash := simplejson.New()
+	if err := json.NewDecoder(file).Decode(dash); err != nil {
+		return nil, err
+	}
+	return dash.Map()
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHomeDashboardCache(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+	require.NoError(t, os.WriteFile(first, []byte(`{"title": "First", "panels": [{"id": 1}]}`), 0600))
+	require.NoError(t, os.WriteFile(second, []byte(`{"title": "Second"}`), 0600))
+
+	var cache homeDashboardCache
+
+	dash, err := cache.get(first)
+	require.NoError(t, err)
+	assert.Equal(t, "First", dash.Get("title").MustString())
+
+	t.Run("should not read the file again for the same path", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(first, []byte(`{"title": "Changed"}`), 0600))
+
+		dash, err := cache.get(first)
+		require.NoError(t, err)
+		assert.Equal(t, "First", dash.Get("title").MustString())
+	})
+
+	t.Run("should not share changes to the returned dashboard", func(t *testing.T) {
+		dash.Set("title", "Modified")
+		dash.Set("panels", append(dash.Get("panels").MustArray(), map[string]any{"id": 2}))
+
+		dash, err := cache.get(first)
+		require.NoError(t, err)
+		assert.Equal(t, "First", dash.Get("title").MustString())
+		assert.Len(t, dash.Get("panels").MustArray(), 1)
+	})
+
+	t.Run("should read the file again when the path changes", func(t *testing.T) {
+		dash, err := cache.get(second)
+		require.NoError(t, err)
+		assert.Equal(t, "Second", dash.Get("title").MustString())
+	})
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// applyIfMatch checks the If-Match header of a dashboard save against the
+// stored dashboard. On match the command is based on the stored version, so
+// concurrent saves are still detected by the version check of the save. It
+// returns the response to send when the precondition fails.
+func (hs *HTTPServer) applyIfMatch(c *contextmodel.ReqContext, cmd *dashboards.SaveDashboardCommand) response.Response {
+	ifMatch := c.Req.Header.Get("If-Match")
+	if ifMatch == "" || cmd.Dashboard == nil || cmd.SaveAsCopy {
+		return nil
+	}
+
+	query := dashboards.GetDashboardQuery{UID: cmd.Dashboard.Get("uid").MustString(), OrgID: c.SignedInUser.GetOrgID()}
+	if query.UID == "" {
+		query.ID = cmd.Dashboard.Get("id").MustInt64() // nolint:staticcheck
+	}
+	if query.UID == "" && query.ID == 0 { // nolint:staticcheck
+		return response.JSON(http.StatusPreconditionFailed, util.DynMap{"status": "version-mismatch", "message": "If-Match requires an existing dashboard", "messageId": dashboards.ErrDashboardVersionMismatch.MessageID})
+	}
+
+	existing, err := hs.DashboardService.GetDashboard(c.Req.Context(), &query)
+	if err != nil {
+		if errors.Is(err, dashboards.ErrDashboardNotFound) {
+			return response.JSON(http.StatusPreconditionFailed, util.DynMap{"status": "version-mismatch", "message": "If-Match requires an existing dashboard", "messageId": dashboards.ErrDashboardVersionMismatch.MessageID})
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard", err)
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), existing, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	if !ifMatchesDashboard(ifMatch, existing) {
+		return response.JSON(http.StatusPreconditionFailed, util.DynMap{
+			"status":    dashboards.ErrDashboardVersionMismatch.Status,
+			"message":   dashboards.ErrDashboardVersionMismatch.Reason,
+			"messageId": dashboards.ErrDashboardVersionMismatch.MessageID,
+			"version":   existing.Version,
+		}).SetHeader("ETag", dashboardETag(existing))
+	}
+
+	cmd.Dashboard.Set("version", existing.Version)
+	cmd.Overwrite = false
+	return nil
+}
+
+// ifMatchesDashboard reports whether the If-Match header matches the stored
+// dashboard, either by the ETag returned by GetDashboard or by its version.
+// Only the saved state part of GetDashboard ETags is compared, the meta of the
+// dashboard doesn't matter for a save.
+func ifMatchesDashboard(ifMatch string, dash *dashboards.Dashboard) bool {
+	state := dashboardETagState(dash)
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.Trim(strings.TrimPrefix(strings.TrimSpace(candidate), "W/"), `"`)
+		if candidate == "*" || candidate == state || strings.HasPrefix(candidate, state+"-") {
+			return true
+		}
+		if version, err := strconv.Atoi(candidate); err == nil && version == dash.Version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestPostDashboard_IfMatch(t *testing.T) {
+	existing := dashboards.NewDashboard("Dash")
+	existing.ID = 1
+	existing.UID = "dash"
+	existing.OrgID = 1
+	existing.Version = 5
+	existing.Updated = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	existing.Data = simplejson.NewFromAny(map[string]any{"id": 1, "uid": "dash", "title": "Dash", "version": 5})
+
+	var saved []*dashboards.SaveDashboardDTO
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(existing, nil)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO))
+		}).Return(&dashboards.Dashboard{ID: 1, UID: "dash", Title: "Renamed", Version: 6}, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	save := func(t *testing.T, ifMatch string) (*http.Response, map[string]any) {
+		t.Helper()
+		req := server.NewPostRequest("/api/dashboards/db", strings.NewReader(`{"dashboard": {"id": 1, "uid": "dash", "title": "Renamed"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+			{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"},
+		})))
+		require.NoError(t, err)
+		result := map[string]any{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		return res, result
+	}
+
+	t.Run("should save based on the stored version when the version matches", func(t *testing.T) {
+		saved = nil
+		res, _ := save(t, `"5"`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, saved, 1)
+		assert.Equal(t, 5, saved[0].Dashboard.Version)
+		assert.False(t, saved[0].Overwrite)
+	})
+
+	t.Run("should save when the etag matches", func(t *testing.T) {
+		saved = nil
+		res, _ := save(t, dashboardETag(existing))
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, saved, 1)
+	})
+
+	t.Run("should save when the etag of a get dashboard response matches", func(t *testing.T) {
+		saved = nil
+		etag, err := dashboardResponseETag(existing, map[string]any{"meta": map[string]any{"canSave": true}})
+		require.NoError(t, err)
+		res, _ := save(t, etag)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, saved, 1)
+	})
+
+	t.Run("should return the current version on mismatch", func(t *testing.T) {
+		saved = nil
+		res, result := save(t, `"4"`)
+		require.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+		assert.Equal(t, "version-mismatch", result["status"])
+		assert.Equal(t, float64(5), result["version"])
+		assert.Equal(t, dashboardETag(existing), res.Header.Get("ETag"))
+		assert.Empty(t, saved)
+	})
+}
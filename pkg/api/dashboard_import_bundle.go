@@ -0,0 +1,283 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+)
+
+// swagger:route POST /dashboards/import-bundle dashboards importDashboardBundle
+//
+// Import a bundle of dashboards.
+//
+// Imports the dashboards of a bundle one at a time through the regular save path, for example to migrate
+// the dashboards exported from another instance. The bundle is either newline-delimited JSON in the format
+// of the export of all dashboards, or a zip archive whose .json files each hold one such line. Folders of
+// the bundle that don't exist are created, titled with `folderTitle` or else with the folder uid, when the
+// signed in user is allowed to create folders; otherwise their dashboards are reported as forbidden. Existing
+// dashboards are only replaced with `overwrite`.
+//
+// The dashboard quota is checked before each new dashboard. Once it is reached the import stops: the
+// dashboards imported so far are kept, the remaining ones are reported as skipped and quotaReached is set.
+//
+// Bundles are limited to 100MB, and zip archives to 10000 files of at most 10MB each.
+//
+// Consumes:
+// - application/x-ndjson
+// - application/zip
+//
+// Responses:
+// 200: importDashboardBundleResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 413: contentTooLargeError
+func (hs *HTTPServer) ImportDashboardBundle(c *contextmodel.ReqContext) response.Response {
+	c.Req.Body = http.MaxBytesReader(c.Resp, c.Req.Body, dashboardBundleMaxBytes)
+	lines, err := readDashboardBundle(c.Req)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) || errors.Is(err, errDashboardBundleTooLarge) {
+			return response.Error(http.StatusRequestEntityTooLarge, "Dashboard bundle too large", err)
+		}
+		return response.Error(http.StatusBadRequest, "Invalid dashboard bundle", err)
+	}
+
+	overwrite := c.QueryBool("overwrite")
+	folders := map[string]error{}
+	result := dtos.DashboardImportBundleResult{Results: make([]dtos.BulkDashboardResult, 0, len(lines))}
+	for i, line := range lines {
+		if line.FolderUID != "" {
+			if _, ok := folders[line.FolderUID]; !ok {
+				folders[line.FolderUID] = hs.ensureBundleFolder(c, line.FolderUID, line.FolderTitle)
+			}
+		}
+
+		item, quotaReached := hs.importBundleDashboard(c, line, folders[line.FolderUID], overwrite)
+		if quotaReached {
+			for _, rest := range lines[i:] {
+				result.Results = append(result.Results, dtos.BulkDashboardResult{
+					UID:     rest.UID,
+					Title:   rest.Dashboard.Get("title").MustString(),
+					Status:  bulkResultSkipped,
+					Message: "Dashboard quota reached",
+				})
+			}
+			result.QuotaReached = true
+			break
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// Limits of an imported bundle, bounding the memory used to read it.
+const (
+	dashboardBundleMaxBytes = 100 << 20
+	// dashboardBundleMaxFiles and dashboardBundleMaxFileBytes bound the files
+	// of a zip archive, whose decompressed size isn't bound by its size.
+	dashboardBundleMaxFiles     = 10000
+	dashboardBundleMaxFileBytes = 10 << 20
+)
+
+var errDashboardBundleTooLarge = errors.New("dashboard bundle too large")
+
+// readDashboardBundle parses the lines of a bundle sent either as NDJSON or
+// as a zip archive of JSON files.
+func readDashboardBundle(req *http.Request) ([]dtos.DashboardExportLine, error) {
+	var lines []dtos.DashboardExportLine
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/zip") {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			return nil, err
+		}
+		if len(archive.File) > dashboardBundleMaxFiles {
+			return nil, fmt.Errorf("%w: more than %d files", errDashboardBundleTooLarge, dashboardBundleMaxFiles)
+		}
+		for _, f := range archive.File {
+			if f.FileInfo().IsDir() || path.Ext(f.Name) != ".json" {
+				continue
+			}
+			line, err := readDashboardBundleFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Name, err)
+			}
+			lines = append(lines, line)
+		}
+	} else {
+		dec := json.NewDecoder(req.Body)
+		for {
+			var line dtos.DashboardExportLine
+			if err := dec.Decode(&line); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("line %d: %w", len(lines)+1, err)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	for i, line := range lines {
+		if line.Dashboard == nil {
+			return nil, fmt.Errorf("entry %d has no dashboard", i+1)
+		}
+	}
+	return lines, nil
+}
+
+func readDashboardBundleFile(f *zip.File) (dtos.DashboardExportLine, error) {
+	var line dtos.DashboardExportLine
+	r, err := f.Open()
+	if err != nil {
+		return line, err
+	}
+	defer func() { _ = r.Close() }()
+	// the size in the header can't be trusted, the read is limited instead
+	b, err := io.ReadAll(io.LimitReader(r, dashboardBundleMaxFileBytes+1))
+	if err != nil {
+		return line, err
+	}
+	if len(b) > dashboardBundleMaxFileBytes {
+		return line, fmt.Errorf("%w: file larger than %d bytes", errDashboardBundleTooLarge, dashboardBundleMaxFileBytes)
+	}
+	err = json.Unmarshal(b, &line)
+	return line, err
+}
+
+var errBundleFolderForbidden = errors.New("not allowed to create folders")
+
+// ensureBundleFolder creates the folder with the given uid unless it exists,
+// giving the creator the same permissions as creating it from the folder API.
+func (hs *HTTPServer) ensureBundleFolder(c *contextmodel.ReqContext, uid, title string) error {
+	_, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, dashboards.ErrFolderNotFound) && !errors.Is(err, folder.ErrFolderNotFound) {
+		return err
+	}
+
+	// creating a folder only checks permissions on its parent, bundle folders
+	// are created at the root which is gated by the folder API route alone
+	canCreate, err := hs.AccessControl.Evaluate(c.Req.Context(), c.SignedInUser, ac.EvalPermission(dashboards.ActionFoldersCreate))
+	if err != nil {
+		return err
+	}
+	if !canCreate {
+		return errBundleFolderForbidden
+	}
+
+	if title == "" {
+		title = uid
+	}
+	f, err := hs.folderService.Create(c.Req.Context(), &folder.CreateFolderCommand{
+		UID:          uid,
+		Title:        title,
+		OrgID:        c.SignedInUser.GetOrgID(),
+		SignedInUser: c.SignedInUser,
+	})
+	if err != nil {
+		return err
+	}
+	if err := hs.setDefaultFolderPermissions(c.Req.Context(), f.OrgID, c.SignedInUser, f); err != nil {
+		hs.log.Error("Could not set the default folder permissions", "folder", f.Title, "user", c.SignedInUser, "error", err)
+	}
+	hs.accesscontrolService.ClearUserPermissionCache(c.SignedInUser)
+	return nil
+}
+
+// importBundleDashboard saves a single dashboard of a bundle. It reports
+// whether the dashboard quota stopped it from being created, in which case
+// the returned result is empty.
+func (hs *HTTPServer) importBundleDashboard(c *contextmodel.ReqContext, line dtos.DashboardExportLine, folderErr error, overwrite bool) (dtos.BulkDashboardResult, bool) {
+	data := line.Dashboard
+	result := dtos.BulkDashboardResult{UID: line.UID, Title: data.Get("title").MustString()}
+	if folderErr != nil {
+		result.Status, result.Message = bulkResultFailed, "Failed to create folder: "+folderErr.Error()
+		if errors.Is(folderErr, errBundleFolderForbidden) {
+			result.Status = bulkResultForbidden
+		}
+		return result, false
+	}
+
+	// ids are local to an instance, dashboards are matched by uid
+	data.Del("id")
+	if line.UID != "" {
+		data.Set("uid", line.UID)
+	}
+
+	isNew := true
+	if line.UID != "" {
+		existing, err := hs.DashboardService.GetDashboard(c.Req.Context(), &dashboards.GetDashboardQuery{UID: line.UID, OrgID: c.SignedInUser.GetOrgID()})
+		switch {
+		case err == nil:
+			isNew = false
+			if overwrite {
+				data.Set("id", existing.ID)
+			}
+		case !errors.Is(err, dashboards.ErrDashboardNotFound):
+			result.Status, result.Message = bulkResultFailed, err.Error()
+			return result, false
+		}
+	}
+	if isNew {
+		limitReached, err := hs.QuotaService.QuotaReached(c, dashboards.QuotaTargetSrv)
+		if err != nil {
+			result.Status, result.Message = bulkResultFailed, "Failed to get quota"
+			return result, false
+		}
+		if limitReached {
+			return dtos.BulkDashboardResult{}, true
+		}
+	}
+
+	saved, rsp := hs.saveDashboard(c, dashboards.SaveDashboardCommand{
+		Dashboard: data,
+		FolderUID: line.FolderUID,
+		Overwrite: overwrite,
+		Message:   "Imported from bundle",
+	})
+	if rsp != nil {
+		result.Status, result.Message = bulkResponseResult(rsp)
+		return result, false
+	}
+	result.Status = bulkResultImported
+	result.UID, _ = saved["uid"].(string)
+	result.Version, _ = saved["version"].(int)
+	return result, false
+}
+
+// swagger:parameters importDashboardBundle
+type ImportDashboardBundleParams struct {
+	// in:body
+	// required:true
+	Body []dtos.DashboardExportLine
+	// Replace dashboards of the bundle that already exist.
+	// in:query
+	// required:false
+	Overwrite bool `json:"overwrite"`
+}
+
+// swagger:response importDashboardBundleResponse
+type ImportDashboardBundleResponse struct {
+	// in: body
+	Body dtos.DashboardImportBundleResult `json:"body"`
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/quota"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+// savedDashboardsQuota is reached once limit dashboards have been saved.
+type savedDashboardsQuota struct {
+	*quotatest.FakeQuotaService
+	saved *int
+	limit int
+}
+
+func (q savedDashboardsQuota) QuotaReached(c *contextmodel.ReqContext, target quota.TargetSrv) (bool, error) {
+	return *q.saved >= q.limit, nil
+}
+
+func TestImportDashboardBundle(t *testing.T) {
+	saved := 0
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(nil, dashboards.ErrDashboardNotFound).Maybe()
+		for _, uid := range []string{"a", "b", "c"} {
+			uid := uid
+			dashSvc.On("SaveDashboard", mock.Anything, mock.MatchedBy(func(dto *dashboards.SaveDashboardDTO) bool {
+				return dto.Dashboard.UID == uid
+			}), mock.Anything).Run(func(mock.Arguments) { saved++ }).Return(&dashboards.Dashboard{ID: 1, UID: uid, Title: strings.ToUpper(uid), Version: 1}, nil).Maybe()
+		}
+		hs.DashboardService = dashSvc
+		hs.QuotaService = savedDashboardsQuota{FakeQuotaService: quotatest.New(false, nil), saved: &saved, limit: 2}
+		hs.folderService = &foldertest.FakeService{ExpectedError: folder.ErrFolderNotFound}
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	send := func(t *testing.T, contentType string, body io.Reader) *http.Response {
+		t.Helper()
+		req := server.NewPostRequest("/api/dashboards/import-bundle", body)
+		req.Header.Set("Content-Type", contentType)
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsCreate, Scope: dashboards.ScopeFoldersAll},
+		})))
+		require.NoError(t, err)
+		return res
+	}
+	bundle := `{"uid": "a", "folderUid": "", "dashboard": {"title": "A"}}
+{"uid": "b", "folderUid": "", "dashboard": {"title": "B"}}
+{"uid": "c", "folderUid": "", "dashboard": {"title": "C"}}
+`
+
+	t.Run("should stop with a partial report when the quota is reached", func(t *testing.T) {
+		saved = 0
+		res := send(t, "application/x-ndjson", strings.NewReader(bundle))
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardImportBundleResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+
+		assert.True(t, result.QuotaReached)
+		require.Len(t, result.Results, 3)
+		assert.Equal(t, dtos.BulkDashboardResult{UID: "a", Title: "A", Status: bulkResultImported, Version: 1}, result.Results[0])
+		assert.Equal(t, bulkResultImported, result.Results[1].Status)
+		assert.Equal(t, dtos.BulkDashboardResult{UID: "c", Title: "C", Status: bulkResultSkipped, Message: "Dashboard quota reached"}, result.Results[2])
+	})
+
+	t.Run("should import the json files of a zip archive", func(t *testing.T) {
+		saved = 0
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		f, err := archive.Create("dashboards/a.json")
+		require.NoError(t, err)
+		_, err = f.Write([]byte(`{"uid": "a", "dashboard": {"title": "A"}}`))
+		require.NoError(t, err)
+		_, err = archive.Create("README.md")
+		require.NoError(t, err)
+		require.NoError(t, archive.Close())
+
+		res := send(t, "application/zip", &buf)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardImportBundleResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+
+		assert.False(t, result.QuotaReached)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, bulkResultImported, result.Results[0].Status)
+	})
+
+	t.Run("should reject an invalid bundle", func(t *testing.T) {
+		res := send(t, "application/x-ndjson", strings.NewReader(`{"uid": "a"}`))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should not create folders without permission to create folders", func(t *testing.T) {
+		saved = 0
+		res := send(t, "application/x-ndjson", strings.NewReader(`{"uid": "a", "folderUid": "new", "dashboard": {"title": "A"}}`))
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardImportBundleResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, bulkResultForbidden, result.Results[0].Status)
+		assert.Zero(t, saved)
+	})
+
+	t.Run("should reject a zip archive with a too large file", func(t *testing.T) {
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		f, err := archive.Create("a.json")
+		require.NoError(t, err)
+		_, err = f.Write(bytes.Repeat([]byte(" "), dashboardBundleMaxFileBytes+1))
+		require.NoError(t, err)
+		require.NoError(t, archive.Close())
+
+		res := send(t, "application/zip", &buf)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+}
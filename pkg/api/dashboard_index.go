@@ -0,0 +1,431 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/search/model"
+)
+
+const (
+	// readableDashboardsPageSize is the number of dashboards fetched per search
+	// page when walking all dashboards a user can read.
+	readableDashboardsPageSize = 1000
+	// dashboardIndexMaxEntries bounds the number of dashboards kept in the
+	// index, the least recently used entries are dropped first.
+	dashboardIndexMaxEntries = 10000
+)
+
+// dashboardIndex keeps a summary of the panels, datasource references and
+// template variables of recently used dashboards, so that fleet wide reports
+// don't have to load and parse every dashboard on each request. Entries are
+// refreshed when a dashboard is saved and lazily whenever the stored version
+// no longer matches the indexed one. A nil index computes entries without
+// caching them.
+type dashboardIndex struct {
+	mu         sync.Mutex
+	maxEntries int
+	// entries holds the *list.Element of every indexed dashboard, whose values
+	// are ordered from the most to the least recently used in lru.
+	entries map[dashboardIndexKey]*list.Element
+	lru     *list.List
+}
+
+type dashboardIndexKey struct {
+	orgID int64
+	uid   string
+}
+
+type dashboardIndexEntry struct {
+	UID          string
+	Title        string
+	FolderUID    string
+	Version      int
+	Panels       []dashboardIndexPanel
+	TemplateVars []string
+}
+
+type dashboardIndexPanel struct {
+	ID          int64
+	Title       string
+	Type        string
+	Datasources []dashboardDatasourceRef
+	// Queries holds the query expressions of the panel targets.
+	Queries []string
+	// Options maps the dotted path of every option set on the panel, e.g.
+	// options.legend.displayMode, to its value.
+	Options map[string]string
+}
+
+// dashboardDatasourceRef is a datasource reference as found in a panel or
+// target. Legacy string references are stored in UID.
+type dashboardDatasourceRef struct {
+	UID  string `json:"uid,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+type dashboardIndexItem struct {
+	key   dashboardIndexKey
+	entry *dashboardIndexEntry
+}
+
+func newDashboardIndex(maxEntries int) *dashboardIndex {
+	return &dashboardIndex{
+		maxEntries: maxEntries,
+		entries:    make(map[dashboardIndexKey]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// get returns the index entry for the dashboard, rebuilding it if the indexed
+// version is stale.
+func (idx *dashboardIndex) get(dash *dashboards.Dashboard) *dashboardIndexEntry {
+	if entry, ok := idx.lookup(dash); ok {
+		return entry
+	}
+	return idx.update(dash)
+}
+
+// lookup returns the index entry for the dashboard if it is indexed at the
+// version of the dashboard. Only the version of the dashboard is read, so it
+// can be looked up without its JSON.
+func (idx *dashboardIndex) lookup(dash *dashboards.Dashboard) (*dashboardIndexEntry, bool) {
+	if idx == nil {
+		return nil, false
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	elem, ok := idx.entries[dashboardIndexKey{dash.OrgID, dash.UID}]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*dashboardIndexItem).entry
+	if entry.Version != dash.Version {
+		return nil, false
+	}
+	idx.lru.MoveToFront(elem)
+	return entry, true
+}
+
+// update (re)computes the index entry for the dashboard.
+func (idx *dashboardIndex) update(dash *dashboards.Dashboard) *dashboardIndexEntry {
+	entry := buildDashboardIndexEntry(dash)
+	if idx == nil {
+		return entry
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	key := dashboardIndexKey{dash.OrgID, dash.UID}
+	if elem, ok := idx.entries[key]; ok {
+		elem.Value.(*dashboardIndexItem).entry = entry
+		idx.lru.MoveToFront(elem)
+		return entry
+	}
+	idx.entries[key] = idx.lru.PushFront(&dashboardIndexItem{key: key, entry: entry})
+	for idx.lru.Len() > idx.maxEntries {
+		oldest := idx.lru.Back()
+		idx.lru.Remove(oldest)
+		delete(idx.entries, oldest.Value.(*dashboardIndexItem).key)
+	}
+	return entry
+}
+
+// remove drops the index entry of a deleted dashboard.
+func (idx *dashboardIndex) remove(orgID int64, uid string) {
+	if idx == nil {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	key := dashboardIndexKey{orgID, uid}
+	if elem, ok := idx.entries[key]; ok {
+		idx.lru.Remove(elem)
+		delete(idx.entries, key)
+	}
+}
+
+func buildDashboardIndexEntry(dash *dashboards.Dashboard) *dashboardIndexEntry {
+	entry := &dashboardIndexEntry{
+		UID:       dash.UID,
+		Title:     dash.Title,
+		FolderUID: dash.FolderUID,
+		Version:   dash.Version,
+		Panels:    []dashboardIndexPanel{},
+	}
+	if dash.Data == nil {
+		return entry
+	}
+
+	for _, variable := range dash.Data.GetPath("templating", "list").MustArray() {
+		name := simplejson.NewFromAny(variable).Get("name").MustString()
+		if name != "" {
+			entry.TemplateVars = append(entry.TemplateVars, name)
+		}
+	}
+
+	forEachDashboardPanel(dash.Data, func(panel *simplejson.Json) {
+		entry.Panels = append(entry.Panels, dashboardIndexPanel{
+			ID:          panel.Get("id").MustInt64(),
+			Title:       panel.Get("title").MustString(),
+			Type:        panel.Get("type").MustString(),
+			Datasources: panelDatasourceRefs(panel),
+			Queries:     panelQueries(panel),
+			Options:     panelOptions(panel),
+		})
+	})
+
+	return entry
+}
+
+// forEachDashboardPanel calls fn for every panel of the dashboard, including
+// panels nested in collapsed rows and in the legacy rows layout.
+func forEachDashboardPanel(data *simplejson.Json, fn func(panel *simplejson.Json)) {
+	var walk func(panels *simplejson.Json)
+	walk = func(panels *simplejson.Json) {
+		for i := range panels.MustArray() {
+			panel := panels.GetIndex(i)
+			fn(panel)
+			if _, ok := panel.CheckGet("panels"); ok {
+				walk(panel.Get("panels"))
+			}
+		}
+	}
+
+	walk(data.Get("panels"))
+	for i := range data.Get("rows").MustArray() {
+		walk(data.Get("rows").GetIndex(i).Get("panels"))
+	}
+}
+
+// panelDatasourceRefs returns the distinct datasource references of a panel
+// and its targets.
+func panelDatasourceRefs(panel *simplejson.Json) []dashboardDatasourceRef {
+	refs := []dashboardDatasourceRef{}
+	seen := make(map[dashboardDatasourceRef]bool)
+	add := func(value *simplejson.Json) {
+		ref, ok := parseDatasourceRef(value)
+		if ok && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	add(panel.Get("datasource"))
+	targets := panel.Get("targets")
+	for i := range targets.MustArray() {
+		add(targets.GetIndex(i).Get("datasource"))
+	}
+	return refs
+}
+
+// panelQueries returns the query expressions of the panel targets, read from
+// the fields used by the common query editors.
+func panelQueries(panel *simplejson.Json) []string {
+	var queries []string
+	targets := panel.Get("targets")
+	for i := range targets.MustArray() {
+		target := targets.GetIndex(i)
+		for _, field := range []string{"expr", "query", "rawSql"} {
+			if query := target.Get(field).MustString(); query != "" {
+				queries = append(queries, query)
+				break
+			}
+		}
+	}
+	return queries
+}
+
+// panelOptions flattens the panel configuration, except its queries, layout
+// and nested panels, into dotted paths. Arrays are kept as encoded values.
+func panelOptions(panel *simplejson.Json) map[string]string {
+	options := make(map[string]string)
+	var flatten func(prefix string, value any)
+	flatten = func(prefix string, value any) {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			if s, isString := value.(string); isString {
+				options[prefix] = s
+			} else if b, err := json.Marshal(value); err == nil {
+				options[prefix] = string(b)
+			}
+			return
+		}
+		for key, child := range obj {
+			if prefix == "" && (key == "targets" || key == "panels" || key == "gridPos") {
+				continue
+			}
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, child)
+		}
+	}
+	flatten("", panel.Interface())
+	return options
+}
+
+// parseDatasourceRef reads a datasource reference that is either a legacy
+// datasource name or an object with uid and type. Null references (the
+// default datasource) are ignored.
+func parseDatasourceRef(value *simplejson.Json) (dashboardDatasourceRef, bool) {
+	if name, err := value.String(); err == nil {
+		return dashboardDatasourceRef{UID: name}, name != ""
+	}
+
+	if _, err := value.Map(); err != nil {
+		return dashboardDatasourceRef{}, false
+	}
+	ref := dashboardDatasourceRef{
+		UID:  value.Get("uid").MustString(),
+		Type: value.Get("type").MustString(),
+	}
+	return ref, ref.UID != ""
+}
+
+// isTemplated reports whether the reference points to a template variable.
+func (r dashboardDatasourceRef) isTemplated() bool {
+	return strings.HasPrefix(r.UID, "$")
+}
+
+// isBuiltIn reports whether the reference points to one of the special
+// datasources that exist on every instance.
+func (r dashboardDatasourceRef) isBuiltIn() bool {
+	switch r.UID {
+	case "grafana", "-- Grafana --", "-- Mixed --", "-- Dashboard --":
+		return true
+	}
+	return false
+}
+
+// readableDashboards returns every dashboard of the signed in user's org that
+// the user is allowed to view, optionally limited to the given folders.
+func (hs *HTTPServer) readableDashboards(c *contextmodel.ReqContext, folderUIDs ...string) ([]*dashboards.Dashboard, error) {
+	return hs.searchDashboards(c, &search.Query{
+		FolderUIDs: folderUIDs,
+		Permission: dashboards.PERMISSION_VIEW,
+	})
+}
+
+// indexedDashboard is a dashboard, without its JSON, with its index entry.
+type indexedDashboard struct {
+	*dashboards.Dashboard
+	entry *dashboardIndexEntry
+}
+
+// readableIndexedDashboards is like readableDashboards but returns the index
+// entries of the dashboards instead of their JSON. Only the dashboards that
+// aren't indexed at their current version are loaded with their JSON.
+func (hs *HTTPServer) readableIndexedDashboards(c *contextmodel.ReqContext) ([]indexedDashboard, error) {
+	ctx, orgID := c.Req.Context(), c.SignedInUser.GetOrgID()
+	uids, err := hs.searchDashboardUIDs(c, &search.Query{Permission: dashboards.PERMISSION_VIEW})
+	if err != nil {
+		return nil, err
+	}
+	dashes, err := hs.queryDashboardsByUIDs(ctx, dashboards.GetDashboardsQuery{OrgID: orgID, WithoutData: true}, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]indexedDashboard, len(dashes))
+	stale := make(map[string]int)
+	staleUIDs := []string{}
+	for i, dash := range dashes {
+		result[i].Dashboard = dash
+		if entry, ok := hs.dashboardIndex.lookup(dash); ok {
+			result[i].entry = entry
+			continue
+		}
+		stale[dash.UID] = i
+		staleUIDs = append(staleUIDs, dash.UID)
+	}
+	if len(staleUIDs) == 0 {
+		return result, nil
+	}
+
+	dashes, err = hs.getDashboardsByUIDs(ctx, orgID, staleUIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, dash := range dashes {
+		result[stale[dash.UID]] = indexedDashboard{Dashboard: dash, entry: hs.dashboardIndex.update(dash)}
+	}
+	// dashboards deleted in the meantime have no entry
+	indexed := result[:0]
+	for _, dash := range result {
+		if dash.entry != nil {
+			indexed = append(indexed, dash)
+		}
+	}
+	return indexed, nil
+}
+
+// searchDashboards returns every dashboard of the signed in user's org that
+// matches the query, walking all pages of search results.
+func (hs *HTTPServer) searchDashboards(c *contextmodel.ReqContext, query *search.Query) ([]*dashboards.Dashboard, error) {
+	uids, err := hs.searchDashboardUIDs(c, query)
+	if err != nil {
+		return nil, err
+	}
+	return hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), uids)
+}
+
+// searchDashboardUIDs is like searchDashboards but only returns the uids of
+// the dashboards.
+func (hs *HTTPServer) searchDashboardUIDs(c *contextmodel.ReqContext, query *search.Query) ([]string, error) {
+	query.SignedInUser = c.SignedInUser
+	query.OrgId = c.SignedInUser.GetOrgID()
+	query.Type = string(model.DashHitDB)
+	query.Limit = readableDashboardsPageSize
+
+	var uids []string
+	for page := int64(1); ; page++ {
+		query.Page = page
+		hits, err := hs.SearchService.SearchHandler(c.Req.Context(), query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range hits {
+			uids = append(uids, hit.UID)
+		}
+		if len(hits) < readableDashboardsPageSize {
+			break
+		}
+	}
+	return uids, nil
+}
+
+func (hs *HTTPServer) getDashboardsByUIDs(ctx context.Context, orgID int64, uids []string) ([]*dashboards.Dashboard, error) {
+	return hs.queryDashboardsByUIDs(ctx, dashboards.GetDashboardsQuery{OrgID: orgID}, uids)
+}
+
+// queryDashboardsByUIDs runs the query for the dashboards with the given uids,
+// a page of uids at a time.
+func (hs *HTTPServer) queryDashboardsByUIDs(ctx context.Context, query dashboards.GetDashboardsQuery, uids []string) ([]*dashboards.Dashboard, error) {
+	result := make([]*dashboards.Dashboard, 0, len(uids))
+	for start := 0; start < len(uids); start += readableDashboardsPageSize {
+		end := start + readableDashboardsPageSize
+		if end > len(uids) {
+			end = len(uids)
+		}
+
+		query.DashboardUIDs = uids[start:end]
+		dashes, err := hs.DashboardService.GetDashboards(ctx, &query)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dashes...)
+	}
+	return result, nil
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/search/model"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func newIndexTestDashboard(t *testing.T, body string) *dashboards.Dashboard {
+	t.Helper()
+	data, err := simplejson.NewJson([]byte(body))
+	require.NoError(t, err)
+
+	dash := dashboards.NewDashboard("indexed")
+	dash.OrgID = 1
+	dash.UID = "indexed"
+	dash.Version = 1
+	dash.Data = data
+	return dash
+}
+
+func TestDashboardIndex(t *testing.T) {
+	dash := newIndexTestDashboard(t, `{
+		"templating": {"list": [{"name": "ds", "type": "datasource"}]},
+		"panels": [
+			{"id": 1, "title": "fixed", "datasource": {"uid": "prom-1", "type": "prometheus"},
+			 "targets": [{"datasource": {"uid": "prom-1", "type": "prometheus"}}, {"datasource": "legacy"}]},
+			{"id": 2, "title": "templated", "datasource": {"uid": "${ds}"}},
+			{"id": 3, "type": "row", "collapsed": true, "panels": [
+				{"id": 4, "title": "nested", "datasource": {"uid": "-- Grafana --"}}
+			]}
+		]
+	}`)
+
+	t.Run("should collect panels, datasources and template variables", func(t *testing.T) {
+		entry := buildDashboardIndexEntry(dash)
+		assert.Equal(t, []string{"ds"}, entry.TemplateVars)
+		require.Len(t, entry.Panels, 4)
+		assert.Equal(t, []dashboardDatasourceRef{{UID: "prom-1", Type: "prometheus"}, {UID: "legacy"}}, entry.Panels[0].Datasources)
+		assert.True(t, entry.Panels[1].Datasources[0].isTemplated())
+		assert.Empty(t, entry.Panels[2].Datasources)
+		assert.True(t, entry.Panels[3].Datasources[0].isBuiltIn())
+	})
+
+	t.Run("should rebuild entries when the version changes", func(t *testing.T) {
+		idx := newDashboardIndex(dashboardIndexMaxEntries)
+		entry := idx.get(dash)
+		assert.Same(t, entry, idx.get(dash))
+
+		dash.Version = 2
+		assert.NotSame(t, entry, idx.get(dash))
+
+		idx.remove(dash.OrgID, dash.UID)
+		assert.Empty(t, idx.entries)
+		assert.Zero(t, idx.lru.Len())
+	})
+
+	t.Run("should drop the least recently used entries", func(t *testing.T) {
+		idx := newDashboardIndex(2)
+		other := func(uid string) *dashboards.Dashboard {
+			d := newIndexTestDashboard(t, `{}`)
+			d.UID = uid
+			return d
+		}
+		a, b, c := other("a"), other("b"), other("c")
+		idx.update(a)
+		idx.update(b)
+		_, ok := idx.lookup(a)
+		require.True(t, ok)
+
+		idx.update(c)
+		_, ok = idx.lookup(b)
+		assert.False(t, ok)
+		_, ok = idx.lookup(a)
+		assert.True(t, ok)
+		_, ok = idx.lookup(c)
+		assert.True(t, ok)
+		assert.Len(t, idx.entries, 2)
+	})
+
+	t.Run("nil index should not cache", func(t *testing.T) {
+		var idx *dashboardIndex
+		assert.Len(t, idx.get(dash).Panels, 4)
+		idx.remove(dash.OrgID, dash.UID)
+	})
+}
+
+func TestReadableIndexedDashboards(t *testing.T) {
+	newDash := func(uid string, version int) *dashboards.Dashboard {
+		dash := newIndexTestDashboard(t, `{"panels": [{"id": 1, "datasource": {"uid": "prom-1", "type": "prometheus"}}]}`)
+		dash.UID = uid
+		dash.Version = version
+		return dash
+	}
+
+	index := newDashboardIndex(dashboardIndexMaxEntries)
+	index.update(newDash("a", 1))
+	var queries []dashboards.GetDashboardsQuery
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.SearchService = &mockSearchService{ExpectedResult: model.HitList{{UID: "a"}, {UID: "b"}}}
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return(func(_ context.Context, query *dashboards.GetDashboardsQuery) []*dashboards.Dashboard {
+			queries = append(queries, *query)
+			var dashes []*dashboards.Dashboard
+			for _, uid := range query.DashboardUIDs {
+				dash := newDash(uid, 1)
+				if query.WithoutData {
+					dash.Data = nil
+				}
+				dashes = append(dashes, dash)
+			}
+			return dashes
+		}, nil)
+		hs.DashboardService = dashSvc
+		hs.dashboardIndex = index
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+	res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/hardcoded-datasources"), userWithPermissions(1, permissions)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var result []dtos.HardcodedDatasourceDashboard
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+	require.NoError(t, res.Body.Close())
+
+	require.Len(t, result, 2)
+	assert.Equal(t, "a", result[0].UID)
+	assert.Equal(t, "b", result[1].UID)
+	// only the dashboard missing from the index is loaded with its JSON
+	require.Len(t, queries, 2)
+	assert.True(t, queries[0].WithoutData)
+	assert.False(t, queries[1].WithoutData)
+	assert.Equal(t, []string{"b"}, queries[1].DashboardUIDs)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+)
+
+// saveWarningLibraryPanelDisconnected is the code of the save warning for a
+// library panel that is no longer used by the saved dashboard.
+const saveWarningLibraryPanelDisconnected = "libraryPanelDisconnected"
+
+// disconnectedLibraryPanelWarnings returns a warning for each library panel
+// connected to the dashboard before the save that the saved body no longer
+// references.
+func disconnectedLibraryPanelWarnings(previous map[string]model.LibraryElementDTO, data *simplejson.Json) []dtos.DashboardSaveWarning {
+	if len(previous) == 0 {
+		return nil
+	}
+
+	referenced := map[string]bool{}
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if uid := panel.GetPath("libraryPanel", "uid").MustString(); uid != "" {
+			referenced[uid] = true
+		}
+	})
+
+	var warnings []dtos.DashboardSaveWarning
+	for uid, element := range previous {
+		if element.Kind != int64(model.PanelElement) || referenced[uid] {
+			continue
+		}
+		warnings = append(warnings, dtos.DashboardSaveWarning{
+			Code:             saveWarningLibraryPanelDisconnected,
+			Message:          fmt.Sprintf("library panel %q is no longer used by the dashboard and was disconnected from it", element.Name),
+			LibraryPanelUID:  uid,
+			LibraryPanelName: element.Name,
+		})
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].LibraryPanelUID < warnings[j].LibraryPanelUID })
+	return warnings
+}
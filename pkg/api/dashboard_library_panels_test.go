@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+)
+
+func TestDisconnectedLibraryPanelWarnings(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"panels": [
+			{"id": 1, "libraryPanel": {"uid": "kept", "name": "Kept"}},
+			{"id": 2, "type": "row", "panels": [{"id": 3, "libraryPanel": {"uid": "nested"}}]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	previous := map[string]model.LibraryElementDTO{
+		"kept":     {UID: "kept", Name: "Kept", Kind: int64(model.PanelElement)},
+		"nested":   {UID: "nested", Name: "Nested", Kind: int64(model.PanelElement)},
+		"removed":  {UID: "removed", Name: "Removed", Kind: int64(model.PanelElement)},
+		"variable": {UID: "variable", Name: "Variable", Kind: int64(model.VariableElement)},
+	}
+
+	assert.Equal(t, []dtos.DashboardSaveWarning{{
+		Code:             saveWarningLibraryPanelDisconnected,
+		Message:          `library panel "Removed" is no longer used by the dashboard and was disconnected from it`,
+		LibraryPanelUID:  "removed",
+		LibraryPanelName: "Removed",
+	}}, disconnectedLibraryPanelWarnings(previous, data))
+
+	t.Run("should not warn for new dashboards", func(t *testing.T) {
+		assert.Empty(t, disconnectedLibraryPanelWarnings(nil, data))
+	})
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboardlineage"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route POST /dashboards/uid/{uid}/clone dashboards cloneDashboard
+//
+// Clone a dashboard.
+//
+// Saves a copy of the dashboard under a new uid and title, and records the source dashboard as the
+// parent of the copy in the dashboard lineage.
+//
+// Responses:
+// 200: postDashboardResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 412: preconditionFailedError
+// 422: unprocessableEntityError
+// 500: internalServerError
+func (hs *HTTPServer) CloneDashboard(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.CloneDashboardCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	data, err := cloneDashboardJSON(dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to copy dashboard", err)
+	}
+	data.Set("id", nil)
+	data.Del("uid")
+	data.Del("version")
+	data.Set("title", cmd.Title)
+
+	folderUID := cmd.FolderUID
+	if folderUID == "" {
+		folderUID = dash.FolderUID
+	}
+	rsp = hs.postDashboard(c, dashboards.SaveDashboardCommand{
+		Dashboard: data,
+		FolderUID: folderUID,
+		Message:   fmt.Sprintf("Cloned from %s", dash.Title),
+	})
+	if rsp.Status() != http.StatusOK {
+		return rsp
+	}
+
+	saved, err := simplejson.NewJson(rsp.Body())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to read saved dashboard", err)
+	}
+	parent := dashboardlineage.Parent{Origin: dashboardlineage.OriginClone, UID: dash.UID, Created: time.Now()}
+	if err := hs.lineageStore.SetParent(c.Req.Context(), dash.OrgID, saved.Get("uid").MustString(), parent); err != nil {
+		hs.log.Warn("Failed to record dashboard lineage", "dashboard", saved.Get("uid").MustString(), "error", err)
+	}
+
+	return rsp
+}
+
+// swagger:route GET /dashboards/uid/{uid}/lineage dashboards getDashboardLineage
+//
+// Get the lineage of a dashboard.
+//
+// Returns what the dashboard was cloned or imported from and the dashboards that were cloned or imported
+// from it. Related dashboards the signed in user cannot read are left out.
+//
+// Responses:
+// 200: dashboardLineageResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardLineage(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	ctx := c.Req.Context()
+	lineage := dtos.DashboardLineage{
+		UID:         dash.UID,
+		Ancestors:   []dtos.DashboardLineageEntry{},
+		Descendants: []dtos.DashboardLineageEntry{},
+	}
+
+	seen := map[string]bool{dash.UID: true}
+	for uid := dash.UID; ; {
+		parent, ok, err := hs.lineageStore.GetParent(ctx, dash.OrgID, uid)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to get dashboard lineage", err)
+		}
+		if !ok {
+			break
+		}
+
+		entry := dtos.DashboardLineageEntry{Origin: parent.Origin, GnetID: parent.GnetID, PluginID: parent.PluginID, Created: parent.Created}
+		if parent.UID == "" {
+			lineage.Ancestors = append(lineage.Ancestors, entry)
+			break
+		}
+		if seen[parent.UID] {
+			break
+		}
+		seen[parent.UID] = true
+
+		ancestor, err := hs.DashboardService.GetDashboard(ctx, &dashboards.GetDashboardQuery{UID: parent.UID, OrgID: dash.OrgID})
+		if err != nil {
+			break
+		}
+		if canView, err := hs.canViewDashboard(c, ancestor); err == nil && canView {
+			entry.UID, entry.Title, entry.URL = ancestor.UID, ancestor.Title, ancestor.GetURL()
+			lineage.Ancestors = append(lineage.Ancestors, entry)
+		}
+		uid = ancestor.UID
+	}
+
+	children, err := hs.lineageStore.GetChildren(ctx, dash.OrgID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard lineage", err)
+	}
+	queue := []string{dash.UID}
+	for len(queue) > 0 {
+		parentUID := queue[0]
+		queue = queue[1:]
+		for _, uid := range children[parentUID] {
+			if seen[uid] {
+				continue
+			}
+			seen[uid] = true
+			queue = append(queue, uid)
+
+			descendant, err := hs.DashboardService.GetDashboard(ctx, &dashboards.GetDashboardQuery{UID: uid, OrgID: dash.OrgID})
+			if err != nil {
+				continue
+			}
+			if canView, err := hs.canViewDashboard(c, descendant); err != nil || !canView {
+				continue
+			}
+			parent, _, err := hs.lineageStore.GetParent(ctx, dash.OrgID, uid)
+			if err != nil || parent == nil {
+				continue
+			}
+			lineage.Descendants = append(lineage.Descendants, dtos.DashboardLineageEntry{
+				UID:       descendant.UID,
+				Title:     descendant.Title,
+				URL:       descendant.GetURL(),
+				ParentUID: parentUID,
+				Origin:    parent.Origin,
+				Created:   parent.Created,
+			})
+		}
+	}
+
+	return response.JSON(http.StatusOK, lineage)
+}
+
+// canViewDashboard reports whether the signed in user is allowed to view the dashboard.
+func (hs *HTTPServer) canViewDashboard(c *contextmodel.ReqContext, dash *dashboards.Dashboard) (bool, error) {
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return false, err
+	}
+	return guardian.CanView()
+}
+
+// swagger:parameters cloneDashboard
+type CloneDashboardParams struct {
+	// in:body
+	// required:true
+	Body dtos.CloneDashboardCommand
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:parameters getDashboardLineage
+type GetDashboardLineageParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardLineageResponse
+type DashboardLineageResponse struct {
+	// in: body
+	Body dtos.DashboardLineage `json:"body"`
+}
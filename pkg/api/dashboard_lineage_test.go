@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboardlineage"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardLineage(t *testing.T) {
+	ctx := context.Background()
+	store := dashboardlineage.NewStore(kvstore.NewFakeKVStore())
+	require.NoError(t, store.SetParent(ctx, 1, "root", dashboardlineage.Parent{Origin: dashboardlineage.OriginImport, GnetID: 1860}))
+	require.NoError(t, store.SetParent(ctx, 1, "child", dashboardlineage.Parent{Origin: dashboardlineage.OriginClone, UID: "root"}))
+	require.NoError(t, store.SetParent(ctx, 1, "grandchild", dashboardlineage.Parent{Origin: dashboardlineage.OriginClone, UID: "child"}))
+	require.NoError(t, store.SetParent(ctx, 1, "hidden", dashboardlineage.Parent{Origin: dashboardlineage.OriginClone, UID: "root"}))
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		for i, uid := range []string{"root", "child", "grandchild", "hidden"} {
+			uid := uid
+			dash := dashboards.NewDashboard(uid)
+			dash.ID = int64(i + 1)
+			dash.UID = uid
+			dash.OrgID = 1
+			dashSvc.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool { return q.UID == uid })).Return(dash, nil).Maybe()
+		}
+		// the access control guardian stays installed after this test, keep answering later lookups
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dashboards.NewDashboard("other"), nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.lineageStore = store
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:root"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:child"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:grandchild"},
+	}
+	getLineage := func(uid string) *http.Response {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/"+uid+"/lineage"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should return ancestors and visible descendants", func(t *testing.T) {
+		res := getLineage("root")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var lineage dtos.DashboardLineage
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&lineage))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, lineage.Ancestors, 1)
+		assert.Equal(t, int64(1860), lineage.Ancestors[0].GnetID)
+		require.Len(t, lineage.Descendants, 2)
+		assert.Equal(t, "child", lineage.Descendants[0].UID)
+		assert.Equal(t, "grandchild", lineage.Descendants[1].UID)
+		assert.Equal(t, "child", lineage.Descendants[1].ParentUID)
+	})
+
+	t.Run("should walk the ancestry of a clone", func(t *testing.T) {
+		res := getLineage("grandchild")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var lineage dtos.DashboardLineage
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&lineage))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, lineage.Ancestors, 3)
+		assert.Equal(t, "child", lineage.Ancestors[0].UID)
+		assert.Equal(t, "root", lineage.Ancestors[1].UID)
+		assert.Equal(t, dashboardlineage.OriginImport, lineage.Ancestors[2].Origin)
+		assert.Empty(t, lineage.Descendants)
+	})
+
+	t.Run("should not return the lineage of a dashboard the user cannot read", func(t *testing.T) {
+		res := getLineage("hidden")
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	lintSeverityError   = "error"
+	lintSeverityWarning = "warning"
+	lintSeverityInfo    = "info"
+)
+
+// Best-practice rules checked by the lint endpoint.
+const (
+	lintRulePanelWithoutTitle       = "panelWithoutTitle"
+	lintRuleTargetWithoutDatasource = "targetWithoutDatasource"
+	lintRuleDeprecatedPanelType     = "deprecatedPanelType"
+	lintRuleMissingUnit             = "missingUnit"
+	lintRuleUnusedVariable          = "unusedVariable"
+
+	// lintRuleInvalidDashboard is reported when the dashboard can't be parsed,
+	// it is not a rule that can be selected.
+	lintRuleInvalidDashboard = "invalidDashboard"
+)
+
+var lintRules = []string{
+	lintRulePanelWithoutTitle,
+	lintRuleTargetWithoutDatasource,
+	lintRuleDeprecatedPanelType,
+	lintRuleMissingUnit,
+	lintRuleUnusedVariable,
+}
+
+// deprecatedPanelTypes maps deprecated panel plugins to the plugin replacing them.
+var deprecatedPanelTypes = map[string]string{
+	"graph":                    "timeseries",
+	"singlestat":               "stat",
+	"grafana-singlestat-panel": "stat",
+	"table-old":                "table",
+	"grafana-piechart-panel":   "piechart",
+	"grafana-worldmap-panel":   "geomap",
+}
+
+// swagger:route POST /dashboards/lint dashboards lintDashboard
+//
+// Lint a dashboard.
+//
+// Checks the dashboard for best-practice problems: panels without a title, targets without a data source,
+// deprecated panel types, numeric panels without a unit and template variables that are not used. Unlike
+// validation, the problems found never fail the request. A dashboard that can't be parsed is reported as an
+// invalidDashboard warning.
+//
+// The rules in the body limit the checks to the given rules, all rules are checked when empty.
+//
+// Responses:
+// 200: lintDashboardResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+func (hs *HTTPServer) LintDashboard(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.LintDashboardCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.JSON(http.StatusOK, invalidDashboardLintResult(err))
+	}
+	dashboardJson, err := simplejson.NewJson([]byte(cmd.Dashboard))
+	if err != nil {
+		return response.JSON(http.StatusOK, invalidDashboardLintResult(err))
+	}
+
+	return response.JSON(http.StatusOK, dtos.DashboardLintResult{
+		Warnings: lintDashboard(dashboardJson, cmd.Rules),
+	})
+}
+
+func invalidDashboardLintResult(err error) dtos.DashboardLintResult {
+	return dtos.DashboardLintResult{Warnings: []dtos.DashboardLintWarning{{
+		Rule:     lintRuleInvalidDashboard,
+		Severity: lintSeverityError,
+		Message:  fmt.Sprintf("unable to parse dashboard: %s", err),
+	}}}
+}
+
+// lintDashboard runs the given rules, or all rules when none are given,
+// against the dashboard. Unknown rules are ignored.
+func lintDashboard(data *simplejson.Json, rules []string) []dtos.DashboardLintWarning {
+	enabled := map[string]bool{}
+	for _, rule := range lintRules {
+		enabled[rule] = len(rules) == 0
+	}
+	for _, rule := range rules {
+		if _, ok := enabled[rule]; ok {
+			enabled[rule] = true
+		}
+	}
+
+	warnings := make([]dtos.DashboardLintWarning, 0)
+	add := func(path, rule, severity, message string) {
+		if enabled[rule] {
+			warnings = append(warnings, dtos.DashboardLintWarning{Path: path, Rule: rule, Severity: severity, Message: message})
+		}
+	}
+
+	var lintPanels func(path string, panels []any)
+	lintPanels = func(path string, panels []any) {
+		for i, item := range panels {
+			panel := simplejson.NewFromAny(item)
+			panelPath := fmt.Sprintf("%s[%d]", path, i)
+			panelType := panel.Get("type").MustString()
+			if panelType == "row" {
+				lintPanels(panelPath+".panels", panel.Get("panels").MustArray())
+				continue
+			}
+
+			if panel.Get("title").MustString() == "" {
+				add(panelPath+".title", lintRulePanelWithoutTitle, lintSeverityWarning, "panel has no title")
+			}
+			if replacement, ok := deprecatedPanelTypes[panelType]; ok {
+				add(panelPath+".type", lintRuleDeprecatedPanelType, lintSeverityWarning,
+					fmt.Sprintf("panel type %q is deprecated, use %q instead", panelType, replacement))
+			}
+			if numericPanelTypes[panelType] && panel.GetPath("fieldConfig", "defaults", "unit").MustString() == "" {
+				add(panelPath+".fieldConfig.defaults.unit", lintRuleMissingUnit, lintSeverityInfo, "values are shown without a unit")
+			}
+			if panel.Get("datasource").Interface() == nil {
+				for j, target := range panel.Get("targets").MustArray() {
+					if simplejson.NewFromAny(target).Get("datasource").Interface() == nil {
+						add(fmt.Sprintf("%s.targets[%d].datasource", panelPath, j), lintRuleTargetWithoutDatasource, lintSeverityWarning,
+							"target has no data source and relies on the default data source")
+					}
+				}
+			}
+		}
+	}
+	lintPanels("panels", data.Get("panels").MustArray())
+
+	for i, name := range unusedTemplateVariables(data) {
+		if name != "" {
+			add(fmt.Sprintf("templating.list[%d]", i), lintRuleUnusedVariable, lintSeverityInfo,
+				fmt.Sprintf("template variable %q is not used", name))
+		}
+	}
+
+	return warnings
+}
+
+// unusedTemplateVariables returns, by position in the templating list, the
+// names of the variables that are referenced neither by the dashboard nor by
+// the other variables. Used variables are returned as empty strings.
+func unusedTemplateVariables(data *simplejson.Json) []string {
+	variables := data.GetPath("templating", "list").MustArray()
+	if len(variables) == 0 {
+		return nil
+	}
+
+	rest := map[string]any{}
+	for key, value := range data.MustMap() {
+		if key != "templating" {
+			rest[key] = value
+		}
+	}
+	restJSON, err := simplejson.NewFromAny(rest).Encode()
+	if err != nil {
+		return nil
+	}
+	encoded := make([][]byte, len(variables))
+	for i, variable := range variables {
+		encoded[i], _ = simplejson.NewFromAny(variable).Encode()
+	}
+
+	unused := make([]string, len(variables))
+	for i, variable := range variables {
+		name := simplejson.NewFromAny(variable).Get("name").MustString()
+		if name == "" {
+			continue
+		}
+		reference := regexp.MustCompile(`\$` + regexp.QuoteMeta(name) + `\b|\$\{` + regexp.QuoteMeta(name) + `[}:.]|\[\[` + regexp.QuoteMeta(name) + `[\]:]`)
+		used := reference.Match(restJSON)
+		for j := range variables {
+			if j != i && !used {
+				used = reference.Match(encoded[j])
+			}
+		}
+		if !used {
+			unused[i] = name
+		}
+	}
+	return unused
+}
+
+// swagger:parameters lintDashboard
+type LintDashboardParams struct {
+	// in:body
+	// required:true
+	Body dtos.LintDashboardCommand
+}
+
+// swagger:response lintDashboardResponse
+type LintDashboardResponse struct {
+	// in: body
+	Body dtos.DashboardLintResult `json:"body"`
+}
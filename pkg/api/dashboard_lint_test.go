@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestLintDashboard(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"title": "Lint",
+		"panels": [
+			{"id": 1, "type": "graph", "title": "Requests", "targets": [{"expr": "up"}],
+				"fieldConfig": {"defaults": {"unit": "reqps"}}},
+			{"id": 2, "type": "row", "panels": [
+				{"id": 3, "type": "stat", "datasource": {"uid": "prom"}, "targets": [{"expr": "sum(up{env=\"$env\"})"}]}
+			]}
+		],
+		"templating": {"list": [
+			{"name": "env", "query": "label_values(env)"},
+			{"name": "cluster", "query": "label_values(up{region=\"${region}\"}, cluster)"},
+			{"name": "region", "query": "label_values(region)"}
+		]}
+	}`))
+	require.NoError(t, err)
+
+	t.Run("should report every rule", func(t *testing.T) {
+		assert.Equal(t, []dtos.DashboardLintWarning{
+			{Path: "panels[0].type", Rule: lintRuleDeprecatedPanelType, Severity: lintSeverityWarning, Message: `panel type "graph" is deprecated, use "timeseries" instead`},
+			{Path: "panels[0].targets[0].datasource", Rule: lintRuleTargetWithoutDatasource, Severity: lintSeverityWarning, Message: "target has no data source and relies on the default data source"},
+			{Path: "panels[1].panels[0].title", Rule: lintRulePanelWithoutTitle, Severity: lintSeverityWarning, Message: "panel has no title"},
+			{Path: "panels[1].panels[0].fieldConfig.defaults.unit", Rule: lintRuleMissingUnit, Severity: lintSeverityInfo, Message: "values are shown without a unit"},
+			{Path: "templating.list[1]", Rule: lintRuleUnusedVariable, Severity: lintSeverityInfo, Message: `template variable "cluster" is not used`},
+		}, lintDashboard(data, nil))
+	})
+
+	t.Run("should only run the given rules", func(t *testing.T) {
+		warnings := lintDashboard(data, []string{lintRuleMissingUnit, "unknown"})
+		require.Len(t, warnings, 1)
+		assert.Equal(t, lintRuleMissingUnit, warnings[0].Rule)
+	})
+}
+
+func TestLintDashboardAPI(t *testing.T) {
+	server := SetupAPITestServer(t)
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll}}
+	lint := func(t *testing.T, body string) dtos.DashboardLintResult {
+		t.Helper()
+		req := server.NewPostRequest("/api/dashboards/lint", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardLintResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		return result
+	}
+
+	t.Run("should return the warnings of the dashboard", func(t *testing.T) {
+		result := lint(t, `{"dashboard": "{\"panels\": [{\"type\": \"text\"}]}"}`)
+		require.Len(t, result.Warnings, 1)
+		assert.Equal(t, lintRulePanelWithoutTitle, result.Warnings[0].Rule)
+	})
+
+	t.Run("should not fail for a dashboard that can't be parsed", func(t *testing.T) {
+		result := lint(t, `{"dashboard": "{not json"}`)
+		require.Len(t, result.Warnings, 1)
+		assert.Equal(t, lintRuleInvalidDashboard, result.Warnings[0].Rule)
+		assert.Equal(t, lintSeverityError, result.Warnings[0].Severity)
+	})
+}
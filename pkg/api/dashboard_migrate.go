@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+// minMigratableSchemaVersion is the lowest schemaVersion the server side
+// migrations can upgrade from. Older dashboards have to go through the
+// frontend dashboard migrations first.
+const minMigratableSchemaVersion = 34
+
+const (
+	mixedDataSourceUID      = "-- Mixed --"
+	expressionDataSourceUID = "__expr__"
+)
+
+// DashboardMigrationStep describes a schema migration applied to a dashboard.
+type DashboardMigrationStep struct {
+	// SchemaVersion is the schemaVersion of the dashboard after the step.
+	SchemaVersion int    `json:"schemaVersion"`
+	Description   string `json:"description"`
+}
+
+type dashboardMigration struct {
+	schemaVersion int
+	description   string
+	migrate       func(m *dashboardMigrator, dash *simplejson.Json) error
+}
+
+// dashboardMigrations mirror the frontend DashboardMigrator steps from
+// minMigratableSchemaVersion up to the handoff schema version.
+var dashboardMigrations = []dashboardMigration{
+	{
+		schemaVersion: 35,
+		description:   "Show the x axis of time series panels whose axis placement is hidden",
+		migrate: func(m *dashboardMigrator, dash *simplejson.Json) error {
+			forEachDashboardPanel(dash, ensureXAxisVisibility)
+			return nil
+		},
+	},
+	{
+		schemaVersion: 36,
+		description:   "Replace data source names with references and empty data sources with the default data source",
+		migrate:       (*dashboardMigrator).migrateDataSourceRefs,
+	},
+}
+
+// dashboardMigrator runs the schema migrations of a dashboard, resolving data
+// sources in the org of the signed in user.
+type dashboardMigrator struct {
+	ctx         context.Context
+	user        *user.SignedInUser
+	dataSources datasources.DataSourceService
+}
+
+// migrate upgrades the dashboard to the handoff schema version in place and
+// returns the steps applied.
+func (m *dashboardMigrator) migrate(dash *simplejson.Json) ([]DashboardMigrationStep, error) {
+	schemaVersion := dash.Get("schemaVersion").MustInt()
+	steps := []DashboardMigrationStep{}
+	for _, migration := range dashboardMigrations {
+		if migration.schemaVersion <= schemaVersion {
+			continue
+		}
+		if err := migration.migrate(m, dash); err != nil {
+			return nil, err
+		}
+		dash.Set("schemaVersion", migration.schemaVersion)
+		steps = append(steps, DashboardMigrationStep{SchemaVersion: migration.schemaVersion, Description: migration.description})
+	}
+	return steps, nil
+}
+
+func (m *dashboardMigrator) migrateDataSourceRefs(dash *simplejson.Json) error {
+	for _, annotation := range dash.GetPath("annotations", "list").MustArray() {
+		query := simplejson.NewFromAny(annotation)
+		ref, err := m.dataSourceRef(query.Get("datasource").Interface())
+		if err != nil {
+			return err
+		}
+		query.Set("datasource", ref)
+	}
+
+	defaultDS, err := m.dataSources.GetDefaultDataSource(m.ctx, &datasources.GetDefaultDataSourceQuery{OrgID: m.user.GetOrgID(), User: m.user})
+	if err != nil && !errors.Is(err, datasources.ErrDataSourceNotFound) {
+		return err
+	}
+	if defaultDS == nil {
+		return nil
+	}
+	defaultRef := map[string]any{"type": defaultDS.Type, "uid": defaultDS.UID}
+
+	for _, v := range dash.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(v)
+		if ds, ok := variable.CheckGet("datasource"); ok && ds.Interface() == nil && variable.Get("type").MustString() == "query" {
+			variable.Set("datasource", copyDataSourceRef(defaultRef))
+		}
+	}
+
+	var panelErr error
+	forEachDashboardPanel(dash, func(panel *simplejson.Json) {
+		targets, ok := panel.CheckGet("targets")
+		if !ok || panelErr != nil {
+			return
+		}
+
+		panelDataSourceWasDefault := false
+		if panel.Get("datasource").Interface() == nil && len(targets.MustArray()) > 0 {
+			panel.Set("datasource", copyDataSourceRef(defaultRef))
+			panelDataSourceWasDefault = true
+		}
+
+		for _, t := range targets.MustArray() {
+			target := simplejson.NewFromAny(t)
+			if target.GetPath("datasource", "uid").Interface() == nil {
+				if panel.GetPath("datasource", "uid").MustString() != mixedDataSourceUID {
+					target.Set("datasource", copyDataSourceRef(panel.Get("datasource").MustMap()))
+				} else {
+					ref, err := m.dataSourceRef(target.Get("datasource").Interface())
+					if err != nil {
+						panelErr = err
+						return
+					}
+					target.Set("datasource", ref)
+				}
+			}
+
+			if panelDataSourceWasDefault && target.GetPath("datasource", "uid").MustString() != expressionDataSourceUID {
+				panel.Set("datasource", target.Get("datasource").Interface())
+			}
+		}
+	})
+	return panelErr
+}
+
+// dataSourceRef returns the reference of the data source with the given name,
+// or of the default data source when the name is empty. References are
+// returned unchanged.
+func (m *dashboardMigrator) dataSourceRef(nameOrRef any) (any, error) {
+	if ref, ok := nameOrRef.(map[string]any); ok {
+		return ref, nil
+	}
+
+	name, _ := nameOrRef.(string)
+	var ds *datasources.DataSource
+	var err error
+	if name == "" || name == "default" {
+		ds, err = m.dataSources.GetDefaultDataSource(m.ctx, &datasources.GetDefaultDataSourceQuery{OrgID: m.user.GetOrgID(), User: m.user})
+	} else {
+		ds, err = m.dataSources.GetDataSource(m.ctx, &datasources.GetDataSourceQuery{Name: name, OrgID: m.user.GetOrgID()})
+		if errors.Is(err, datasources.ErrDataSourceNotFound) {
+			ds, err = m.dataSources.GetDataSource(m.ctx, &datasources.GetDataSourceQuery{UID: name, OrgID: m.user.GetOrgID()})
+		}
+	}
+	if err != nil && !errors.Is(err, datasources.ErrDataSourceNotFound) {
+		return nil, err
+	}
+
+	if ds == nil {
+		if name == "" {
+			return map[string]any{}, nil
+		}
+		return map[string]any{"uid": name}, nil
+	}
+	return map[string]any{"type": ds.Type, "uid": ds.UID}, nil
+}
+
+func copyDataSourceRef(ref map[string]any) map[string]any {
+	cp := make(map[string]any, len(ref))
+	for key, value := range ref {
+		cp[key] = value
+	}
+	return cp
+}
+
+// ensureXAxisVisibility keeps the time axis of a time series panel visible
+// when its axis placement is hidden, as hidden used to only apply to the y axis.
+func ensureXAxisVisibility(panel *simplejson.Json) {
+	if panel.Get("type").MustString() != "timeseries" {
+		return
+	}
+	if panel.GetPath("fieldConfig", "defaults", "custom", "axisPlacement").MustString() != "hidden" {
+		return
+	}
+
+	overrides := panel.GetPath("fieldConfig", "overrides").MustArray()
+	overrides = append(overrides, map[string]any{
+		"matcher": map[string]any{"id": "byType", "options": "time"},
+		"properties": []any{
+			map[string]any{"id": "custom.axisPlacement", "value": "auto"},
+		},
+	})
+	panel.SetPath([]string{"fieldConfig", "overrides"}, overrides)
+}
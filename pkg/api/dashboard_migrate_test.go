@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	fakeDatasources "github.com/grafana/grafana/pkg/services/datasources/fakes"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+type defaultDataSourceService struct {
+	*fakeDatasources.FakeDataSourceService
+	defaultDS *datasources.DataSource
+}
+
+func (s *defaultDataSourceService) GetDefaultDataSource(context.Context, *datasources.GetDefaultDataSourceQuery) (*datasources.DataSource, error) {
+	return s.defaultDS, nil
+}
+
+func TestDashboardMigrator(t *testing.T) {
+	prometheus := &datasources.DataSource{OrgID: 1, UID: "prom", Name: "Prometheus", Type: "prometheus"}
+	loki := &datasources.DataSource{OrgID: 1, UID: "loki", Name: "Loki", Type: "loki"}
+	migrator := &dashboardMigrator{
+		ctx:  context.Background(),
+		user: &user.SignedInUser{OrgID: 1},
+		dataSources: &defaultDataSourceService{
+			FakeDataSourceService: &fakeDatasources.FakeDataSourceService{DataSources: []*datasources.DataSource{prometheus, loki}},
+			defaultDS:             prometheus,
+		},
+	}
+
+	dash, err := simplejson.NewJson([]byte(`{
+		"schemaVersion": 34,
+		"annotations": {"list": [{"name": "Logs", "datasource": "Loki"}, {"name": "Missing", "datasource": "gone"}]},
+		"templating": {"list": [{"name": "job", "type": "query", "datasource": null}]},
+		"panels": [
+			{"id": 1, "type": "timeseries", "targets": [{"refId": "A"}],
+				"fieldConfig": {"defaults": {"custom": {"axisPlacement": "hidden"}}, "overrides": []}},
+			{"id": 2, "type": "table", "datasource": {"uid": "-- Mixed --"}, "targets": [{"refId": "A", "datasource": "Loki"}]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	steps, err := migrator.migrate(dash)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, 35, steps[0].SchemaVersion)
+	assert.Equal(t, 36, steps[1].SchemaVersion)
+	assert.Equal(t, 36, dash.Get("schemaVersion").MustInt())
+
+	annotations := dash.GetPath("annotations", "list")
+	assert.Equal(t, map[string]any{"type": "loki", "uid": "loki"}, annotations.GetIndex(0).Get("datasource").MustMap())
+	assert.Equal(t, map[string]any{"uid": "gone"}, annotations.GetIndex(1).Get("datasource").MustMap())
+	assert.Equal(t, "prom", dash.GetPath("templating", "list").GetIndex(0).GetPath("datasource", "uid").MustString())
+
+	panels := dash.Get("panels")
+	assert.Equal(t, "prom", panels.GetIndex(0).GetPath("datasource", "uid").MustString())
+	assert.Equal(t, "prom", panels.GetIndex(0).Get("targets").GetIndex(0).GetPath("datasource", "uid").MustString())
+	assert.Len(t, panels.GetIndex(0).GetPath("fieldConfig", "overrides").MustArray(), 1)
+	assert.Equal(t, map[string]any{"type": "loki", "uid": "loki"}, panels.GetIndex(1).Get("targets").GetIndex(0).Get("datasource").MustMap())
+
+	t.Run("should not migrate a dashboard at the handoff schema version", func(t *testing.T) {
+		steps, err := migrator.migrate(dash)
+		require.NoError(t, err)
+		assert.Empty(t, steps)
+	})
+}
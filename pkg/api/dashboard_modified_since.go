@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/search"
+)
+
+// swagger:route GET /dashboards/modified-since dashboards getDashboardsModifiedSince
+//
+// List the dashboards modified since a point in time.
+//
+// Returns the uid and version of the dashboards the signed in user can view that were saved after `ts`, an
+// RFC 3339 timestamp, so that a backup can poll for changes instead of exporting every dashboard again.
+// When deleted dashboards are kept in the trash, the dashboards deleted after `ts` are listed as well.
+// Deletions older than the trash retention are not reported. The returned until is the time to pass as
+// `ts` on the next call.
+//
+// Responses:
+// 200: getDashboardsModifiedSinceResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardsModifiedSince(c *contextmodel.ReqContext) response.Response {
+	since, err := time.Parse(time.RFC3339, c.Query("ts"))
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "ts must be an RFC 3339 timestamp", err)
+	}
+
+	result := dtos.DashboardsModifiedSince{
+		Modified: []dtos.ModifiedDashboard{},
+		Deleted:  []dtos.DeletedDashboard{},
+		Until:    time.Now().UTC(),
+	}
+
+	uids, err := hs.searchDashboardUIDs(c, &search.Query{Permission: dashboards.PERMISSION_VIEW})
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+	dashes, err := hs.getDashboardsByUIDs(c.Req.Context(), c.SignedInUser.GetOrgID(), uids)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboards", err)
+	}
+	for _, dash := range dashes {
+		if !dash.Updated.After(since) {
+			continue
+		}
+		if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+			continue
+		}
+		result.Modified = append(result.Modified, dtos.ModifiedDashboard{UID: dash.UID, Version: dash.Version, Updated: dash.Updated})
+	}
+
+	if hs.trashStore.Enabled() {
+		entries, err := hs.trashStore.List(c.Req.Context(), c.SignedInUser.GetOrgID())
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to get deleted dashboards", err)
+		}
+		for _, entry := range entries {
+			if !entry.Deleted.After(since) {
+				continue
+			}
+			// the permissions of the dashboard as it was before it was deleted
+			dash := &dashboards.Dashboard{UID: entry.UID, FolderUID: entry.FolderUID, OrgID: c.SignedInUser.GetOrgID()}
+			if canView, err := hs.canViewDashboard(c, dash); err != nil || !canView {
+				continue
+			}
+			result.Deleted = append(result.Deleted, dtos.DeletedDashboard{UID: entry.UID, Deleted: entry.Deleted})
+		}
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:parameters getDashboardsModifiedSince
+type GetDashboardsModifiedSinceParams struct {
+	// RFC 3339 timestamp after which dashboards are listed.
+	// in:query
+	// required:true
+	TS string `json:"ts"`
+}
+
+// swagger:response getDashboardsModifiedSinceResponse
+type GetDashboardsModifiedSinceResponse struct {
+	// in: body
+	Body dtos.DashboardsModifiedSince `json:"body"`
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardtrash"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/search/model"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardsModifiedSince(t *testing.T) {
+	since := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	newDash := func(uid string, version int, updated time.Time) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.Version = version
+		dash.Updated = updated
+		return dash
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.SearchService = &mockSearchService{ExpectedResult: model.HitList{{UID: "a"}, {UID: "b"}, {UID: "c"}}}
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{
+			newDash("a", 3, since.Add(time.Hour)),
+			newDash("b", 2, since.Add(-time.Hour)),
+			newDash("c", 5, since.Add(time.Hour)),
+		}, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.trashStore = dashboardtrash.NewStore(kvstore.NewFakeKVStore(), 24*time.Hour)
+		for _, entry := range []*dashboardtrash.Entry{
+			{UID: "d", Deleted: since.Add(time.Minute)},
+			{UID: "e", Deleted: since.Add(-time.Minute)},
+		} {
+			require.NoError(t, hs.trashStore.Add(context.Background(), 1, entry))
+		}
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	get := func(t *testing.T, ts string) *http.Response {
+		t.Helper()
+		permissions := []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"},
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:b"},
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:d"},
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:e"},
+		}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/modified-since?ts="+ts), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should list the readable dashboards saved or deleted after the timestamp", func(t *testing.T) {
+		res := get(t, since.Format(time.RFC3339))
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.DashboardsModifiedSince
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+
+		require.Len(t, result.Modified, 1)
+		assert.Equal(t, "a", result.Modified[0].UID)
+		assert.Equal(t, 3, result.Modified[0].Version)
+		require.Len(t, result.Deleted, 1)
+		assert.Equal(t, "d", result.Deleted[0].UID)
+		assert.True(t, result.Until.After(since))
+	})
+
+	t.Run("should reject an invalid timestamp", func(t *testing.T) {
+		res := get(t, "yesterday")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
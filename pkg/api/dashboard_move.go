@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route POST /dashboards/uid/{uid}/move dashboards moveDashboard
+//
+// Move a dashboard to another folder.
+//
+// Changes only the folder of the dashboard, saving it as a new version. The user needs to be allowed to
+// save and delete the dashboard in its current folder and to create dashboards in the destination folder.
+// An empty folderUid moves the dashboard to the General folder. Provisioned dashboards stay linked to their
+// provisioner; those whose provisioner doesn't allow UI updates cannot be moved.
+//
+// Responses:
+// 200: dashboardMetaResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 412: preconditionFailedError
+// 500: internalServerError
+func (hs *HTTPServer) MoveDashboard(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.MoveDashboardCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	if dash.IsFolder {
+		return response.Error(http.StatusBadRequest, "Folders cannot be moved as dashboards", nil)
+	}
+	source, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := source.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+	if canDelete, err := source.CanDelete(); err != nil || !canDelete {
+		return dashboardGuardianResponse(err)
+	}
+
+	if cmd.FolderUID != dash.FolderUID {
+		folderTitle := "General"
+		scopeUID := ac.GeneralFolderUID
+		if cmd.FolderUID != "" {
+			f, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{UID: &cmd.FolderUID, OrgID: dash.OrgID, SignedInUser: c.SignedInUser})
+			if err != nil {
+				if errors.Is(err, dashboards.ErrFolderNotFound) {
+					return response.Error(http.StatusBadRequest, "Folder not found", err)
+				}
+				return response.Error(http.StatusInternalServerError, "Failed to get folder", err)
+			}
+			folderTitle = f.Title
+			scopeUID = f.UID
+		}
+		evaluator := ac.EvalPermission(dashboards.ActionDashboardsCreate, dashboards.ScopeFoldersProvider.GetResourceScopeUID(scopeUID))
+		if canCreate, err := hs.AccessControl.Evaluate(c.Req.Context(), c.SignedInUser, evaluator); err != nil || !canCreate {
+			return dashboardGuardianResponse(err)
+		}
+
+		data, err := cloneDashboardJSON(dash.Data)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to move dashboard", err)
+		}
+		data.Set("id", dash.ID)
+		data.Set("uid", dash.UID)
+		data.Set("version", dash.Version)
+		rsp := hs.postDashboard(c, dashboards.SaveDashboardCommand{
+			Dashboard: data,
+			FolderUID: cmd.FolderUID,
+			Message:   fmt.Sprintf("Moved to folder %s", folderTitle),
+		})
+		if rsp.Status() != http.StatusOK {
+			return rsp
+		}
+
+		if dash, rsp = hs.getDashboardHelper(c.Req.Context(), dash.OrgID, 0, dash.UID); rsp != nil {
+			return rsp
+		}
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	meta, rsp := hs.getDashboardMeta(c, dash, guardian)
+	if rsp != nil {
+		return rsp
+	}
+	return response.JSON(http.StatusOK, meta)
+}
+
+// swagger:parameters moveDashboard
+type MoveDashboardParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:body
+	// required:true
+	Body dtos.MoveDashboardCommand
+}
+
+// swagger:response dashboardMetaResponse
+type DashboardMetaResponse struct {
+	// in: body
+	Body dtos.DashboardMeta `json:"body"`
+}
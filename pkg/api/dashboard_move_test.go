@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/star/startest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestMoveDashboard(t *testing.T) {
+	dash := dashboards.NewDashboard("Dash")
+	dash.ID = 1
+	dash.UID = "dash"
+	dash.OrgID = 1
+	dash.Version = 3
+	dash.Data = simplejson.NewFromAny(map[string]any{"id": 1, "uid": "dash", "title": "Dash", "version": 3})
+
+	moved := dashboards.NewDashboard("Dash")
+	moved.ID = 1
+	moved.UID = "dash"
+	moved.OrgID = 1
+	moved.Version = 4
+	moved.FolderID = 2 // nolint:staticcheck
+	moved.FolderUID = "dest"
+
+	destFolder := dashboards.NewDashboardFolder("Destination")
+	destFolder.ID = 2
+	destFolder.UID = "dest"
+
+	var saved []*dashboards.SaveDashboardDTO
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool { return q.ID == 2 })).Return(destFolder, nil).Maybe()
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(func(_ context.Context, _ *dashboards.GetDashboardQuery) *dashboards.Dashboard {
+			if len(saved) > 0 {
+				return moved
+			}
+			return dash
+		}, nil)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO))
+		}).Return(moved, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		folderSvc := foldertest.NewFakeService()
+		folderSvc.ExpectedFolder = &folder.Folder{UID: "dest", Title: "Destination"}
+		hs.folderService = folderSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.starService = startest.NewStarServiceFake()
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	move := func(t *testing.T, permissions []accesscontrol.Permission) *http.Response {
+		t.Helper()
+		saved = nil
+		req := server.NewPostRequest("/api/dashboards/uid/dash/move", strings.NewReader(`{"folderUid": "dest"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+	sourcePermissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+		{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+		{Action: dashboards.ActionDashboardsDelete, Scope: dashboards.ScopeDashboardsAll},
+	}
+
+	t.Run("should move the dashboard and return its meta", func(t *testing.T) {
+		res := move(t, append(sourcePermissions, accesscontrol.Permission{Action: dashboards.ActionDashboardsCreate, Scope: "folders:uid:dest"}))
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var meta dtos.DashboardMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&meta))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, "dest", meta.FolderUid)
+		assert.Equal(t, 4, meta.Version)
+
+		require.Len(t, saved, 1)
+		assert.Equal(t, "dest", saved[0].Dashboard.FolderUID)
+		assert.Equal(t, 3, saved[0].Dashboard.Version)
+		assert.Equal(t, "Dash", saved[0].Dashboard.Title)
+	})
+
+	t.Run("should not move without create permission in the destination", func(t *testing.T) {
+		res := move(t, sourcePermissions)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("should not move without delete permission in the source", func(t *testing.T) {
+		res := move(t, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+			{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+			{Action: dashboards.ActionDashboardsCreate, Scope: "folders:uid:dest"},
+		})
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		assert.Empty(t, saved)
+	})
+}
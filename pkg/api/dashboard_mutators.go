@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// DashboardMutator transforms the JSON of a dashboard saved through the
+// API before it is validated and saved, e.g. to enforce the conventions of
+// an org. Returning a *DashboardMutationError rejects the save.
+type DashboardMutator interface {
+	// Name identifies the mutator in rejections and logs.
+	Name() string
+	MutateDashboard(ctx context.Context, user identity.Requester, orgID int64, dashboard *simplejson.Json) error
+}
+
+// DashboardMutationError rejects the save of a dashboard, with a reason
+// returned to the client.
+type DashboardMutationError struct {
+	Reason string
+}
+
+func (e *DashboardMutationError) Error() string {
+	return "dashboard rejected: " + e.Reason
+}
+
+// RegisterDashboardMutator adds a mutator run on every dashboard saved
+// through the API, after the ones registered before it. Without mutators
+// dashboards are saved as sent.
+func (hs *HTTPServer) RegisterDashboardMutator(m DashboardMutator) {
+	hs.dashboardMutators = append(hs.dashboardMutators, m)
+}
+
+// mutateDashboard runs the registered mutators over the dashboard. It
+// returns the response to send when a mutator rejects or fails the save.
+func (hs *HTTPServer) mutateDashboard(c *contextmodel.ReqContext, dashboard *simplejson.Json) response.Response {
+	for _, m := range hs.dashboardMutators {
+		err := m.MutateDashboard(c.Req.Context(), c.SignedInUser, c.SignedInUser.GetOrgID(), dashboard)
+		if err == nil {
+			continue
+		}
+		var rejected *DashboardMutationError
+		if errors.As(err, &rejected) {
+			return response.JSON(http.StatusUnprocessableEntity, util.DynMap{
+				"status":    "mutation-rejected",
+				"messageId": "dashboards.mutationRejected",
+				"mutator":   m.Name(),
+				"message":   rejected.Reason,
+			})
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to apply dashboard mutator "+m.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+// tagMutator adds a tag to every dashboard and rejects dashboards without a
+// title.
+type tagMutator struct {
+	orgID int64
+}
+
+func (m *tagMutator) Name() string { return "tag" }
+
+func (m *tagMutator) MutateDashboard(ctx context.Context, user identity.Requester, orgID int64, dashboard *simplejson.Json) error {
+	m.orgID = orgID
+	if dashboard.Get("title").MustString() == "" {
+		return &DashboardMutationError{Reason: "a title is required"}
+	}
+	dashboard.Set("tags", append(dashboard.Get("tags").MustArray(), "managed"))
+	return nil
+}
+
+func TestPostDashboard_Mutators(t *testing.T) {
+	var saved []*dashboards.SaveDashboardDTO
+	mutator := &tagMutator{}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO))
+		}).Return(&dashboards.Dashboard{ID: 1, UID: "dash", Title: "Dash", Version: 1}, nil).Maybe()
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(func(context.Context, *dashboards.GetDashboardQuery) *dashboards.Dashboard {
+			dash := dashboards.NewDashboard("Dash")
+			dash.ID, dash.UID, dash.OrgID, dash.Version = 1, "dash", 1, 1
+			return dash
+		}, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.QuotaService = quotatest.New(false, nil)
+		hs.RegisterDashboardMutator(mutator)
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	post := func(t *testing.T, url, body string) *http.Response {
+		t.Helper()
+		usr := userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsCreate, Scope: dashboards.ScopeFoldersAll},
+			{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+		})
+		req := server.NewPostRequest(url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, usr))
+		require.NoError(t, err)
+		return res
+	}
+	save := func(t *testing.T, body string) *http.Response {
+		t.Helper()
+		return post(t, "/api/dashboards/db", body)
+	}
+
+	t.Run("should save the mutated dashboard", func(t *testing.T) {
+		saved = nil
+		res := save(t, `{"dashboard": {"title": "Dash", "tags": ["team"]}}`)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, saved, 1)
+		assert.Equal(t, []string{"team", "managed"}, saved[0].Dashboard.Data.Get("tags").MustStringArray())
+		assert.Equal(t, int64(1), mutator.orgID)
+	})
+
+	t.Run("should reject the dashboard when a mutator rejects it", func(t *testing.T) {
+		saved = nil
+		res := save(t, `{"dashboard": {"tags": ["team"]}}`)
+		require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, map[string]string{"status": "mutation-rejected", "messageId": "dashboards.mutationRejected", "mutator": "tag", "message": "a title is required"}, body)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("should mutate dashboards saved by other endpoints", func(t *testing.T) {
+		saved = nil
+		res := post(t, "/api/dashboards/uid/dash/tags", `{"tag": "prod"}`)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, saved, 1)
+		assert.Equal(t, []string{"prod", "managed"}, saved[0].Dashboard.Data.Get("tags").MustStringArray())
+	})
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /dashboards/uid/{uid}/panels/{panelId} dashboards getDashboardPanel
+//
+// Get a single panel of a dashboard.
+//
+// Returns the panel with the given id, including panels nested in rows, together with the templating, time
+// range, timezone and week start of the dashboard, so that the panel can be embedded without loading the
+// whole dashboard. The templating includes the org wide variables and the var-<name> query parameters set
+// the current values of variables the same way as when getting the dashboard.
+//
+// Responses:
+// 200: getDashboardPanelResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardPanel(c *contextmodel.ReqContext) response.Response {
+	panelID, err := strconv.ParseInt(web.Params(c.Req)[":panelId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "panelId is invalid", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canView, err := guardian.CanView(); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	panel := findDashboardPanel(dash.Data, panelID)
+	if panel == nil || panel.Get("type").MustString() == "row" {
+		return response.Error(http.StatusNotFound, "Panel not found", nil)
+	}
+
+	if _, err := hs.injectOrgDashboardVariables(c.Req.Context(), dash.OrgID, dash.Data); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get org dashboard variables", err)
+	}
+	applyTemplateVariableOverrides(dash.Data, c.Req.URL.Query())
+
+	templating := dash.Data.Get("templating")
+	if _, ok := templating.CheckGet("list"); !ok {
+		templating = simplejson.NewFromAny(map[string]any{"list": []any{}})
+	}
+	return response.JSON(http.StatusOK, dtos.DashboardPanelEmbed{
+		DashboardUID:   dash.UID,
+		DashboardTitle: dash.Title,
+		Version:        dash.Version,
+		Panel:          panel,
+		Templating:     templating,
+		Time:           dash.Data.Get("time"),
+		Timezone:       dash.Data.Get("timezone").MustString(),
+		WeekStart:      dash.Data.Get("weekStart").MustString(),
+	})
+}
+
+// swagger:parameters getDashboardPanel
+type GetDashboardPanelParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:path
+	// required:true
+	PanelID int64 `json:"panelId"`
+}
+
+// swagger:response getDashboardPanelResponse
+type GetDashboardPanelResponse struct {
+	// in: body
+	Body dtos.DashboardPanelEmbed `json:"body"`
+}
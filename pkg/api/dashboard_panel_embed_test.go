@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardPanel(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"uid": "dash",
+		"title": "Dash",
+		"timezone": "utc",
+		"time": {"from": "now-6h", "to": "now"},
+		"panels": [
+			{"id": 1, "type": "timeseries", "title": "CPU"},
+			{"id": 2, "type": "row", "collapsed": true, "panels": [{"id": 3, "type": "stat", "title": "Nested"}]}
+		],
+		"templating": {"list": [{"name": "env", "current": {"text": "dev", "value": "dev"}, "options": []}]}
+	}`))
+	require.NoError(t, err)
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboardFromJson(data)
+		dash.ID = 1
+		dash.OrgID = 1
+		dash.Version = 4
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	get := func(t *testing.T, url string, permissions []accesscontrol.Permission) *http.Response {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+	canRead := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"}}
+
+	t.Run("should return the panel with the context to render it", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/dash/panels/3?var-env=prod", canRead)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var embed dtos.DashboardPanelEmbed
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&embed))
+		require.NoError(t, res.Body.Close())
+
+		assert.Equal(t, "dash", embed.DashboardUID)
+		assert.Equal(t, 4, embed.Version)
+		assert.Equal(t, "Nested", embed.Panel.Get("title").MustString())
+		assert.Equal(t, "now-6h", embed.Time.Get("from").MustString())
+		assert.Equal(t, "utc", embed.Timezone)
+		variable := simplejson.NewFromAny(embed.Templating.Get("list").MustArray()[0])
+		assert.Equal(t, "prod", variable.GetPath("current", "value").MustString())
+	})
+
+	t.Run("should return 404 for a panel that doesn't exist", func(t *testing.T) {
+		for _, url := range []string{"/api/dashboards/uid/dash/panels/9", "/api/dashboards/uid/dash/panels/2"} {
+			res := get(t, url, canRead)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		}
+	})
+
+	t.Run("should require read permission on the dashboard", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/dash/panels/1", []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:other"}})
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+}
@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+var exportInputNameInvalidChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// swagger:route GET /dashboards/hardcoded-datasources dashboards getHardcodedDatasourceDashboards
+//
+// Find dashboards with hardcoded datasources.
+//
+// Returns the dashboards the signed in user can read whose panels reference a datasource by a fixed uid
+// rather than through a template variable, together with the offending panels.
+//
+// Responses:
+// 200: hardcodedDatasourceDashboardsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetHardcodedDatasourceDashboards(c *contextmodel.ReqContext) response.Response {
+	dashes, err := hs.readableIndexedDashboards(c)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	result := make([]dtos.HardcodedDatasourceDashboard, 0)
+	for _, dash := range dashes {
+		entry := dash.entry
+
+		var panels []dtos.HardcodedDatasourcePanel
+		for _, panel := range entry.Panels {
+			var refs []dtos.DatasourceRef
+			for _, ref := range panel.Datasources {
+				if ref.isTemplated() || ref.isBuiltIn() {
+					continue
+				}
+				refs = append(refs, dtos.DatasourceRef{UID: ref.UID, Type: ref.Type})
+			}
+			if len(refs) > 0 {
+				panels = append(panels, dtos.HardcodedDatasourcePanel{ID: panel.ID, Title: panel.Title, Datasources: refs})
+			}
+		}
+
+		if len(panels) > 0 {
+			result = append(result, dtos.HardcodedDatasourceDashboard{
+				UID:       dash.UID,
+				Title:     dash.Title,
+				URL:       dash.GetURL(),
+				FolderUID: dash.FolderUID,
+				Panels:    panels,
+			})
+		}
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route POST /dashboards/uid/{uid}/import-diff dashboards calculateDashboardImportDiff
+//
+// Diff an incoming dashboard against the stored one.
+//
+// Both the incoming and the stored dashboard are normalized to their export form (internal ids removed,
+// datasources replaced by import inputs) before being compared, so that only the changes a re-import
+// would make are reported.
+//
+// Produces:
+// - application/json
+// - text/html
+//
+// Responses:
+// 200: calculateDashboardDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CalculateDashboardImportDiff(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.ImportDiffCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	if _, err := cmd.Dashboard.Map(); err != nil {
+		return response.Error(http.StatusBadRequest, "dashboard must be a JSON object", err)
+	}
+
+	diffType, rsp := hs.parseDiffType(cmd.DiffType)
+	if rsp != nil {
+		return rsp
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canView, err := guardian.CanView(); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	base, err := hs.exportDashboardJSON(c.Req.Context(), dash.OrgID, dash.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to normalize stored dashboard", err)
+	}
+	incoming, err := hs.exportDashboardJSON(c.Req.Context(), dash.OrgID, cmd.Dashboard)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Failed to normalize incoming dashboard", err)
+	}
+	for _, key := range []string{"__inputs", "__requires", "__elements"} {
+		base.Del(key)
+		incoming.Del(key)
+	}
+
+	options := dashdiffs.Options{
+		OrgId:    dash.OrgID,
+		DiffType: diffType,
+	}
+	result, err := calculateDiff(c.Req.Context(), &options, base, incoming)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	return diffResultResponse(&options, result)
+}
+
+// calculateDiff diffs two dashboard bodies. Identical bodies yield an empty
+// diff instead of an error, in the format of the diff type.
+func calculateDiff(ctx context.Context, options *dashdiffs.Options, baseData, newData *simplejson.Json) (*dashdiffs.Result, error) {
+	result, err := dashdiffs.CalculateDiff(ctx, options, baseData, newData)
+	if errors.Is(err, dashdiffs.ErrNilDiff) {
+		switch options.DiffType {
+		case dashdiffs.DiffDelta:
+			return &dashdiffs.Result{Delta: []byte("{}")}, nil
+		case dashdiffs.DiffSemantic:
+			return &dashdiffs.Result{Delta: []byte("[]")}, nil
+		}
+		return &dashdiffs.Result{Delta: []byte{}}, nil
+	}
+	return result, err
+}
+
+// diffResultResponse writes a computed diff, reporting the total number of
+// changes of a paged semantic diff and whether a basic diff was truncated in
+// headers.
+func diffResultResponse(options *dashdiffs.Options, result *dashdiffs.Result) response.Response {
+	rsp := response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", diffContentType(options))
+	if options.DiffType == dashdiffs.DiffSemantic {
+		rsp.SetHeader("X-Total-Changes", strconv.Itoa(result.TotalChanges))
+	}
+	if result.Truncated {
+		rsp.SetHeader("X-Diff-Truncated", "true")
+	}
+	return rsp
+}
+
+func diffContentType(options *dashdiffs.Options) string {
+	if options.DiffType == dashdiffs.DiffDelta || options.DiffType == dashdiffs.DiffSemantic {
+		return "application/json"
+	}
+	return "text/html"
+}
+
+// cloneDashboardJSON returns a deep copy of a dashboard body.
+func cloneDashboardJSON(data *simplejson.Json) (*simplejson.Json, error) {
+	b, err := data.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return simplejson.NewJson(b)
+}
+
+// exportDashboardJSON returns a copy of the dashboard body in the form used
+// when sharing a dashboard externally: the internal id and version are
+// removed and every datasource of the org referenced by the dashboard or by
+// the library panels listed in __elements is replaced by an import input
+// listed in __inputs. Internal datasource ids left in other references are
+// removed too.
+func (hs *HTTPServer) exportDashboardJSON(ctx context.Context, orgID int64, data *simplejson.Json) (*simplejson.Json, error) {
+	export, err := cloneDashboardJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	export.Set("id", nil)
+	export.Del("version")
+
+	dsList, err := hs.DataSourcesService.GetDataSources(ctx, &datasources.GetDataSourcesQuery{OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	dsByRef := make(map[string]*datasources.DataSource, 2*len(dsList))
+	for _, ds := range dsList {
+		dsByRef[ds.Name] = ds
+		dsByRef[ds.UID] = ds
+	}
+
+	inputs := make(map[string]*datasources.DataSource)
+	templatize := func(owner *simplejson.Json) {
+		if _, err := owner.Get("datasource").Map(); err == nil {
+			owner.Get("datasource").Del("id")
+		}
+		ref, ok := parseDatasourceRef(owner.Get("datasource"))
+		if !ok || ref.isTemplated() || ref.isBuiltIn() {
+			return
+		}
+		ds, ok := dsByRef[ref.UID]
+		if !ok {
+			return
+		}
+		name := exportInputName(ds)
+		inputs[name] = ds
+		owner.Set("datasource", map[string]any{"type": ds.Type, "uid": "${" + name + "}"})
+	}
+
+	templatizePanel := func(panel *simplejson.Json) {
+		templatize(panel)
+		targets := panel.Get("targets")
+		for i := range targets.MustArray() {
+			templatize(targets.GetIndex(i))
+		}
+	}
+	forEachDashboardPanel(export, templatizePanel)
+	// library panels exported next to the dashboard
+	for i := range export.Get("__elements").MustArray() {
+		templatizePanel(export.Get("__elements").GetIndex(i).Get("model"))
+	}
+	for _, list := range []*simplejson.Json{export.GetPath("templating", "list"), export.GetPath("annotations", "list")} {
+		for i := range list.MustArray() {
+			templatize(list.GetIndex(i))
+		}
+	}
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	exportInputs := make([]any, 0, len(names))
+	for _, name := range names {
+		ds := inputs[name]
+		exportInputs = append(exportInputs, map[string]any{
+			"name":        name,
+			"label":       ds.Name,
+			"description": "",
+			"type":        "datasource",
+			"pluginId":    ds.Type,
+			"pluginName":  ds.Type,
+		})
+	}
+	export.Set("__inputs", exportInputs)
+
+	return export, nil
+}
+
+// exportInputName returns the import input name used for a datasource, e.g.
+// DS_MY_PROMETHEUS for a datasource named "My Prometheus".
+func exportInputName(ds *datasources.DataSource) string {
+	return "DS_" + exportInputNameInvalidChars.ReplaceAllString(strings.ToUpper(ds.Name), "_")
+}
+
+// swagger:parameters calculateDashboardImportDiff
+type CalculateDashboardImportDiffParams struct {
+	// in:body
+	// required:true
+	Body dtos.ImportDiffCommand
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response hardcodedDatasourceDashboardsResponse
+type HardcodedDatasourceDashboardsResponse struct {
+	// in: body
+	Body []dtos.HardcodedDatasourceDashboard `json:"body"`
+}
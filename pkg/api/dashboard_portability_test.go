@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestExportDashboardJSON(t *testing.T) {
+	hs := &HTTPServer{
+		DataSourcesService: &dataSourcesServiceMock{
+			expectedDatasources: []*datasources.DataSource{
+				{UID: "prom-1", Name: "My Prometheus", Type: "prometheus"},
+			},
+		},
+	}
+
+	data, err := simplejson.NewJson([]byte(`{
+		"id": 12,
+		"uid": "dash",
+		"version": 4,
+		"panels": [
+			{"id": 1, "datasource": {"uid": "prom-1", "type": "prometheus"}, "targets": [{"datasource": "My Prometheus"}]},
+			{"id": 2, "datasource": {"uid": "${ds}"}},
+			{"id": 3, "datasource": {"uid": "unknown", "id": 7}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	export, err := hs.exportDashboardJSON(context.Background(), 1, data)
+	require.NoError(t, err)
+
+	assert.Nil(t, export.Get("id").Interface())
+	_, hasVersion := export.CheckGet("version")
+	assert.False(t, hasVersion)
+	assert.Equal(t, "dash", export.Get("uid").MustString())
+
+	panels := export.Get("panels")
+	assert.Equal(t, "${DS_MY_PROMETHEUS}", panels.GetIndex(0).GetPath("datasource", "uid").MustString())
+	assert.Equal(t, "${DS_MY_PROMETHEUS}", panels.GetIndex(0).Get("targets").GetIndex(0).GetPath("datasource", "uid").MustString())
+	assert.Equal(t, "${ds}", panels.GetIndex(1).GetPath("datasource", "uid").MustString())
+	assert.Equal(t, "unknown", panels.GetIndex(2).GetPath("datasource", "uid").MustString())
+	_, hasDatasourceID := panels.GetIndex(2).Get("datasource").CheckGet("id")
+	assert.False(t, hasDatasourceID)
+
+	inputs := export.Get("__inputs").MustArray()
+	require.Len(t, inputs, 1)
+	assert.Equal(t, "DS_MY_PROMETHEUS", export.Get("__inputs").GetIndex(0).Get("name").MustString())
+
+	// the stored body must not be modified
+	assert.Equal(t, int64(12), data.Get("id").MustInt64())
+	assert.Equal(t, "prom-1", data.Get("panels").GetIndex(0).GetPath("datasource", "uid").MustString())
+}
+
+func TestCalculateDashboardImportDiff(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboardFromJson(simplejson.NewFromAny(map[string]any{
+			"id": 1, "uid": "dash", "title": "Dash", "version": 3,
+			"panels": []any{map[string]any{"id": 1, "datasource": map[string]any{"uid": "prom-1", "type": "prometheus"}}},
+		}))
+		dash.OrgID = 1
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+		hs.DataSourcesService = &dataSourcesServiceMock{
+			expectedDatasources: []*datasources.DataSource{{UID: "prom-1", Name: "Prom", Type: "prometheus"}},
+		}
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	diff := func(t *testing.T, body string) (*http.Response, string) {
+		t.Helper()
+		req := server.NewPostRequest("/api/dashboards/uid/dash/import-diff", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res, string(b)
+	}
+
+	t.Run("should return an empty diff for a dashboard only differing in ids and datasources", func(t *testing.T) {
+		res, body := diff(t, `{"dashboard": {"id": 7, "uid": "dash", "title": "Dash", "version": 9, "panels": [{"id": 1, "datasource": "Prom"}]}, "diffType": "delta"}`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+		var delta map[string]any
+		require.NoError(t, json.Unmarshal([]byte(body), &delta))
+		assert.Empty(t, delta)
+	})
+
+	t.Run("should return an empty list of changes for an identical dashboard", func(t *testing.T) {
+		res, body := diff(t, `{"dashboard": {"uid": "dash", "title": "Dash", "panels": [{"id": 1, "datasource": {"uid": "prom-1"}}]}, "diffType": "semantic"}`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var changes []any
+		require.NoError(t, json.Unmarshal([]byte(body), &changes))
+		assert.NotNil(t, changes)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("should return the changes a re-import would make", func(t *testing.T) {
+		res, body := diff(t, `{"dashboard": {"uid": "dash", "title": "Renamed", "panels": [{"id": 1, "datasource": {"uid": "prom-1"}}]}, "diffType": "delta"}`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, body, "Renamed")
+	})
+
+	t.Run("should reject an incoming dashboard that isn't an object", func(t *testing.T) {
+		res, _ := diff(t, `{"dashboard": ["not", "a", "dashboard"]}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should reject unknown diff types", func(t *testing.T) {
+		res, _ := diff(t, `{"dashboard": {"uid": "dash"}, "diffType": "unknown"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
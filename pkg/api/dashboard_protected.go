@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const dashboardProtectedNamespace = "dashboard-protected"
+
+// swagger:route PUT /dashboards/uid/{uid}/protected dashboards updateDashboardProtection
+//
+// Mark a dashboard as protected.
+//
+// Deleting a protected dashboard requires `confirm=true`, without it the delete fails with a 409. Bulk
+// deletes skip protected dashboards unless confirmed the same way. Only users who can administer the
+// dashboard can change its protection.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateDashboardProtection(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.DashboardProtection{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canAdmin, err := guardian.CanAdmin(); err != nil || !canAdmin {
+		return dashboardGuardianResponse(err)
+	}
+
+	store := kvstore.WithNamespace(hs.kvStore, dash.OrgID, dashboardProtectedNamespace)
+	if cmd.Protected {
+		err = store.Set(c.Req.Context(), dash.UID, "true")
+	} else {
+		err = store.Del(c.Req.Context(), dash.UID)
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to update dashboard protection", err)
+	}
+	if cmd.Protected {
+		return response.Success("Dashboard protected")
+	}
+	return response.Success("Dashboard no longer protected")
+}
+
+// isDashboardProtected tells whether deleting the dashboard needs to be
+// confirmed.
+func (hs *HTTPServer) isDashboardProtected(ctx context.Context, orgID int64, uid string) (bool, error) {
+	if hs.kvStore == nil {
+		return false, nil
+	}
+	_, ok, err := kvstore.WithNamespace(hs.kvStore, orgID, dashboardProtectedNamespace).Get(ctx, uid)
+	return ok, err
+}
+
+// swagger:parameters updateDashboardProtection
+type UpdateDashboardProtectionParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:body
+	// required:true
+	Body dtos.DashboardProtection
+}
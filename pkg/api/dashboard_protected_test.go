@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/api"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestProtectedDashboard(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		dashSvc.On("DeleteDashboard", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.kvStore = kvstore.NewFakeKVStore()
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+
+		pubDashService := publicdashboards.NewFakePublicDashboardService(t)
+		pubDashService.On("DeleteByDashboard", mock.Anything, mock.Anything).Return(nil).Maybe()
+		hs.PublicDashboardsApi = api.ProvideApi(pubDashService, nil, hs.AccessControl, featuremgmt.WithFeatures())
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsDelete, Scope: "dashboards:uid:1"},
+		{Action: dashboards.ActionDashboardsPermissionsRead, Scope: "dashboards:uid:1"},
+		{Action: dashboards.ActionDashboardsPermissionsWrite, Scope: "dashboards:uid:1"},
+	}
+	send := func(t *testing.T, req *http.Request) int {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+	protect := func(t *testing.T, protected string) int {
+		t.Helper()
+		req := server.NewRequest(http.MethodPut, "/api/dashboards/uid/1/protected", strings.NewReader(`{"protected": `+protected+`}`))
+		req.Header.Set("Content-Type", "application/json")
+		return send(t, req)
+	}
+
+	t.Run("should require confirmation to delete a protected dashboard", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, protect(t, "true"))
+
+		assert.Equal(t, http.StatusConflict, send(t, server.NewRequest(http.MethodDelete, "/api/dashboards/uid/1", nil)))
+		assert.Equal(t, http.StatusOK, send(t, server.NewRequest(http.MethodDelete, "/api/dashboards/uid/1?confirm=true", nil)))
+	})
+
+	t.Run("should delete an unprotected dashboard without confirmation", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, protect(t, "false"))
+
+		assert.Equal(t, http.StatusOK, send(t, server.NewRequest(http.MethodDelete, "/api/dashboards/uid/1", nil)))
+	})
+}
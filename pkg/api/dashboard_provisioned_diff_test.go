@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/provisioning"
+)
+
+func TestReadProvisionedDashboardFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dash.json"), []byte(`{"title": "From file"}`), 0600))
+
+	provisioningService := provisioning.NewProvisioningServiceMock(context.Background())
+	provisioningService.GetDashboardProvisionerResolvedPathFunc = func(name string) string {
+		return dir
+	}
+	hs := &HTTPServer{ProvisioningService: provisioningService}
+
+	data, err := hs.readProvisionedDashboardFile(&dashboards.DashboardProvisioning{Name: "default", ExternalID: filepath.Join(dir, "dash.json")})
+	require.NoError(t, err)
+	assert.Equal(t, "From file", data.Get("title").MustString())
+
+	t.Run("should reject a file outside of the provisioner path", func(t *testing.T) {
+		_, err := hs.readProvisionedDashboardFile(&dashboards.DashboardProvisioning{Name: "default", ExternalID: filepath.Join(dir, "..", "other.json")})
+		require.Error(t, err)
+	})
+
+	t.Run("should return a not exist error for a missing file", func(t *testing.T) {
+		_, err := hs.readProvisionedDashboardFile(&dashboards.DashboardProvisioning{Name: "default", ExternalID: filepath.Join(dir, "missing.json")})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestProvisioningDrift(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dash.json"), []byte(`{"title": "From file", "version": 1}`), 0600))
+
+	provisioningService := provisioning.NewProvisioningServiceMock(context.Background())
+	provisioningService.GetDashboardProvisionerResolvedPathFunc = func(name string) string {
+		return dir
+	}
+	hs := &HTTPServer{ProvisioningService: provisioningService}
+	provisioningData := &dashboards.DashboardProvisioning{Name: "default", ExternalID: filepath.Join(dir, "dash.json")}
+
+	t.Run("should not report drift when only the id and version differ", func(t *testing.T) {
+		dash := &dashboards.Dashboard{UID: "dash", Data: simplejson.NewFromAny(map[string]any{"id": 4, "uid": "dash", "title": "From file", "version": 3})}
+		drift, err := hs.provisioningDrift(dash, provisioningData)
+		require.NoError(t, err)
+		assert.False(t, drift)
+	})
+
+	t.Run("should report drift when the content differs", func(t *testing.T) {
+		dash := &dashboards.Dashboard{UID: "dash", Data: simplejson.NewFromAny(map[string]any{"uid": "dash", "title": "Edited"})}
+		drift, err := hs.provisioningDrift(dash, provisioningData)
+		require.NoError(t, err)
+		assert.True(t, drift)
+	})
+}
+
+func TestCompareWithProvisionedDashboard(t *testing.T) {
+	compare := func(t *testing.T, data, fileData string) []DashboardValidationError {
+		t.Helper()
+		dash, err := simplejson.NewJson([]byte(data))
+		require.NoError(t, err)
+		file, err := simplejson.NewJson([]byte(fileData))
+		require.NoError(t, err)
+		warnings, err := compareWithProvisionedDashboard(dash, file)
+		require.NoError(t, err)
+		return warnings
+	}
+
+	t.Run("should warn when the version is older than the provisioned one", func(t *testing.T) {
+		warnings := compare(t, `{"title": "A", "version": 2}`, `{"title": "A", "version": 3}`)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, "version", warnings[0].Path)
+		assert.Equal(t, validationCodeOlderThanProvisioned, warnings[0].Code)
+	})
+
+	t.Run("should not warn when the version is the same or newer", func(t *testing.T) {
+		assert.Empty(t, compare(t, `{"title": "B", "version": 3}`, `{"title": "A", "version": 3}`))
+		assert.Empty(t, compare(t, `{"title": "B", "version": 4}`, `{"title": "A", "version": 3}`))
+	})
+
+	t.Run("should compare the content without versions", func(t *testing.T) {
+		assert.Empty(t, compare(t, `{"title": "A", "id": 7}`, `{"title": "A", "version": 3}`))
+
+		warnings := compare(t, `{"title": "B"}`, `{"title": "A", "version": 3}`)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, validationCodeProvisionedMismatch, warnings[0].Code)
+	})
+}
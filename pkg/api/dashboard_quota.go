@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/dtos"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/quota"
+)
+
+// dashboardQuotaUsage returns the dashboard quota usage of the signed in
+// user's org and of the user with the given id. Scopes without a dashboard
+// quota are left out.
+func (hs *HTTPServer) dashboardQuotaUsage(c *contextmodel.ReqContext, userID int64) (*dtos.DashboardQuotaUsage, error) {
+	usage := &dtos.DashboardQuotaUsage{}
+
+	org, err := hs.dashboardQuotaByScope(c, quota.OrgScope, c.SignedInUser.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	usage.Org = org
+
+	if userID > 0 {
+		usr, err := hs.dashboardQuotaByScope(c, quota.UserScope, userID)
+		if err != nil {
+			return nil, err
+		}
+		usage.User = usr
+	}
+	return usage, nil
+}
+
+func (hs *HTTPServer) dashboardQuotaByScope(c *contextmodel.ReqContext, scope quota.Scope, id int64) (*dtos.QuotaUsage, error) {
+	quotas, err := hs.QuotaService.GetQuotasByScope(c.Req.Context(), scope, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range quotas {
+		if q.Target == string(dashboards.QuotaTarget) {
+			return &dtos.QuotaUsage{Used: q.Used, Limit: q.Limit}, nil
+		}
+	}
+	return nil, nil
+}
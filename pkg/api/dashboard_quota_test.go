@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/quota"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestPostDashboard_IncludeQuota(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Return(&dashboards.Dashboard{ID: 1, UID: "dash", Title: "Dash", Version: 1}, nil)
+		hs.DashboardService = dashSvc
+
+		quotaSvc := quotatest.New(false, nil)
+		quotaSvc.ExpectedQuotas = map[quota.Scope][]quota.QuotaDTO{
+			quota.OrgScope: {
+				{Target: "alert_rule", Used: 3, Limit: 10},
+				{Target: string(dashboards.QuotaTarget), Used: 48, Limit: 100},
+			},
+			quota.UserScope: {
+				{Target: "org_user", Used: 1, Limit: 10},
+			},
+		}
+		hs.QuotaService = quotaSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	save := func(t *testing.T, url string) map[string]json.RawMessage {
+		t.Helper()
+		usr := userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsCreate, Scope: dashboards.ScopeFoldersAll},
+			{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+		})
+		usr.IsAnonymous = false
+		usr.UserID = 2
+		req := server.NewPostRequest(url, strings.NewReader(`{"dashboard": {"title": "Dash"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, usr))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		result := map[string]json.RawMessage{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		return result
+	}
+
+	t.Run("should include the dashboard quota usage when asked", func(t *testing.T) {
+		result := save(t, "/api/dashboards/db?includeQuota=true")
+		require.Contains(t, result, "quota")
+
+		var usage dtos.DashboardQuotaUsage
+		require.NoError(t, json.Unmarshal(result["quota"], &usage))
+		require.NotNil(t, usage.Org)
+		assert.Equal(t, dtos.QuotaUsage{Used: 48, Limit: 100}, *usage.Org)
+		assert.Nil(t, usage.User)
+	})
+
+	t.Run("should not include the quota usage by default", func(t *testing.T) {
+		result := save(t, "/api/dashboards/db")
+		assert.NotContains(t, result, "quota")
+	})
+}
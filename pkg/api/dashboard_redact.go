@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/datasources"
+)
+
+// redactedPanelContent is the text shown in place of a redacted panel.
+const redactedPanelContent = "You don't have access to the data source of this panel."
+
+// redactNoAccessPanels replaces every panel using a data source the signed in
+// user can't query with a text panel, and returns the ids of the replaced
+// panels. References to template variables and built-in data sources are not
+// checked, and neither are legacy data source names that don't exist.
+func (hs *HTTPServer) redactNoAccessPanels(c *contextmodel.ReqContext, data *simplejson.Json) ([]int64, error) {
+	allowed := map[dashboardDatasourceRef]bool{}
+	canQuery := func(ref dashboardDatasourceRef) (bool, error) {
+		if ok, checked := allowed[ref]; checked {
+			return ok, nil
+		}
+
+		uid := ref.UID
+		if ref.Type == "" {
+			// a reference without type may be a legacy data source name
+			ds, err := hs.DataSourcesService.GetDataSource(c.Req.Context(), &datasources.GetDataSourceQuery{Name: ref.UID, OrgID: c.SignedInUser.GetOrgID()})
+			if err != nil && !errors.Is(err, datasources.ErrDataSourceNotFound) {
+				return false, err
+			}
+			if ds != nil {
+				uid = ds.UID
+			}
+		}
+
+		ok, err := hs.AccessControl.Evaluate(c.Req.Context(), c.SignedInUser,
+			accesscontrol.EvalPermission(datasources.ActionQuery, datasources.ScopeProvider.GetResourceScopeUID(uid)))
+		if err != nil {
+			return false, err
+		}
+		allowed[ref] = ok
+		return ok, nil
+	}
+
+	redacted := []int64{}
+	var walkErr error
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if walkErr != nil {
+			return
+		}
+		for _, ref := range panelDatasourceRefs(panel) {
+			if ref.isTemplated() || ref.isBuiltIn() {
+				continue
+			}
+			ok, err := canQuery(ref)
+			if err != nil {
+				walkErr = err
+				return
+			}
+			if !ok {
+				redacted = append(redacted, panel.Get("id").MustInt64())
+				redactPanel(panel)
+				return
+			}
+		}
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return redacted, nil
+}
+
+// redactPanel turns the panel into a text panel, keeping only its id, title
+// and position.
+func redactPanel(panel *simplejson.Json) {
+	fields := panel.MustMap()
+	for key := range fields {
+		if key != "id" && key != "title" && key != "gridPos" {
+			delete(fields, key)
+		}
+	}
+	fields["type"] = "text"
+	fields["options"] = map[string]any{
+		"mode":    "markdown",
+		"content": redactedPanelContent,
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardusage"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestHTTPServer_GetDashboard_RedactNoAccess(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+		var err error
+		dash.Data, err = simplejson.NewJson([]byte(`{"uid": "1", "title": "some dash", "panels": [
+			{"id": 1, "title": "Allowed", "type": "timeseries", "datasource": {"uid": "prom", "type": "prometheus"}},
+			{"id": 2, "title": "Denied", "type": "timeseries", "gridPos": {"x": 0, "y": 8, "w": 12, "h": 8},
+				"datasource": {"uid": "prom", "type": "prometheus"}, "targets": [{"datasource": {"uid": "loki", "type": "loki"}}]},
+			{"id": 3, "type": "row", "collapsed": true, "panels": [
+				{"id": 4, "title": "Legacy", "type": "table", "datasource": "Legacy"},
+				{"id": 5, "title": "Templated", "type": "table", "datasource": {"uid": "$ds"}}
+			]}
+		]}`))
+		require.NoError(t, err)
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.usageStore = dashboardusage.NewStore(kvstore.NewFakeKVStore())
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.DataSourcesService = &dataSourcesServiceMock{expectedDatasource: &datasources.DataSource{UID: "legacy", Name: "Legacy"}}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+		{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID("prom")},
+	}
+	get := func(t *testing.T, url string) dtos.DashboardFullWithMeta {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data
+	}
+
+	t.Run("should return all panels by default", func(t *testing.T) {
+		data := get(t, "/api/dashboards/uid/1")
+		assert.Empty(t, data.Meta.RedactedPanels)
+		assert.Equal(t, "timeseries", data.Dashboard.Get("panels").GetIndex(1).Get("type").MustString())
+	})
+
+	t.Run("should replace the panels using data sources the user can't query", func(t *testing.T) {
+		data := get(t, "/api/dashboards/uid/1?redactNoAccess=true")
+		assert.Equal(t, []int64{2, 4}, data.Meta.RedactedPanels)
+
+		panels := data.Dashboard.Get("panels")
+		assert.Equal(t, "timeseries", panels.GetIndex(0).Get("type").MustString())
+
+		denied := panels.GetIndex(1)
+		assert.Equal(t, map[string]any{
+			"id":      json.Number("2"),
+			"title":   "Denied",
+			"type":    "text",
+			"gridPos": map[string]any{"x": json.Number("0"), "y": json.Number("8"), "w": json.Number("12"), "h": json.Number("8")},
+			"options": map[string]any{"mode": "markdown", "content": redactedPanelContent},
+		}, denied.MustMap())
+
+		nested := panels.GetIndex(2).Get("panels")
+		assert.Equal(t, "text", nested.GetIndex(0).Get("type").MustString())
+		assert.Equal(t, "table", nested.GetIndex(1).Get("type").MustString())
+	})
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema/encoding/jsonschema"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/kinds/dashboard"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+// latestDashboardSchemaVersion is the schemaVersion dashboards are saved with
+// by the frontend, see DASHBOARD_SCHEMA_VERSION in DashboardMigrator.ts.
+const latestDashboardSchemaVersion = 39
+
+// swagger:route GET /dashboards/schema dashboards getDashboardSchema
+//
+// Get the dashboard JSON schema.
+//
+// Returns the JSON schema (draft 4) the validate endpoint checks dashboards at the given schemaVersion
+// against, or at the latest schemaVersion when no version is given. Definitions referenced by the schema
+// are under components.schemas.
+//
+// Like validating, getting the schema requires permission to write dashboards.
+//
+// Dashboards are only validated from schemaVersion 36, so a 404 is returned for lower versions and for
+// versions newer than the server knows.
+//
+// Responses:
+// 200: getDashboardSchemaResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardSchema(c *contextmodel.ReqContext) response.Response {
+	version := latestDashboardSchemaVersion
+	if v := c.Query("version"); v != "" {
+		var err error
+		if version, err = strconv.Atoi(v); err != nil {
+			return response.Error(http.StatusBadRequest, "version is invalid", err)
+		}
+	}
+	if version < dashboard.HandoffSchemaVersion || version > latestDashboardSchemaVersion {
+		return response.Error(http.StatusNotFound, fmt.Sprintf("No dashboard schema for schema version %d", version), nil)
+	}
+
+	schema, err := dashboardJSONSchema(hs.Kinds.Dashboard())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to generate the dashboard schema", err)
+	}
+	return response.JSON(http.StatusOK, schema)
+}
+
+// dashboardJSONSchema returns the JSON schema of the dashboard body. Every
+// schemaVersion the server validates is checked against the latest schema of
+// the dashboard kind.
+func dashboardJSONSchema(dk *dashboard.Kind) (map[string]any, error) {
+	f, err := jsonschema.GenerateSchema(dk.Lineage().Latest())
+	if err != nil {
+		return nil, err
+	}
+	b, err := cuecontext.New().BuildFile(f).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	// The kind describes the dashboard as a resource, the body is its spec.
+	var resource struct {
+		Properties struct {
+			Spec map[string]any `json:"spec"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(doc.Components.Schemas["dashboard"], &resource); err != nil {
+		return nil, err
+	}
+	schema := resource.Properties.Spec
+	if schema == nil {
+		return nil, fmt.Errorf("dashboard schema has no spec")
+	}
+
+	// Keep the definitions where the references of the spec point to.
+	schema["$schema"] = "http://json-schema.org/draft-04/schema#"
+	schema["components"] = map[string]any{"schemas": doc.Components.Schemas}
+	return schema, nil
+}
+
+// swagger:parameters getDashboardSchema
+type GetDashboardSchemaParams struct {
+	// The schemaVersion to get the schema of, the latest when not set.
+	// in:query
+	// required:false
+	Version int `json:"version"`
+}
+
+// swagger:response getDashboardSchemaResponse
+type GetDashboardSchemaResponse struct {
+	// in: body
+	Body map[string]any `json:"body"`
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/registry/corekind"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardSchema(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.Kinds = corekind.NewBase(nil)
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll}}
+	get := func(t *testing.T, url string) *http.Response {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should return the schema of the dashboard body", func(t *testing.T) {
+		for _, url := range []string{"/api/dashboards/schema", "/api/dashboards/schema?version=36"} {
+			res := get(t, url)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			var schema struct {
+				Schema     string                    `json:"$schema"`
+				Properties map[string]any            `json:"properties"`
+				Components map[string]map[string]any `json:"components"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&schema))
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, "http://json-schema.org/draft-04/schema#", schema.Schema)
+			assert.Contains(t, schema.Properties, "schemaVersion")
+			assert.Contains(t, schema.Properties, "panels")
+			assert.Contains(t, schema.Components["schemas"], "Panel")
+		}
+	})
+
+	t.Run("should return 404 for unknown schema versions", func(t *testing.T) {
+		for _, url := range []string{"/api/dashboards/schema?version=35", "/api/dashboards/schema?version=1000"} {
+			res := get(t, url)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		}
+	})
+
+	t.Run("should return 400 for an invalid version", func(t *testing.T) {
+		res := get(t, "/api/dashboards/schema?version=latest")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should require permission to write dashboards", func(t *testing.T) {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/schema"), userWithPermissions(1, nil)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+}
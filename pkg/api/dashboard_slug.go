@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /dashboards/slug/{slug} dashboards getDashboardBySlug
+//
+// Get dashboard by slug.
+//
+// Resolves the slug to a dashboard of the organization and returns it as the get dashboard by uid endpoint
+// does, accepting the same query parameters. Meant for old links that only have the slug.
+//
+// Slugs aren't unique across folders. When several dashboards the signed in user can view have the slug, a
+// 300 is returned listing them, so the caller can pick one by uid.
+//
+// Responses:
+// 200: dashboardResponse
+// 300: dashboardSlugCandidatesResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardBySlug(c *contextmodel.ReqContext) response.Response {
+	slug := web.Params(c.Req)[":slug"]
+	dashes, err := hs.DashboardService.GetDashboards(c.Req.Context(), &dashboards.GetDashboardsQuery{Slug: slug, OrgID: c.SignedInUser.GetOrgID()})
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboards", err)
+	}
+	if len(dashes) == 0 {
+		return response.Error(http.StatusNotFound, "Dashboard not found", nil)
+	}
+
+	candidates := make([]dtos.DashboardSlugCandidate, 0, len(dashes))
+	for _, dash := range dashes {
+		canView, err := hs.canViewDashboard(c, dash)
+		if err != nil {
+			return dashboardGuardianResponse(err)
+		}
+		if canView {
+			candidates = append(candidates, dtos.DashboardSlugCandidate{
+				UID:       dash.UID,
+				Title:     dash.Title,
+				FolderUID: dash.FolderUID,
+				URL:       dash.GetURL(),
+			})
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return dashboardGuardianResponse(nil)
+	case 1:
+		c.Req = web.SetURLParams(c.Req, map[string]string{":uid": candidates[0].UID})
+		return hs.GetDashboard(c)
+	default:
+		return response.JSON(http.StatusMultipleChoices, candidates)
+	}
+}
+
+// swagger:parameters getDashboardBySlug
+type GetDashboardBySlugParams struct {
+	// in:path
+	// required:true
+	Slug string `json:"slug"`
+}
+
+// swagger:response dashboardSlugCandidatesResponse
+type DashboardSlugCandidatesResponse struct {
+	// in: body
+	Body []dtos.DashboardSlugCandidate `json:"body"`
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardusage"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardBySlug(t *testing.T) {
+	newDash := func(uid string, title string, folderUID string) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(title)
+		dash.ID = int64(len(uid))
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.FolderUID = folderUID
+		dash.Data = simplejson.NewFromAny(map[string]any{"uid": uid, "title": title})
+		return dash
+	}
+	dashes := []*dashboards.Dashboard{
+		newDash("a", "Single", ""),
+		newDash("bb", "Shared", ""),
+		newDash("ccc", "Shared", "f"),
+		newDash("dddd", "Hidden", ""),
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return(func(_ context.Context, q *dashboards.GetDashboardsQuery) []*dashboards.Dashboard {
+			var found []*dashboards.Dashboard
+			for _, dash := range dashes {
+				if dash.Slug == q.Slug {
+					found = append(found, dash)
+				}
+			}
+			return found
+		}, nil)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(func(_ context.Context, q *dashboards.GetDashboardQuery) *dashboards.Dashboard {
+			for _, dash := range dashes {
+				if dash.UID == q.UID {
+					return dash
+				}
+			}
+			return nil
+		}, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.usageStore = dashboardusage.NewStore(kvstore.NewFakeKVStore())
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:bb"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:ccc"},
+	}
+	get := func(t *testing.T, slug string) *http.Response {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/slug/"+slug), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should return the dashboard with the slug", func(t *testing.T) {
+		res := get(t, "single")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, "a", data.Dashboard.Get("uid").MustString())
+		assert.Equal(t, "single", data.Meta.Slug)
+	})
+
+	t.Run("should list the dashboards sharing the slug", func(t *testing.T) {
+		res := get(t, "shared")
+		require.Equal(t, http.StatusMultipleChoices, res.StatusCode)
+		var candidates []dtos.DashboardSlugCandidate
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&candidates))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, []dtos.DashboardSlugCandidate{
+			{UID: "bb", Title: "Shared", URL: "/d/bb/shared"},
+			{UID: "ccc", Title: "Shared", FolderUID: "f", URL: "/d/ccc/shared"},
+		}, candidates)
+	})
+
+	t.Run("should reject dashboards the user can't view", func(t *testing.T) {
+		res := get(t, "hidden")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should return 404 for an unknown slug", func(t *testing.T) {
+		res := get(t, "missing")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
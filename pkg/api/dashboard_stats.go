@@ -0,0 +1,50 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// unknownDatasourceType is the type data source references without a type,
+// such as legacy data source names, are counted under.
+const unknownDatasourceType = "unknown"
+
+// setDashboardStats sets the panel count, the number of panels per data
+// source type and the template variable names of the dashboard on the meta.
+// Rows are not counted as panels.
+func setDashboardStats(meta *dtos.DashboardMeta, data *simplejson.Json) {
+	panelCount := 0
+	datasourceTypes := map[string]int{}
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		if panel.Get("type").MustString() == "row" {
+			return
+		}
+		panelCount++
+
+		types := map[string]bool{}
+		for _, ref := range panelDatasourceRefs(panel) {
+			dsType := ref.Type
+			if dsType == "" {
+				dsType = unknownDatasourceType
+			}
+			if !types[dsType] {
+				types[dsType] = true
+				datasourceTypes[dsType]++
+			}
+		}
+	})
+
+	variables := []string{}
+	seen := map[string]bool{}
+	for _, variable := range data.GetPath("templating", "list").MustArray() {
+		name := simplejson.NewFromAny(variable).Get("name").MustString()
+		if name != "" && !seen[name] {
+			seen[name] = true
+			variables = append(variables, name)
+		}
+	}
+
+	meta.PanelCount = &panelCount
+	meta.DatasourceTypes = datasourceTypes
+	meta.TemplateVariables = variables
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestSetDashboardStats(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"panels": [
+			{"id": 1, "type": "timeseries", "datasource": {"uid": "prom", "type": "prometheus"},
+				"targets": [{"datasource": {"uid": "prom", "type": "prometheus"}}, {"datasource": {"uid": "loki", "type": "loki"}}]},
+			{"id": 2, "type": "row", "collapsed": true, "panels": [
+				{"id": 3, "type": "stat", "datasource": {"uid": "prom2", "type": "prometheus"}},
+				{"id": 4, "type": "table", "datasource": "Legacy"}
+			]},
+			{"id": 5, "type": "text"}
+		],
+		"templating": {"list": [{"name": "env"}, {"name": "region"}, {"name": "env"}]}
+	}`))
+	require.NoError(t, err)
+
+	meta := dtos.DashboardMeta{}
+	setDashboardStats(&meta, data)
+
+	require.NotNil(t, meta.PanelCount)
+	assert.Equal(t, 4, *meta.PanelCount)
+	assert.Equal(t, map[string]int{"prometheus": 2, "loki": 1, unknownDatasourceType: 1}, meta.DatasourceTypes)
+	assert.Equal(t, []string{"env", "region"}, meta.TemplateVariables)
+
+	t.Run("should report zero panels for an empty dashboard", func(t *testing.T) {
+		meta := dtos.DashboardMeta{}
+		setDashboardStats(&meta, simplejson.New())
+		require.NotNil(t, meta.PanelCount)
+		assert.Equal(t, 0, *meta.PanelCount)
+		assert.Empty(t, meta.DatasourceTypes)
+		assert.Empty(t, meta.TemplateVariables)
+	})
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route POST /dashboards/uid/{uid}/tags dashboards addDashboardTag
+//
+// Add a tag to a dashboard.
+//
+// Saves the dashboard with the tag added as a new version. Adding a tag the dashboard already has doesn't
+// save it.
+//
+// Responses:
+// 200: updatedDashboardTagsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) AddDashboardTag(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.DashboardTagCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	tag := strings.TrimSpace(cmd.Tag)
+	if tag == "" {
+		return response.Error(http.StatusBadRequest, "tag is required", nil)
+	}
+	return hs.updateDashboardTags(c, "Added tag "+tag, func(data *simplejson.Json) bool {
+		return addDashboardTag(data, tag)
+	})
+}
+
+// swagger:route DELETE /dashboards/uid/{uid}/tags/{tag} dashboards removeDashboardTag
+//
+// Remove a tag from a dashboard.
+//
+// Saves the dashboard without the tag as a new version. Removing a tag the dashboard doesn't have doesn't
+// save it.
+//
+// Responses:
+// 200: updatedDashboardTagsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) RemoveDashboardTag(c *contextmodel.ReqContext) response.Response {
+	tag := web.Params(c.Req)[":tag"]
+	return hs.updateDashboardTags(c, "Removed tag "+tag, func(data *simplejson.Json) bool {
+		return removeDashboardTag(data, tag)
+	})
+}
+
+func (hs *HTTPServer) updateDashboardTags(c *contextmodel.ReqContext, message string, mutate func(data *simplejson.Json) bool) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	result := dtos.DashboardTags{UID: dash.UID, Version: dash.Version}
+	if mutate(dash.Data) {
+		saved, rsp := hs.saveDashboardChanges(c, dash, message)
+		if rsp != nil {
+			return rsp
+		}
+		result.Version, _ = saved["version"].(int)
+	}
+	result.Tags = dash.GetTags()
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route POST /dashboards/bulk-tag dashboards bulkTagDashboards
+//
+// Add a tag to dashboards.
+//
+// Adds the tag to each of the given dashboards the signed in user can save, saving every changed
+// dashboard as a new version. Dashboards that already have the tag are reported as unchanged. With
+// dryRun set nothing is saved.
+//
+// Responses:
+// 200: bulkDashboardResultsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) BulkTagDashboards(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.BulkTagDashboardsCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	tag := strings.TrimSpace(cmd.Tag)
+	if tag == "" {
+		return response.Error(http.StatusBadRequest, "tag is required", nil)
+	}
+
+	results, dashes, err := hs.bulkDashboards(c, cmd.DashboardUIDs, "")
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Failed to select dashboards", err)
+	}
+	for _, dash := range dashes {
+		results = append(results, hs.bulkUpdateDashboard(c, dash, cmd.DryRun, "Added tag "+tag, func(data *simplejson.Json) bool {
+			return addDashboardTag(data, tag)
+		}))
+	}
+
+	return response.JSON(http.StatusOK, results)
+}
+
+// addDashboardTag adds the tag to the dashboard unless it has it already.
+// It reports whether the dashboard changed.
+func addDashboardTag(data *simplejson.Json, tag string) bool {
+	tags := data.Get("tags").MustStringArray()
+	for _, t := range tags {
+		if t == tag {
+			return false
+		}
+	}
+	setDashboardTags(data, append(tags, tag))
+	return true
+}
+
+// removeDashboardTag removes every occurrence of the tag from the dashboard.
+// It reports whether the dashboard changed.
+func removeDashboardTag(data *simplejson.Json, tag string) bool {
+	tags := data.Get("tags").MustStringArray()
+	kept := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t != tag {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == len(tags) {
+		return false
+	}
+	setDashboardTags(data, kept)
+	return true
+}
+
+// setDashboardTags sets the tags the way they are decoded from JSON, so they
+// can be read back with MustStringArray.
+func setDashboardTags(data *simplejson.Json, tags []string) {
+	values := make([]any, 0, len(tags))
+	for _, t := range tags {
+		values = append(values, t)
+	}
+	data.Set("tags", values)
+}
+
+// swagger:parameters addDashboardTag
+type AddDashboardTagParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:body
+	// required:true
+	Body dtos.DashboardTagCommand
+}
+
+// swagger:parameters removeDashboardTag
+type RemoveDashboardTagParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:path
+	// required:true
+	Tag string `json:"tag"`
+}
+
+// swagger:parameters bulkTagDashboards
+type BulkTagDashboardsParams struct {
+	// in:body
+	// required:true
+	Body dtos.BulkTagDashboardsCommand
+}
+
+// swagger:response updatedDashboardTagsResponse
+type UpdatedDashboardTagsResponse struct {
+	// in: body
+	Body dtos.DashboardTags `json:"body"`
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestAddAndRemoveDashboardTag(t *testing.T) {
+	data := simplejson.NewFromAny(map[string]any{"tags": []any{"a", "b", "a"}})
+
+	assert.False(t, addDashboardTag(data, "b"))
+	assert.True(t, addDashboardTag(data, "c"))
+	assert.Equal(t, []string{"a", "b", "a", "c"}, data.Get("tags").MustStringArray())
+
+	assert.True(t, removeDashboardTag(data, "a"))
+	assert.Equal(t, []string{"b", "c"}, data.Get("tags").MustStringArray())
+	assert.False(t, removeDashboardTag(data, "a"))
+
+	empty := simplejson.New()
+	assert.True(t, addDashboardTag(empty, "a"))
+	assert.Equal(t, []string{"a"}, empty.Get("tags").MustStringArray())
+}
+
+// freshDashboardService returns a new copy of the stored dashboard on every
+// read, so that changes made by a request don't leak into the next one.
+type freshDashboardService struct {
+	*dashboards.FakeDashboardService
+	newDash func() *dashboards.Dashboard
+}
+
+func (s *freshDashboardService) GetDashboard(ctx context.Context, query *dashboards.GetDashboardQuery) (*dashboards.Dashboard, error) {
+	return s.newDash(), nil
+}
+
+func TestDashboardTagEndpoints(t *testing.T) {
+	newDash := func(uid string, id int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = id
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.Version = 4
+		dash.Data.Set("tags", []any{"team"})
+		return dash
+	}
+
+	var saved []*dashboards.SaveDashboardDTO
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := &freshDashboardService{FakeDashboardService: dashboards.NewFakeDashboardService(t), newDash: func() *dashboards.Dashboard { return newDash("a", 1) }}
+		dashSvc.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{newDash("a", 1), newDash("b", 2)}, nil).Maybe()
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO))
+		}).Return(&dashboards.Dashboard{ID: 1, UID: "a", Title: "a", Version: 5}, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:a"},
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:b"},
+	}
+	send := func(t *testing.T, method, url, body string) *http.Response {
+		t.Helper()
+		req := server.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+	decodeTags := func(t *testing.T, res *http.Response) dtos.DashboardTags {
+		t.Helper()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var tags dtos.DashboardTags
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&tags))
+		require.NoError(t, res.Body.Close())
+		return tags
+	}
+
+	t.Run("should save the dashboard with the added tag", func(t *testing.T) {
+		saved = nil
+		tags := decodeTags(t, send(t, http.MethodPost, "/api/dashboards/uid/a/tags", `{"tag": " prod "}`))
+		assert.Equal(t, dtos.DashboardTags{UID: "a", Version: 5, Tags: []string{"team", "prod"}}, tags)
+		require.Len(t, saved, 1)
+		assert.Equal(t, []string{"team", "prod"}, saved[0].Dashboard.GetTags())
+		assert.Equal(t, "Added tag prod", saved[0].Message)
+	})
+
+	t.Run("should not save when the tag is already there", func(t *testing.T) {
+		saved = nil
+		tags := decodeTags(t, send(t, http.MethodPost, "/api/dashboards/uid/a/tags", `{"tag": "team"}`))
+		assert.Equal(t, dtos.DashboardTags{UID: "a", Version: 4, Tags: []string{"team"}}, tags)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("should save the dashboard without the removed tag", func(t *testing.T) {
+		saved = nil
+		tags := decodeTags(t, send(t, http.MethodDelete, "/api/dashboards/uid/a/tags/team", ""))
+		assert.Equal(t, []string{}, tags.Tags)
+		require.Len(t, saved, 1)
+	})
+
+	t.Run("should tag the dashboards the user can save", func(t *testing.T) {
+		saved = nil
+		res := send(t, http.MethodPost, "/api/dashboards/bulk-tag", `{"dashboardUids": ["a", "b", "c"], "tag": "prod"}`)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var results []dtos.BulkDashboardResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&results))
+		require.NoError(t, res.Body.Close())
+
+		statuses := map[string]string{}
+		for _, result := range results {
+			statuses[result.UID] = result.Status
+		}
+		assert.Equal(t, map[string]string{"a": bulkResultUpdated, "b": bulkResultForbidden, "c": bulkResultNotFound}, statuses)
+		assert.Len(t, saved, 1)
+	})
+}
@@ -3,9 +3,13 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,13 +21,16 @@ import (
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/bus"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/db/dbtest"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/localcache"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/infra/usagestats"
+	"github.com/grafana/grafana/pkg/plugins"
 	"github.com/grafana/grafana/pkg/registry/corekind"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
@@ -36,8 +43,11 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboards/database"
 	"github.com/grafana/grafana/pkg/services/dashboards/service"
+	"github.com/grafana/grafana/pkg/services/dashboardusage"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
 	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	fakeDatasources "github.com/grafana/grafana/pkg/services/datasources/fakes"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/folder/folderimpl"
@@ -53,6 +63,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/provisioning"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/api"
+	publicdashboardModels "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/quota/quotatest"
 	"github.com/grafana/grafana/pkg/services/star/startest"
 	"github.com/grafana/grafana/pkg/services/tag/tagimpl"
@@ -118,6 +129,96 @@ func TestGetHomeDashboard(t *testing.T) {
 	}
 }
 
+type homePreferenceService struct {
+	preftest.FakePreferenceService
+	homeDashboards map[pref.GetPreferenceQuery]int64
+}
+
+func (s *homePreferenceService) Get(ctx context.Context, query *pref.GetPreferenceQuery) (*pref.Preference, error) {
+	return &pref.Preference{HomeDashboardID: s.homeDashboards[*query]}, nil
+}
+
+func TestGetHomeDashboard_Preferences(t *testing.T) {
+	httpReq, err := http.NewRequest(http.MethodGet, "", nil)
+	require.NoError(t, err)
+	req := &contextmodel.ReqContext{
+		SignedInUser: &user.SignedInUser{OrgID: 1, UserID: 1, Teams: []int64{2, 3}},
+		Context:      &web.Context{Req: httpReq},
+	}
+
+	dashSvc := dashboards.NewFakeDashboardService(t)
+	dashSvc.On("GetDashboardUIDByID", mock.Anything, &dashboards.GetDashboardRefByIDQuery{ID: 10}).Return(nil, dashboards.ErrDashboardNotFound).Maybe()
+	for id, uid := range map[int64]string{11: "user", 12: "team-2", 13: "team-3", 14: "org"} {
+		dashSvc.On("GetDashboardUIDByID", mock.Anything, &dashboards.GetDashboardRefByIDQuery{ID: id}).Return(&dashboards.DashboardRef{UID: uid, Slug: uid}, nil).Maybe()
+	}
+
+	prefService := &homePreferenceService{}
+	hs := &HTTPServer{
+		Cfg:               setting.NewCfg(),
+		DashboardService:  dashSvc,
+		preferenceService: prefService,
+		log:               log.New("test-logger"),
+	}
+
+	tests := []struct {
+		name           string
+		homeDashboards map[pref.GetPreferenceQuery]int64
+		expectedURL    string
+	}{
+		{
+			name: "user preference wins",
+			homeDashboards: map[pref.GetPreferenceQuery]int64{
+				{OrgID: 1, UserID: 1}: 11, {OrgID: 1, TeamID: 2}: 12, {OrgID: 1}: 14,
+			},
+			expectedURL: "/d/user/user",
+		},
+		{
+			name: "team preference wins over org, highest team id first",
+			homeDashboards: map[pref.GetPreferenceQuery]int64{
+				{OrgID: 1, TeamID: 2}: 12, {OrgID: 1, TeamID: 3}: 13, {OrgID: 1}: 14,
+			},
+			expectedURL: "/d/team-3/team-3",
+		},
+		{
+			name: "missing dashboards are skipped",
+			homeDashboards: map[pref.GetPreferenceQuery]int64{
+				{OrgID: 1, UserID: 1}: 10, {OrgID: 1, TeamID: 3}: 10, {OrgID: 1}: 14,
+			},
+			expectedURL: "/d/org/org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prefService.homeDashboards = tc.homeDashboards
+
+			res := hs.GetHomeDashboard(req)
+			require.Equal(t, http.StatusOK, res.Status())
+
+			var redirect dtos.DashboardRedirect
+			require.NoError(t, json.Unmarshal(res.Body(), &redirect))
+			assert.Equal(t, tc.expectedURL, redirect.RedirectUri)
+			assert.Equal(t, strings.Split(tc.expectedURL, "/")[2], redirect.UID)
+		})
+	}
+
+	t.Run("redirects with redirect=true", func(t *testing.T) {
+		prefService.homeDashboards = map[pref.GetPreferenceQuery]int64{{OrgID: 1}: 14}
+		httpReq, err := http.NewRequest(http.MethodGet, "/api/dashboards/home?redirect=true", nil)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		req := &contextmodel.ReqContext{
+			SignedInUser: &user.SignedInUser{OrgID: 1, UserID: 1},
+			Context:      &web.Context{Req: httpReq, Resp: web.NewResponseWriter(http.MethodGet, rec)},
+		}
+
+		res := hs.GetHomeDashboard(req)
+		require.Equal(t, http.StatusFound, res.Status())
+		res.WriteTo(req)
+		assert.Equal(t, "/d/org/org", rec.Header().Get("Location"))
+	})
+}
+
 func newTestLive(t *testing.T, store db.DB) *live.GrafanaLive {
 	features := featuremgmt.WithFeatures()
 	cfg := setting.NewCfg()
@@ -252,6 +353,316 @@ func TestHTTPServer_GetDashboard_AccessControl(t *testing.T) {
 	})
 }
 
+func TestHTTPServer_GetDashboard_FolderPath(t *testing.T) {
+	getFolderPath := func(t *testing.T, folderID int64) []dtos.FolderPathItem {
+		server := SetupAPITestServer(t, func(hs *HTTPServer) {
+			dash := dashboards.NewDashboard("some dash")
+			dash.ID = 1
+			dash.UID = "1"
+			// nolint:staticcheck
+			dash.FolderID = folderID
+			folderDash := dashboards.NewDashboardFolder("Child")
+			folderDash.ID = 3
+			folderDash.UID = "child"
+
+			dashSvc := dashboards.NewFakeDashboardService(t)
+			dashSvc.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool { return q.ID == 3 })).Return(folderDash, nil).Maybe()
+			dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+			hs.DashboardService = dashSvc
+
+			folderSvc := foldertest.NewFakeService()
+			folderSvc.ExpectedFolders = []*folder.Folder{{UID: "root", Title: "Root"}, {UID: "parent", Title: "Parent"}}
+			hs.folderService = folderSvc
+
+			hs.Cfg = setting.NewCfg()
+			hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+			hs.starService = startest.NewStarServiceFake()
+			hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+			guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+		})
+
+		permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data.Meta.FolderPath
+	}
+
+	t.Run("Should list the folders from the root to the folder of the dashboard", func(t *testing.T) {
+		assert.Equal(t, []dtos.FolderPathItem{
+			{UID: "root", Title: "Root"},
+			{UID: "parent", Title: "Parent"},
+			{UID: "child", Title: "Child"},
+		}, getFolderPath(t, 3))
+	})
+
+	t.Run("Should return an empty path for the General folder", func(t *testing.T) {
+		assert.Equal(t, []dtos.FolderPathItem{}, getFolderPath(t, 0))
+	})
+}
+
+func TestHTTPServer_GetDashboard_PublicDashboard(t *testing.T) {
+	getMeta := func(t *testing.T, permissions []accesscontrol.Permission) dtos.DashboardMeta {
+		server := SetupAPITestServer(t, func(hs *HTTPServer) {
+			dash := dashboards.NewDashboard("some dash")
+			dash.ID = 1
+			dash.UID = "1"
+
+			dashSvc := dashboards.NewFakeDashboardService(t)
+			dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+			hs.DashboardService = dashSvc
+
+			hs.Cfg = setting.NewCfg()
+			hs.Features = featuremgmt.WithFeatures(featuremgmt.FlagPublicDashboards)
+			hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+			hs.starService = startest.NewStarServiceFake()
+			hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+			pubDashService := publicdashboards.NewFakePublicDashboardService(t)
+			pubDashService.On("FindByDashboardUid", mock.Anything, int64(1), "1").Return(&publicdashboardModels.PublicDashboard{Uid: "pubdash", DashboardUid: "1", IsEnabled: true}, nil)
+			hs.PublicDashboardsApi = api.ProvideApi(pubDashService, nil, hs.AccessControl, featuremgmt.WithFeatures())
+
+			guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+		})
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data.Meta
+	}
+
+	t.Run("Should return the public dashboard uid to users who can manage it", func(t *testing.T) {
+		meta := getMeta(t, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+			{Action: dashboards.ActionDashboardsPublicWrite, Scope: dashboards.ScopeDashboardsAll},
+		})
+		assert.True(t, meta.PublicDashboardEnabled)
+		assert.Equal(t, "pubdash", meta.PublicDashboardUID)
+	})
+
+	t.Run("Should omit the public dashboard uid for viewers", func(t *testing.T) {
+		meta := getMeta(t, []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}})
+		assert.True(t, meta.PublicDashboardEnabled)
+		assert.Empty(t, meta.PublicDashboardUID)
+	})
+}
+
+func TestHTTPServer_GetDashboard_Usage(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.starService = &startest.FakeStarService{ExpectedCount: 3}
+		hs.usageStore = dashboardusage.NewStore(kvstore.NewFakeKVStore())
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	getMeta := func(t *testing.T, url string) dtos.DashboardMeta {
+		permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data.Meta
+	}
+
+	meta := getMeta(t, "/api/dashboards/uid/1")
+	assert.Nil(t, meta.ViewCount)
+	assert.Nil(t, meta.StarCount)
+
+	meta = getMeta(t, "/api/dashboards/uid/1?withUsage=true")
+	require.NotNil(t, meta.ViewCount)
+	assert.EqualValues(t, 1, *meta.ViewCount)
+	require.NotNil(t, meta.LastViewedAt)
+	assert.WithinDuration(t, time.Now(), *meta.LastViewedAt, time.Minute)
+	require.NotNil(t, meta.StarCount)
+	assert.EqualValues(t, 3, *meta.StarCount)
+}
+
+func TestHTTPServer_GetDashboard_Modifiers(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+		dash.Created, dash.Updated = created, updated
+		// the creator no longer exists
+		dash.CreatedBy, dash.UpdatedBy = 3, 2
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.userService = &usertest.FakeUserService{ExpectedUsers: []*user.User{{ID: 2, Login: "editor"}}}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+	res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1"), userWithPermissions(1, permissions)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var data dtos.DashboardFullWithMeta
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+	require.NoError(t, res.Body.Close())
+
+	assert.Equal(t, "editor", data.Meta.UpdatedBy)
+	assert.Equal(t, anonString, data.Meta.CreatedBy)
+	assert.True(t, created.Equal(data.Meta.Created))
+	assert.True(t, updated.Equal(data.Meta.Updated))
+}
+
+func TestHTTPServer_GetDashboard_Export(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+		dash.Version = 3
+		dash.Data = simplejson.NewFromAny(map[string]any{
+			"id": 1, "uid": "1", "title": "some dash", "version": 3,
+			"panels": []any{map[string]any{"id": 1, "description": "written", "datasource": map[string]any{"uid": "prom-1", "id": 4}}},
+		})
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.usageStore = dashboardusage.NewStore(kvstore.NewFakeKVStore())
+		hs.LibraryElementService = &mockLibraryElementService{}
+		hs.DataSourcesService = &dataSourcesServiceMock{
+			expectedDatasources: []*datasources.DataSource{{UID: "prom-1", Name: "Prom", Type: "prometheus"}},
+		}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll}}
+	res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1?export=true&descriptions=auto"), userWithPermissions(1, permissions)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	export, err := simplejson.NewFromReader(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	_, hasMeta := export.CheckGet("meta")
+	assert.False(t, hasMeta)
+	assert.Nil(t, export.Get("id").Interface())
+	_, hasVersion := export.CheckGet("version")
+	assert.False(t, hasVersion)
+	assert.Equal(t, map[string]any{"type": "prometheus", "uid": "${DS_PROM}"}, export.GetPath("panels").GetIndex(0).Get("datasource").MustMap())
+	assert.Equal(t, "DS_PROM", export.Get("__inputs").GetIndex(0).Get("name").MustString())
+	_, hasDescription := export.GetPath("panels").GetIndex(0).CheckGet("description")
+	assert.False(t, hasDescription)
+}
+
+func TestHTTPServer_GetDashboard_ETag(t *testing.T) {
+	dash := dashboards.NewDashboard("some dash")
+	dash.ID = 1
+	dash.UID = "1"
+	dash.Version = 1
+	dash.Updated = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.starService = startest.NewStarServiceFake()
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"}}
+	getDashboard := func(ifNoneMatch string, permissions []accesscontrol.Permission) *http.Response {
+		req := server.NewGetRequest("/api/dashboards/uid/1")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	res := getDashboard("", permissions)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	etag := res.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	require.NoError(t, res.Body.Close())
+
+	t.Run("should keep the etag stable across reads", func(t *testing.T) {
+		res := getDashboard("", permissions)
+		assert.Equal(t, etag, res.Header.Get("ETag"))
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should return 304 when the etag matches", func(t *testing.T) {
+		res := getDashboard(`"other", `+etag, permissions)
+		assert.Equal(t, http.StatusNotModified, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Empty(t, body)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should change the etag when the meta changes", func(t *testing.T) {
+		res := getDashboard(etag, append(permissions, accesscontrol.Permission{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1"}))
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotEqual(t, etag, res.Header.Get("ETag"))
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should not return 304 without permission to read the dashboard", func(t *testing.T) {
+		res := getDashboard(etag, nil)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should change the etag when the dashboard is saved", func(t *testing.T) {
+		dash.Version = 2
+		dash.Updated = dash.Updated.Add(time.Minute)
+
+		res := getDashboard(etag, permissions)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotEqual(t, etag, res.Header.Get("ETag"))
+		require.NoError(t, res.Body.Close())
+	})
+}
+
 func TestHTTPServer_DeleteDashboardByUID_AccessControl(t *testing.T) {
 	setup := func() *webtest.Server {
 		return SetupAPITestServer(t, func(hs *HTTPServer) {
@@ -305,6 +716,134 @@ func TestHTTPServer_DeleteDashboardByUID_AccessControl(t *testing.T) {
 	})
 }
 
+func TestHTTPServer_Dashboard_InheritedFolderAccessControl(t *testing.T) {
+	// the dashboard lives in folder child, nested in folder parent
+	setup := func() *webtest.Server {
+		return SetupAPITestServer(t, func(hs *HTTPServer) {
+			dash := dashboards.NewDashboard("some dash")
+			dash.ID = 1
+			dash.UID = "1"
+			dash.OrgID = 1
+			dash.FolderID = 2 // nolint:staticcheck
+			dash.FolderUID = "child"
+
+			childFolder := dashboards.NewDashboardFolder("child")
+			childFolder.ID = 2
+			childFolder.UID = "child"
+
+			dashSvc := dashboards.NewFakeDashboardService(t)
+			dashSvc.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool { return q.ID == 2 })).Return(childFolder, nil).Maybe()
+			dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+			dashSvc.On("DeleteDashboard", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			hs.DashboardService = dashSvc
+
+			folderSvc := foldertest.NewFakeService()
+			folderSvc.ExpectedFolders = []*folder.Folder{{UID: "parent", Title: "parent"}}
+			hs.folderService = folderSvc
+			folderStore := foldertest.NewFakeFolderStore(t)
+			folderStore.On("GetFolderByID", mock.Anything, int64(1), int64(2)).Return(&folder.Folder{ID: 2, UID: "child", Title: "child"}, nil).Maybe()
+
+			hs.Cfg = setting.NewCfg()
+			hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+			hs.AccessControl.RegisterScopeAttributeResolver(dashboards.NewDashboardUIDScopeResolver(folderStore, dashSvc, folderSvc))
+			hs.starService = startest.NewStarServiceFake()
+			hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+			hs.LibraryPanelService = &mockLibraryPanelService{}
+			hs.LibraryElementService = &mockLibraryElementService{}
+
+			pubDashService := publicdashboards.NewFakePublicDashboardService(t)
+			pubDashService.On("DeleteByDashboard", mock.Anything, mock.Anything).Return(nil).Maybe()
+			hs.PublicDashboardsApi = api.ProvideApi(pubDashService, nil, hs.AccessControl, featuremgmt.WithFeatures())
+
+			guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+		})
+	}
+
+	getDashboard := func(server *webtest.Server, permissions []accesscontrol.Permission) (*http.Response, error) {
+		return server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1"), userWithPermissions(1, permissions)))
+	}
+	deleteDashboard := func(server *webtest.Server, permissions []accesscontrol.Permission) (*http.Response, error) {
+		return server.Send(webtest.RequestWithSignedInUser(server.NewRequest(http.MethodDelete, "/api/dashboards/uid/1", nil), userWithPermissions(1, permissions)))
+	}
+
+	t.Run("Should not be able to get dashboard with permissions on another folder", func(t *testing.T) {
+		server := setup()
+
+		res, err := getDashboard(server, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "folders:uid:other"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should set CanSave and CanEdit with permissions on a parent folder", func(t *testing.T) {
+		server := setup()
+
+		res, err := getDashboard(server, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "folders:uid:parent"},
+			{Action: dashboards.ActionDashboardsWrite, Scope: "folders:uid:parent"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+
+		assert.Equal(t, data.Meta.CanSave, true)
+		assert.Equal(t, data.Meta.CanEdit, true)
+		assert.Equal(t, data.Meta.CanDelete, false)
+		assert.Equal(t, data.Meta.CanAdmin, false)
+
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should set canDelete with wildcard folder permissions", func(t *testing.T) {
+		server := setup()
+
+		res, err := getDashboard(server, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "folders:uid:child"},
+			{Action: dashboards.ActionDashboardsDelete, Scope: "folders:uid:*"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+
+		assert.Equal(t, data.Meta.CanSave, false)
+		assert.Equal(t, data.Meta.CanEdit, false)
+		assert.Equal(t, data.Meta.CanDelete, true)
+		assert.Equal(t, data.Meta.CanAdmin, false)
+
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should not be able to delete dashboard with delete permission on another folder", func(t *testing.T) {
+		server := setup()
+		res, err := deleteDashboard(server, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsDelete, Scope: "folders:uid:other"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should be able to delete dashboard with delete permission on a parent folder", func(t *testing.T) {
+		server := setup()
+		res, err := deleteDashboard(server, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsDelete, Scope: "folders:uid:parent"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
 func TestHTTPServer_GetDashboardVersions_AccessControl(t *testing.T) {
 	setup := func() *webtest.Server {
 		return SetupAPITestServer(t, func(hs *HTTPServer) {
@@ -370,7 +909,27 @@ func TestHTTPServer_GetDashboardVersions_AccessControl(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, res.StatusCode)
 
-		require.NoError(t, res.Body.Close())
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should cap the number of versions listed with their data", func(t *testing.T) {
+		server := setup()
+
+		permissions := []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"},
+			{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1"},
+		}
+		list := func(query string) int {
+			res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1/versions?"+query), userWithPermissions(1, permissions)))
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			return res.StatusCode
+		}
+
+		assert.Equal(t, http.StatusOK, list("include=data&limit=20"))
+		assert.Equal(t, http.StatusBadRequest, list("include=data&limit=21"))
+		assert.Equal(t, http.StatusBadRequest, list("include=data"))
+		assert.Equal(t, http.StatusOK, list("limit=100"))
 	})
 }
 
@@ -467,29 +1026,66 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 			})
 		})
 
+		t.Run("Given a request for saving a dashboard as a copy", func(t *testing.T) {
+			cmd := dashboards.SaveDashboardCommand{
+				OrgID: 1,
+				Dashboard: simplejson.NewFromAny(map[string]any{
+					"id":      7,
+					"uid":     "original",
+					"title":   "Dash",
+					"version": 3,
+				}),
+				Overwrite:  true,
+				SaveAsCopy: true,
+			}
+
+			var saved []*dashboards.Dashboard
+			dashboardService := dashboards.NewFakeDashboardService(t)
+			dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).
+				Return(nil, dashboards.ErrDashboardWithSameNameInFolderExists).Once()
+			dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).Run(func(args mock.Arguments) {
+				dto := args.Get(1).(*dashboards.SaveDashboardDTO)
+				saved = append(saved, dto.Dashboard)
+				assert.False(t, dto.Overwrite)
+			}).Return(&dashboards.Dashboard{ID: 8, UID: "copy", Title: "Dash Copy", Slug: "dash-copy", Version: 1}, nil).Once()
+
+			postDashboardScenario(t, "When calling POST on", "/api/dashboards", "/api/dashboards", cmd, dashboardService, nil, func(sc *scenarioContext) {
+				callPostDashboardShouldReturnSuccess(sc)
+
+				require.Len(t, saved, 1)
+				assert.Equal(t, int64(0), saved[0].ID)
+				assert.Empty(t, saved[0].UID)
+				assert.Equal(t, "Dash Copy", saved[0].Title)
+				assert.Equal(t, "Dash Copy", saved[0].Data.Get("title").MustString())
+				assert.Equal(t, "copy", sc.ToJSON().Get("uid").MustString())
+			})
+		})
+
 		// This tests that invalid requests returns expected error responses
 		t.Run("Given incorrect requests for creating a dashboard", func(t *testing.T) {
 			testCases := []struct {
 				SaveError          error
 				ExpectedStatusCode int
+				ExpectedMessageID  string
 			}{
-				{SaveError: dashboards.ErrDashboardNotFound, ExpectedStatusCode: http.StatusNotFound},
-				{SaveError: dashboards.ErrFolderNotFound, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardWithSameUIDExists, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardWithSameNameInFolderExists, ExpectedStatusCode: http.StatusPreconditionFailed},
-				{SaveError: dashboards.ErrDashboardVersionMismatch, ExpectedStatusCode: http.StatusPreconditionFailed},
-				{SaveError: dashboards.ErrDashboardTitleEmpty, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardFolderCannotHaveParent, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: alerting.ValidationError{Reason: "Mu"}, ExpectedStatusCode: http.StatusUnprocessableEntity},
-				{SaveError: dashboards.ErrDashboardTypeMismatch, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardFolderWithSameNameAsDashboard, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardWithSameNameAsFolder, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardFolderNameExists, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardUpdateAccessDenied, ExpectedStatusCode: http.StatusForbidden},
-				{SaveError: dashboards.ErrDashboardInvalidUid, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardUidTooLong, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.ErrDashboardCannotSaveProvisionedDashboard, ExpectedStatusCode: http.StatusBadRequest},
-				{SaveError: dashboards.UpdatePluginDashboardError{PluginId: "plug"}, ExpectedStatusCode: http.StatusPreconditionFailed},
+				{SaveError: dashboards.ErrDashboardNotFound, ExpectedStatusCode: http.StatusNotFound, ExpectedMessageID: "dashboards.notFound"},
+				{SaveError: dashboards.ErrFolderNotFound, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.folderNotFound"},
+				{SaveError: dashboards.ErrDashboardWithSameUIDExists, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.uidExists"},
+				{SaveError: dashboards.ErrDashboardWithSameNameInFolderExists, ExpectedStatusCode: http.StatusPreconditionFailed, ExpectedMessageID: "dashboards.nameExistsInFolder"},
+				{SaveError: dashboards.ErrDashboardVersionMismatch, ExpectedStatusCode: http.StatusPreconditionFailed, ExpectedMessageID: "dashboards.versionMismatch"},
+				{SaveError: dashboards.ErrDashboardTitleEmpty, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.titleEmpty"},
+				{SaveError: dashboards.ErrDashboardFolderCannotHaveParent, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.folderCannotHaveParent"},
+				{SaveError: alerting.ValidationError{Reason: "Mu"}, ExpectedStatusCode: http.StatusUnprocessableEntity, ExpectedMessageID: "dashboards.alertValidationFailed"},
+				{SaveError: dashboards.ErrDashboardTypeMismatch, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.typeMismatch"},
+				{SaveError: dashboards.ErrDashboardFolderWithSameNameAsDashboard, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.folderNameMatchesDashboard"},
+				{SaveError: dashboards.ErrDashboardWithSameNameAsFolder, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.nameMatchesFolder"},
+				{SaveError: dashboards.ErrDashboardFolderNameExists, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.folderNameExists"},
+				{SaveError: dashboards.ErrDashboardUpdateAccessDenied, ExpectedStatusCode: http.StatusForbidden, ExpectedMessageID: "dashboards.saveAccessDenied"},
+				{SaveError: dashboards.ErrDashboardInvalidUid, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.invalidUid"},
+				{SaveError: dashboards.ErrDashboardUidTooLong, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.uidTooLong"},
+				{SaveError: dashboards.ErrDashboardCannotSaveProvisionedDashboard, ExpectedStatusCode: http.StatusBadRequest, ExpectedMessageID: "dashboards.provisionedCannotSave"},
+				{SaveError: dashboards.UpdatePluginDashboardError{PluginId: "plug"}, ExpectedStatusCode: http.StatusPreconditionFailed, ExpectedMessageID: "dashboards.pluginDashboard"},
+				{SaveError: errors.New("boom"), ExpectedStatusCode: http.StatusInternalServerError, ExpectedMessageID: "dashboards.saveFailed"},
 			}
 
 			cmd := dashboards.SaveDashboardCommand{
@@ -507,8 +1103,17 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 					"/api/dashboards", "/api/dashboards", cmd, dashboardService, nil, func(sc *scenarioContext) {
 						callPostDashboard(sc)
 						assert.Equal(t, tc.ExpectedStatusCode, sc.resp.Code, sc.resp.Body.String())
+						assert.Equal(t, tc.ExpectedMessageID, sc.ToJSON().Get("messageId").MustString())
 					})
 			}
+
+			t.Run("every error has a distinct message id", func(t *testing.T) {
+				seen := map[string]bool{}
+				for _, tc := range testCases {
+					assert.False(t, seen[tc.ExpectedMessageID], tc.ExpectedMessageID)
+					seen[tc.ExpectedMessageID] = true
+				}
+			})
 		})
 	})
 
@@ -544,6 +1149,78 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 				assert.Equal(t, http.StatusPreconditionFailed, sc.resp.Code)
 				assert.False(t, result.Get("isValid").MustBool())
 				assert.Equal(t, "invalid schema version", result.Get("message").MustString())
+				assert.Equal(t, "schemaVersion", result.GetPath("errors").GetIndex(0).Get("path").MustString())
+				assert.Equal(t, "invalidSchemaVersion", result.GetPath("errors").GetIndex(0).Get("code").MustString())
+			}, sqlmock)
+		})
+
+		t.Run("When a dashboard with a too-low schema version is posted with skipSchemaVersion", func(t *testing.T) {
+			cmd := dashboards.ValidateDashboardCommand{
+				Dashboard: `{"schemaVersion": 1, "title": "Old", "panels": [{"id": 1, "type": "not-installed"}]}`,
+			}
+
+			role := org.RoleAdmin
+			postValidateScenario(t, "When calling POST on", "/api/dashboards/validate", "/api/dashboards/validate", cmd, role, func(sc *scenarioContext) {
+				sc.fakeReqWithParams("POST", sc.url, map[string]string{"skipSchemaVersion": "true"}).exec()
+
+				result := sc.ToJSON()
+				assert.Equal(t, http.StatusUnprocessableEntity, sc.resp.Code)
+				assert.False(t, result.Get("isValid").MustBool())
+				require.Len(t, result.Get("errors").MustArray(), 1)
+				assert.Equal(t, "unknownPanelType", result.GetPath("errors").GetIndex(0).Get("code").MustString())
+				require.Len(t, result.Get("warnings").MustArray(), 1)
+				assert.Equal(t, "invalidSchemaVersion", result.GetPath("warnings").GetIndex(0).Get("code").MustString())
+			}, sqlmock)
+		})
+
+		t.Run("When a dashboard without a title and with an unknown panel type is posted", func(t *testing.T) {
+			cmd := dashboards.ValidateDashboardCommand{
+				Dashboard: `{"schemaVersion": 36, "title": "", "panels": [{"id": 1, "type": "dashlist"}, {"id": 2, "type": "row", "panels": [{"id": 3, "type": "not-installed"}]}]}`,
+			}
+
+			role := org.RoleAdmin
+			postValidateScenario(t, "When calling POST on", "/api/dashboards/validate", "/api/dashboards/validate", cmd, role, func(sc *scenarioContext) {
+				callPostDashboard(sc)
+
+				result := sc.ToJSON()
+				assert.Equal(t, http.StatusUnprocessableEntity, sc.resp.Code)
+				assert.False(t, result.Get("isValid").MustBool())
+				assert.Equal(t, "dashboard title cannot be empty", result.Get("message").MustString())
+
+				validationErrors := result.Get("errors")
+				require.Len(t, validationErrors.MustArray(), 2)
+				assert.Equal(t, "title", validationErrors.GetIndex(0).Get("path").MustString())
+				assert.Equal(t, "emptyTitle", validationErrors.GetIndex(0).Get("code").MustString())
+				assert.Equal(t, "panels[1].panels[0].type", validationErrors.GetIndex(1).Get("path").MustString())
+				assert.Equal(t, "unknownPanelType", validationErrors.GetIndex(1).Get("code").MustString())
+			}, sqlmock)
+		})
+
+		t.Run("When a dashboard using a transformation behind a disabled feature flag is posted", func(t *testing.T) {
+			cmd := dashboards.ValidateDashboardCommand{
+				Dashboard: `{"schemaVersion": 36, "title": "Flags", "panels": [{"id": 1, "type": "dashlist", "transformations": [{"id": "organize"}, {"id": "formatString"}]}]}`,
+			}
+
+			role := org.RoleAdmin
+			postValidateScenario(t, "When calling POST on", "/api/dashboards/validate", "/api/dashboards/validate", cmd, role, func(sc *scenarioContext) {
+				callPostDashboard(sc)
+
+				result := sc.ToJSON()
+				assert.Equal(t, http.StatusOK, sc.resp.Code)
+				assert.True(t, result.Get("isValid").MustBool())
+				require.Len(t, result.Get("warnings").MustArray(), 1)
+				assert.Equal(t, "panels[0].transformations[1].id", result.GetPath("warnings").GetIndex(0).Get("path").MustString())
+				assert.Equal(t, "featureFlagDisabled", result.GetPath("warnings").GetIndex(0).Get("code").MustString())
+			}, sqlmock)
+
+			postValidateScenario(t, "When calling POST with strictFeatureFlags on", "/api/dashboards/validate", "/api/dashboards/validate", cmd, role, func(sc *scenarioContext) {
+				sc.fakeReqWithParams("POST", sc.url, map[string]string{"strictFeatureFlags": "true"}).exec()
+
+				result := sc.ToJSON()
+				assert.Equal(t, http.StatusUnprocessableEntity, sc.resp.Code)
+				assert.False(t, result.Get("isValid").MustBool())
+				require.Len(t, result.Get("errors").MustArray(), 1)
+				assert.Equal(t, "featureFlagDisabled", result.GetPath("errors").GetIndex(0).Get("code").MustString())
 			}, sqlmock)
 		})
 
@@ -562,6 +1239,7 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 				result := sc.ToJSON()
 				assert.Equal(t, http.StatusOK, sc.resp.Code)
 				assert.True(t, result.Get("isValid").MustBool())
+				assert.Empty(t, result.Get("errors").MustArray())
 			}, sqlmock)
 		})
 	})
@@ -617,6 +1295,88 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 				assert.Equal(t, http.StatusOK, sc.resp.Code)
 			}, sqlmock, fakeDashboardVersionService)
 		})
+
+		t.Run("when diffing unsaved data against a stored version", func(t *testing.T) {
+			versionService := &dashvertest.FakeDashboardVersionService{ExpectedDashboardVersion: &dashver.DashboardVersionDTO{
+				DashboardID: 1,
+				Version:     1,
+				Data:        simplejson.NewFromAny(map[string]any{"title": "Dash1"}),
+			}}
+			cmd := dtos.CalculateDiffOptions{
+				Base:     dtos.CalculateDiffTarget{DashboardId: 1, Version: 1},
+				New:      dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "Unsaved dash"})},
+				DiffType: "delta",
+			}
+			postDiffScenario(t, "When calling POST on", "/api/dashboards/calculate-diff", "/api/dashboards/calculate-diff", cmd, org.RoleEditor, func(sc *scenarioContext) {
+				guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanSaveValue: true})
+				callPostDashboard(sc)
+				require.Equal(t, http.StatusOK, sc.resp.Code)
+
+				var delta map[string]any
+				require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&delta))
+				assert.Equal(t, []any{"Dash1", "Unsaved dash"}, delta["title"])
+			}, sqlmock, versionService)
+		})
+
+		t.Run("when diffing two unsaved dashboards", func(t *testing.T) {
+			cmd := dtos.CalculateDiffOptions{
+				Base:     dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "Base"})},
+				New:      dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "New"})},
+				DiffType: "basic",
+			}
+			postDiffScenario(t, "When calling POST on", "/api/dashboards/calculate-diff", "/api/dashboards/calculate-diff", cmd, org.RoleEditor, func(sc *scenarioContext) {
+				// no stored dashboard is read, so dashboard permissions don't apply
+				guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanSaveValue: false})
+				callPostDashboard(sc)
+				assert.Equal(t, http.StatusOK, sc.resp.Code)
+			}, sqlmock, dashvertest.NewDashboardVersionServiceFake())
+		})
+
+		t.Run("when paging a semantic diff", func(t *testing.T) {
+			cmd := dtos.CalculateDiffOptions{
+				Base: dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"panels": []any{}})},
+				New: dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"panels": []any{
+					map[string]any{"id": 1}, map[string]any{"id": 2}, map[string]any{"id": 3},
+				}})},
+				DiffType: "semantic",
+				Page:     1,
+				PageSize: 2,
+			}
+			postDiffScenario(t, "When calling POST on", "/api/dashboards/calculate-diff", "/api/dashboards/calculate-diff", cmd, org.RoleEditor, func(sc *scenarioContext) {
+				callPostDashboard(sc)
+				require.Equal(t, http.StatusOK, sc.resp.Code)
+				assert.Equal(t, "3", sc.resp.Header().Get("X-Total-Changes"))
+
+				var changes []dashdiffs.Change
+				require.NoError(t, json.NewDecoder(sc.resp.Body).Decode(&changes))
+				assert.Len(t, changes, 2)
+			}, sqlmock, dashvertest.NewDashboardVersionServiceFake())
+		})
+
+		t.Run("when no diff type is given", func(t *testing.T) {
+			cmd := dtos.CalculateDiffOptions{
+				Base: dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "Base"})},
+				New:  dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "New"})},
+			}
+			postDiffScenario(t, "When calling POST on", "/api/dashboards/calculate-diff", "/api/dashboards/calculate-diff", cmd, org.RoleEditor, func(sc *scenarioContext) {
+				callPostDashboard(sc)
+				require.Equal(t, http.StatusOK, sc.resp.Code)
+				// the scenario configures semantic as the default diff type
+				assert.Equal(t, "1", sc.resp.Header().Get("X-Total-Changes"))
+			}, sqlmock, dashvertest.NewDashboardVersionServiceFake())
+		})
+
+		t.Run("when the diff type is unknown", func(t *testing.T) {
+			cmd := dtos.CalculateDiffOptions{
+				Base:     dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "Base"})},
+				New:      dtos.CalculateDiffTarget{Data: simplejson.NewFromAny(map[string]any{"title": "New"})},
+				DiffType: "unified",
+			}
+			postDiffScenario(t, "When calling POST on", "/api/dashboards/calculate-diff", "/api/dashboards/calculate-diff", cmd, org.RoleEditor, func(sc *scenarioContext) {
+				callPostDashboard(sc)
+				assert.Equal(t, http.StatusBadRequest, sc.resp.Code)
+			}, sqlmock, dashvertest.NewDashboardVersionServiceFake())
+		})
 	})
 
 	t.Run("Given dashboard in folder being restored should restore to folder", func(t *testing.T) {
@@ -695,6 +1455,127 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 			}, mockSQLStore)
 	})
 
+	t.Run("Given a dashboard being restored by version tag", func(t *testing.T) {
+		fakeDash := dashboards.NewDashboard("Child dash")
+		fakeDash.ID = 2
+		fakeDash.HasACL = false
+
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*dashboards.GetDashboardQuery")).Return(fakeDash, nil)
+		dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).Run(func(args mock.Arguments) {
+			cmd := args.Get(1).(*dashboards.SaveDashboardDTO)
+			cmd.Dashboard = &dashboards.Dashboard{
+				ID: 2, UID: "uid", Title: "Dash", Slug: "dash", Version: 3,
+			}
+		}).Return(nil, nil).Maybe()
+
+		fakeDashboardVersionService := dashvertest.NewDashboardVersionServiceFake()
+		fakeDashboardVersionService.ExpectedDashboardVersion = &dashver.DashboardVersionDTO{
+			DashboardID: 2,
+			Version:     1,
+			VersionTag:  "pre-migration",
+			Data:        fakeDash.Data,
+		}
+
+		mockSQLStore := dbtest.NewFakeDB()
+		restoreDashboardVersionScenario(t, "When calling POST on", "/api/dashboards/id/2/restore",
+			"/api/dashboards/id/:dashboardId/restore", dashboardService, fakeDashboardVersionService, dtos.RestoreDashboardVersionCommand{VersionTag: "pre-migration"}, func(sc *scenarioContext) {
+				callRestoreDashboardVersion(sc)
+				assert.Equal(t, http.StatusOK, sc.resp.Code)
+			}, mockSQLStore)
+
+		restoreDashboardVersionScenario(t, "When calling POST with both a version and a tag on", "/api/dashboards/id/2/restore",
+			"/api/dashboards/id/:dashboardId/restore", dashboardService, fakeDashboardVersionService, dtos.RestoreDashboardVersionCommand{Version: 1, VersionTag: "pre-migration"}, func(sc *scenarioContext) {
+				callRestoreDashboardVersion(sc)
+				assert.Equal(t, http.StatusBadRequest, sc.resp.Code)
+			}, mockSQLStore)
+	})
+
+	t.Run("Given a dashboard being restored to the newest version before a timestamp", func(t *testing.T) {
+		fakeDash := dashboards.NewDashboard("Child dash")
+		fakeDash.ID = 2
+		fakeDash.HasACL = false
+
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*dashboards.GetDashboardQuery")).Return(fakeDash, nil)
+		dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).Run(func(args mock.Arguments) {
+			cmd := args.Get(1).(*dashboards.SaveDashboardDTO)
+			cmd.Dashboard = &dashboards.Dashboard{
+				ID: 2, UID: "uid", Title: "Dash", Slug: "dash", Version: 3,
+			}
+		}).Return(nil, nil).Maybe()
+
+		before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockSQLStore := dbtest.NewFakeDB()
+
+		fakeDashboardVersionService := dashvertest.NewDashboardVersionServiceFake()
+		fakeDashboardVersionService.ExpectedDashboardVersion = &dashver.DashboardVersionDTO{
+			DashboardID: 2,
+			Version:     1,
+			Data:        fakeDash.Data,
+		}
+		restoreDashboardVersionScenario(t, "When calling POST on", "/api/dashboards/id/2/restore",
+			"/api/dashboards/id/:dashboardId/restore", dashboardService, fakeDashboardVersionService, dtos.RestoreDashboardVersionCommand{BeforeTimestamp: before}, func(sc *scenarioContext) {
+				callRestoreDashboardVersion(sc)
+				assert.Equal(t, http.StatusOK, sc.resp.Code)
+			}, mockSQLStore)
+
+		missingVersionService := dashvertest.NewDashboardVersionServiceFake()
+		missingVersionService.ExpectedError = dashver.ErrDashboardVersionNotFound
+		restoreDashboardVersionScenario(t, "When calling POST without an older version on", "/api/dashboards/id/2/restore",
+			"/api/dashboards/id/:dashboardId/restore", dashboardService, missingVersionService, dtos.RestoreDashboardVersionCommand{BeforeTimestamp: before}, func(sc *scenarioContext) {
+				callRestoreDashboardVersion(sc)
+				assert.Equal(t, http.StatusNotFound, sc.resp.Code)
+				assert.Contains(t, sc.resp.Body.String(), "No dashboard version created at or before 2024-01-01T00:00:00Z")
+			}, mockSQLStore)
+
+		restoreDashboardVersionScenario(t, "When calling POST with both a version and a timestamp on", "/api/dashboards/id/2/restore",
+			"/api/dashboards/id/:dashboardId/restore", dashboardService, fakeDashboardVersionService, dtos.RestoreDashboardVersionCommand{Version: 1, BeforeTimestamp: before}, func(sc *scenarioContext) {
+				callRestoreDashboardVersion(sc)
+				assert.Equal(t, http.StatusBadRequest, sc.resp.Code)
+			}, mockSQLStore)
+	})
+
+	t.Run("Given a dashboard being restored while preserving the current version", func(t *testing.T) {
+		fakeDash := dashboards.NewDashboard("Current")
+		fakeDash.ID = 2
+		fakeDash.UID = "uid"
+		fakeDash.Version = 3
+
+		var saved []*dashboards.SaveDashboardDTO
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*dashboards.GetDashboardQuery")).Return(fakeDash, nil)
+		dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), mock.AnythingOfType("bool")).Return(
+			func(_ context.Context, dto *dashboards.SaveDashboardDTO, _ bool) *dashboards.Dashboard {
+				saved = append(saved, dto)
+				return &dashboards.Dashboard{ID: 2, UID: "uid", Title: dto.Dashboard.Title, Version: dto.Dashboard.Version + 1, Data: dto.Dashboard.Data}
+			}, nil)
+
+		fakeDashboardVersionService := dashvertest.NewDashboardVersionServiceFake()
+		fakeDashboardVersionService.ExpectedDashboardVersion = &dashver.DashboardVersionDTO{
+			DashboardID: 2,
+			Version:     1,
+			Data:        simplejson.NewFromAny(map[string]any{"id": 2, "title": "Old"}),
+		}
+		restoreDashboardVersionScenario(t, "When calling POST on", "/api/dashboards/id/2/restore",
+			"/api/dashboards/id/:dashboardId/restore", dashboardService, fakeDashboardVersionService, dtos.RestoreDashboardVersionCommand{Version: 1, PreserveCurrent: true}, func(sc *scenarioContext) {
+				callRestoreDashboardVersion(sc)
+				require.Equal(t, http.StatusOK, sc.resp.Code)
+
+				require.Len(t, saved, 2)
+				assert.Equal(t, "Current", saved[0].Dashboard.Title)
+				assert.Equal(t, 3, saved[0].Dashboard.Version)
+				assert.Equal(t, "Snapshot before restoring v1", saved[0].Message)
+				assert.Equal(t, "Old", saved[1].Dashboard.Title)
+				assert.Equal(t, 4, saved[1].Dashboard.Version)
+				assert.Equal(t, "Restored from version 1", saved[1].Message)
+
+				result := sc.ToJSON()
+				assert.Equal(t, 5, result.Get("version").MustInt())
+				assert.Equal(t, 4, result.Get("snapshotVersion").MustInt())
+			}, dbtest.NewFakeDB())
+	})
+
 	t.Run("Given provisioned dashboard", func(t *testing.T) {
 		mockSQLStore := dbtest.NewFakeDB()
 		dashboardStore := dashboards.NewFakeDashboardStore(t)
@@ -755,6 +1636,182 @@ func TestDashboardAPIEndpoint(t *testing.T) {
 	})
 }
 
+func TestValidateDashboardDataSources(t *testing.T) {
+	hs := &HTTPServer{DataSourcesService: &fakeDatasources.FakeDataSourceService{DataSources: []*datasources.DataSource{
+		{UID: "prom", Name: "Prometheus", Type: "prometheus", OrgID: 1},
+	}}}
+	data := simplejson.NewFromAny(map[string]any{
+		"panels": []any{
+			map[string]any{"datasource": map[string]any{"uid": "prom", "type": "prometheus"}, "targets": []any{
+				map[string]any{"datasource": map[string]any{"uid": "missing", "type": "loki"}},
+				map[string]any{"datasource": map[string]any{"uid": "prom", "type": "loki"}},
+				map[string]any{"datasource": map[string]any{"uid": "__expr__"}},
+			}},
+			map[string]any{"type": "row", "panels": []any{
+				map[string]any{"datasource": "Prometheus"},
+				map[string]any{"datasource": "Graphite"},
+				map[string]any{"datasource": map[string]any{"uid": "${ds}"}},
+				map[string]any{"datasource": nil},
+			}},
+		},
+	})
+
+	validationErrors, err := hs.validateDashboardDataSources(context.Background(), 1, data)
+	require.NoError(t, err)
+	require.Len(t, validationErrors, 3)
+	assert.Equal(t, DashboardValidationError{Path: "panels[0].targets[0].datasource", Code: "unknownDataSource", Message: `data source "missing" not found`}, validationErrors[0])
+	assert.Equal(t, "panels[0].targets[1].datasource", validationErrors[1].Path)
+	assert.Equal(t, "panels[1].panels[1].datasource", validationErrors[2].Path)
+}
+
+func TestHTTPServer_CalculateDashboardOriginDiff(t *testing.T) {
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboardFromJson(simplejson.NewFromAny(map[string]any{"title": "Renamed dash", "version": 3}))
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{
+			ExpectedDashboardVersion: &dashver.DashboardVersionDTO{
+				Version: 1,
+				Data:    simplejson.NewFromAny(map[string]any{"title": "Some dash", "version": 1}),
+			},
+		}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	getDiff := func(permissions []accesscontrol.Permission) *http.Response {
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/1/diff-origin?diffType=delta"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("Should diff the current version against the first one", func(t *testing.T) {
+		res := getDiff([]accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"}})
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+		var delta map[string]any
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&delta))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, []any{"Some dash", "Renamed dash"}, delta["title"])
+	})
+
+	t.Run("Should not diff without read permission", func(t *testing.T) {
+		res := getDiff([]accesscontrol.Permission{})
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
+func TestHTTPServer_CalculateDashboardVersionsDiff(t *testing.T) {
+	var versionSvc *dashvertest.FakeDashboardVersionService
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("Renamed dash")
+		dash.ID = 1
+		dash.UID = "1"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.Cfg = setting.NewCfg()
+		hs.Cfg.DashboardDefaultDiffType = "delta"
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		versionSvc = &dashvertest.FakeDashboardVersionService{}
+		hs.dashboardVersionService = versionSvc
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	getDiff := func(url string, permissions []accesscontrol.Permission) *http.Response {
+		*versionSvc = dashvertest.FakeDashboardVersionService{ExpectedDashboardVersions: []*dashver.DashboardVersionDTO{
+			{Version: 1, Data: simplejson.NewFromAny(map[string]any{"title": "Some dash", "version": 1})},
+			{Version: 2, Data: simplejson.NewFromAny(map[string]any{"title": "Renamed dash", "version": 2})},
+		}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+	canSave := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:1"},
+	}
+
+	t.Run("Should diff the two versions", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff/2?type=delta", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+		var delta map[string]any
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&delta))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, []any{"Some dash", "Renamed dash"}, delta["title"])
+	})
+
+	t.Run("Should accept diffType as an alias of type", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff/2?diffType=delta", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should use the configured default diff type", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff/2", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should reject unknown diff types", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff/2?type=bogus", canSave)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should diff a version against the current dashboard", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff-current?diffType=delta", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var delta map[string]any
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&delta))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, []any{"Some dash", "Renamed dash"}, delta["title"])
+	})
+
+	t.Run("Should reject unknown diff types to diff against the current dashboard", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff-current?diffType=bogus", canSave)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should reject an invalid version to diff against the current dashboard", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/first/diff-current", canSave)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should reject invalid versions", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/first/diff/2", canSave)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Should not diff without save permission", func(t *testing.T) {
+		res := getDiff("/api/dashboards/uid/1/versions/1/diff/2", []accesscontrol.Permission{{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:1"}})
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
 func TestDashboardVersionsAPIEndpoint(t *testing.T) {
 	fakeDash := dashboards.NewDashboard("Child dash")
 	fakeDash.ID = 1
@@ -797,10 +1854,12 @@ func TestDashboardVersionsAPIEndpoint(t *testing.T) {
 				{
 					Version:   1,
 					CreatedBy: 1,
+					Source:    dashver.SourceProvisioning,
 				},
 				{
 					Version:   2,
 					CreatedBy: 1,
+					Source:    dashver.SourceUI,
 				},
 			}
 			getHS(&usertest.FakeUserService{
@@ -814,6 +1873,57 @@ func TestDashboardVersionsAPIEndpoint(t *testing.T) {
 			for _, v := range versions {
 				assert.Equal(t, "test-user", v.CreatedBy)
 			}
+			require.Len(t, versions, 2)
+			assert.Equal(t, dashver.SourceProvisioning, versions[0].Source)
+			assert.Equal(t, dashver.SourceUI, versions[1].Source)
+		}, mockSQLStore)
+
+	loggedInUserScenarioWithRole(t, "When versions have different authors and calling GET on", "GET", "/api/dashboards/id/2/versions",
+		"/api/dashboards/id/:dashboardId/versions", org.RoleEditor, func(sc *scenarioContext) {
+			setUp()
+			fakeDashboardVersionService.ExpectedListDashboarVersions = []*dashver.DashboardVersionDTO{
+				{Version: 1, CreatedBy: 1},
+				{Version: 2, CreatedBy: 2},
+				{Version: 3, CreatedBy: 1},
+				{Version: 4, CreatedBy: 3},
+			}
+			userSvc := &usertest.FakeUserService{
+				ExpectedUsers: []*user.User{{ID: 1, Login: "first"}, {ID: 2, Login: "second"}},
+			}
+			getHS(userSvc).callGetDashboardVersions(sc)
+
+			assert.Equal(t, http.StatusOK, sc.resp.Code)
+			var versions []dashver.DashboardVersionMeta
+			err := json.NewDecoder(sc.resp.Body).Decode(&versions)
+			require.NoError(t, err)
+			creators := make([]string, 0, len(versions))
+			for _, v := range versions {
+				creators = append(creators, v.CreatedBy)
+			}
+			assert.Equal(t, []string{"first", "second", "first", anonString}, creators)
+		}, mockSQLStore)
+
+	loggedInUserScenarioWithRole(t, "When filtering versions and calling GET on", "GET", "/api/dashboards/id/2/versions?limit=1&createdBy=test-user&from=1600000000000",
+		"/api/dashboards/id/:dashboardId/versions", org.RoleEditor, func(sc *scenarioContext) {
+			setUp()
+			fakeDashboardVersionService.ExpectedCount = 2
+			fakeDashboardVersionService.ExpectedListDashboarVersions = []*dashver.DashboardVersionDTO{
+				{
+					Version:   2,
+					CreatedBy: 1,
+				},
+			}
+			getHS(&usertest.FakeUserService{
+				ExpectedUser: &user.User{ID: 1, Login: "test-user"},
+			}).callGetDashboardVersions(sc)
+
+			assert.Equal(t, http.StatusOK, sc.resp.Code)
+			assert.Equal(t, "2", sc.resp.Header().Get("X-Total-Count"))
+			var versions []dashver.DashboardVersionMeta
+			err := json.NewDecoder(sc.resp.Body).Decode(&versions)
+			require.NoError(t, err)
+			require.Len(t, versions, 1)
+			assert.Equal(t, "test-user", versions[0].CreatedBy)
 		}, mockSQLStore)
 
 	loggedInUserScenarioWithRole(t, "When user does not exist and calling GET on", "GET", "/api/dashboards/id/2/versions",
@@ -1007,6 +2117,9 @@ func postValidateScenario(t *testing.T, desc string, url string, routePattern st
 			SQLStore:              sqlmock,
 			Features:              featuremgmt.WithFeatures(),
 			Kinds:                 corekind.NewBase(nil),
+			pluginStore: &pluginstore.FakePluginStore{
+				PluginList: []pluginstore.Plugin{{JSONData: plugins.JSONData{ID: "dashlist", Type: plugins.TypePanel}}},
+			},
 		}
 
 		sc := setupScenarioContext(t, url)
@@ -1033,6 +2146,7 @@ func postDiffScenario(t *testing.T, desc string, url string, routePattern string
 	role org.RoleType, fn scenarioFunc, sqlmock db.DB, fakeDashboardVersionService *dashvertest.FakeDashboardVersionService) {
 	t.Run(fmt.Sprintf("%s %s", desc, url), func(t *testing.T) {
 		cfg := setting.NewCfg()
+		cfg.DashboardDefaultDiffType = "semantic"
 
 		dashSvc := dashboards.NewFakeDashboardService(t)
 		hs := HTTPServer{
@@ -1091,6 +2205,8 @@ func restoreDashboardVersionScenario(t *testing.T, desc string, url string, rout
 			Kinds:                   corekind.NewBase(nil),
 			accesscontrolService:    actest.FakeService{},
 			folderService:           folderSvc,
+
+			dashboardProvisioningService: mockDashboardProvisioningService{},
 		}
 
 		sc := setupScenarioContext(t, url)
@@ -1131,6 +2247,11 @@ func (s mockDashboardProvisioningService) GetProvisionedDashboardDataByDashboard
 	return nil, nil
 }
 
+func (s mockDashboardProvisioningService) GetProvisionedDashboardDataByDashboardUID(ctx context.Context, orgID int64, dashboardUID string) (
+	*dashboards.DashboardProvisioning, error) {
+	return nil, nil
+}
+
 type mockLibraryPanelService struct {
 }
 
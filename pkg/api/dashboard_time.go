@@ -0,0 +1,145 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+var (
+	// relativeTimeExpr matches range expressions anchored on now, e.g. now-6h or now-1d/d.
+	relativeTimeExpr = regexp.MustCompile(`^now(([+-]\d+|/)(ms|s|m|h|d|w|M|y))*$`)
+	// relativeDurationExpr matches the durations used by panel time overrides, e.g. 24h or 1d/d.
+	relativeDurationExpr = regexp.MustCompile(`^\d+(ms|s|m|h|d|w|M|y)(/(s|m|h|d|w|M|y))?$`)
+)
+
+// panelTimeOverrideFields are the panel options overriding the dashboard time range.
+var panelTimeOverrideFields = []string{"timeFrom", "timeShift"}
+
+// swagger:route GET /dashboards/uid/{uid}/time-analysis dashboards getDashboardTimeAnalysis
+//
+// Analyze the time ranges of a dashboard.
+//
+// Reports whether the dashboard time range and the panel time overrides are relative to the current time,
+// and lists the panels that use hardcoded absolute times.
+//
+// Responses:
+// 200: dashboardTimeAnalysisResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardTimeAnalysis(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canView, err := guardian.CanView(); err != nil || !canView {
+		return dashboardGuardianResponse(err)
+	}
+
+	analysis := analyzeDashboardTime(dash.Data)
+	analysis.UID = dash.UID
+	analysis.Title = dash.Title
+	return response.JSON(http.StatusOK, analysis)
+}
+
+// analyzeDashboardTime inspects the dashboard time range and the time
+// overrides of every panel for absolute times.
+func analyzeDashboardTime(data *simplejson.Json) dtos.DashboardTimeAnalysis {
+	analysis := dtos.DashboardTimeAnalysis{
+		From:           timeValueString(data.GetPath("time", "from")),
+		To:             timeValueString(data.GetPath("time", "to")),
+		AbsolutePanels: []dtos.AbsoluteTimePanel{},
+	}
+
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		for _, field := range panelTimeOverrideFields {
+			value := timeValueString(panel.Get(field))
+			if isRelativeTimeOverride(value) {
+				continue
+			}
+			analysis.AbsolutePanels = append(analysis.AbsolutePanels, dtos.AbsoluteTimePanel{
+				ID:    panel.Get("id").MustInt64(),
+				Title: panel.Get("title").MustString(),
+				Field: field,
+				Value: value,
+			})
+		}
+	})
+
+	analysis.Relative = isRelativeTime(analysis.From) && isRelativeTime(analysis.To) && len(analysis.AbsolutePanels) == 0
+	return analysis
+}
+
+// isRelativeTime reports whether a dashboard time range boundary is relative
+// to the current time. Unset boundaries fall back to the relative default.
+func isRelativeTime(value string) bool {
+	return value == "" || relativeTimeExpr.MatchString(value)
+}
+
+// isRelativeTimeOverride reports whether a panel time override is relative to
+// the dashboard time range or to the current time.
+func isRelativeTimeOverride(value string) bool {
+	return value == "" || relativeDurationExpr.MatchString(value) || relativeTimeExpr.MatchString(value)
+}
+
+// relativizeDashboardTime replaces an absolute dashboard time range with the
+// given relative range and drops absolute panel time overrides, so that the
+// panels follow the dashboard range. It reports whether the body was changed.
+func relativizeDashboardTime(data *simplejson.Json, from, to string) bool {
+	changed := false
+	if !isRelativeTime(timeValueString(data.GetPath("time", "from"))) || !isRelativeTime(timeValueString(data.GetPath("time", "to"))) {
+		data.SetPath([]string{"time", "from"}, from)
+		data.SetPath([]string{"time", "to"}, to)
+		changed = true
+	}
+
+	forEachDashboardPanel(data, func(panel *simplejson.Json) {
+		for _, field := range panelTimeOverrideFields {
+			if !isRelativeTimeOverride(timeValueString(panel.Get(field))) {
+				panel.Del(field)
+				changed = true
+			}
+		}
+	})
+
+	return changed
+}
+
+// timeValueString returns a time value, which is either a string or an epoch
+// in milliseconds, as a string.
+func timeValueString(value *simplejson.Json) string {
+	if value.Interface() == nil {
+		return ""
+	}
+	if s, err := value.String(); err == nil {
+		return s
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// swagger:parameters getDashboardTimeAnalysis
+type GetDashboardTimeAnalysisParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardTimeAnalysisResponse
+type DashboardTimeAnalysisResponse struct {
+	// in: body
+	Body dtos.DashboardTimeAnalysis `json:"body"`
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestAnalyzeDashboardTime(t *testing.T) {
+	t.Run("relative dashboard", func(t *testing.T) {
+		data, err := simplejson.NewJson([]byte(`{
+			"time": {"from": "now-1d/d", "to": "now"},
+			"panels": [{"id": 1, "timeFrom": "24h", "timeShift": "1d/d"}, {"id": 2, "timeFrom": "now/d"}]
+		}`))
+		require.NoError(t, err)
+
+		analysis := analyzeDashboardTime(data)
+		assert.True(t, analysis.Relative)
+		assert.Empty(t, analysis.AbsolutePanels)
+	})
+
+	t.Run("absolute dashboard range and panel overrides", func(t *testing.T) {
+		data, err := simplejson.NewJson([]byte(`{
+			"time": {"from": "2023-01-01T00:00:00.000Z", "to": 1672617600000},
+			"panels": [
+				{"id": 1, "title": "ok", "timeFrom": "1h"},
+				{"id": 2, "type": "row", "panels": [{"id": 3, "title": "pinned", "timeFrom": "2023-01-01"}]}
+			]
+		}`))
+		require.NoError(t, err)
+
+		analysis := analyzeDashboardTime(data)
+		assert.False(t, analysis.Relative)
+		assert.Equal(t, "2023-01-01T00:00:00.000Z", analysis.From)
+		assert.Equal(t, "1672617600000", analysis.To)
+		assert.Equal(t, []dtos.AbsoluteTimePanel{{ID: 3, Title: "pinned", Field: "timeFrom", Value: "2023-01-01"}}, analysis.AbsolutePanels)
+	})
+}
+
+func TestRelativizeDashboardTime(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"time": {"from": "2023-01-01T00:00:00.000Z", "to": "now"},
+		"panels": [{"id": 1, "timeFrom": "1h"}, {"id": 2, "timeFrom": "2023-01-01", "timeShift": "1d"}]
+	}`))
+	require.NoError(t, err)
+
+	require.True(t, relativizeDashboardTime(data, "now-7d", "now"))
+	assert.Equal(t, "now-7d", data.GetPath("time", "from").MustString())
+	assert.Equal(t, "now", data.GetPath("time", "to").MustString())
+
+	panels := data.Get("panels")
+	assert.Equal(t, "1h", panels.GetIndex(0).Get("timeFrom").MustString())
+	_, hasTimeFrom := panels.GetIndex(1).CheckGet("timeFrom")
+	assert.False(t, hasTimeFrom)
+	assert.Equal(t, "1d", panels.GetIndex(1).Get("timeShift").MustString())
+
+	assert.True(t, analyzeDashboardTime(data).Relative)
+	assert.False(t, relativizeDashboardTime(data, "now-7d", "now"))
+}
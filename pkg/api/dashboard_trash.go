@@ -0,0 +1,189 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardtrash"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/util"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /dashboards/trash dashboards getDashboardTrash
+//
+// List deleted dashboards.
+//
+// Returns the deleted dashboards that can still be restored, most recently deleted first. Only dashboards
+// the signed in user may delete are listed.
+//
+// Responses:
+// 200: dashboardTrashResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardTrash(c *contextmodel.ReqContext) response.Response {
+	entries, err := hs.trashStore.List(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get deleted dashboards", err)
+	}
+
+	allowed := make([]*dashboardtrash.Entry, 0, len(entries))
+	deletedBy := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		canDelete, err := hs.canDeleteTrashedDashboard(c, entry)
+		if err != nil {
+			return dashboardGuardianResponse(err)
+		}
+		if canDelete {
+			allowed = append(allowed, entry)
+			deletedBy = append(deletedBy, entry.DeletedBy)
+		}
+	}
+
+	logins := hs.getUserLogins(c.Req.Context(), deletedBy)
+	result := make([]dtos.TrashedDashboard, 0, len(allowed))
+	for _, entry := range allowed {
+		login, ok := logins[entry.DeletedBy]
+		if !ok {
+			login = anonString
+		}
+		result = append(result, dtos.TrashedDashboard{
+			UID:       entry.UID,
+			Title:     entry.Title,
+			FolderUID: entry.FolderUID,
+			Deleted:   entry.Deleted,
+			DeletedBy: login,
+			Expires:   hs.trashStore.Expires(entry),
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route POST /dashboards/trash/{uid}/restore dashboards restoreDeletedDashboard
+//
+// Restore a deleted dashboard.
+//
+// Saves the deleted dashboard again with its former uid in its former folder and removes it from the
+// trash. The signed in user needs to be allowed to delete the dashboard and to create dashboards in the
+// folder.
+//
+// Responses:
+// 200: postDashboardResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 412: preconditionFailedError
+// 500: internalServerError
+func (hs *HTTPServer) RestoreDeletedDashboard(c *contextmodel.ReqContext) response.Response {
+	entry, rsp := hs.getTrashedDashboard(c)
+	if rsp != nil {
+		return rsp
+	}
+
+	data, err := cloneDashboardJSON(entry.Data)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to copy dashboard", err)
+	}
+	data.Set("id", nil)
+	data.Set("uid", entry.UID)
+	data.Del("version")
+
+	rsp = hs.postDashboard(c, dashboards.SaveDashboardCommand{
+		Dashboard: data,
+		FolderUID: entry.FolderUID,
+		Message:   "Restored from trash",
+	})
+	if rsp.Status() != http.StatusOK {
+		return rsp
+	}
+
+	if err := hs.trashStore.Delete(c.Req.Context(), c.SignedInUser.GetOrgID(), entry.UID); err != nil {
+		hs.log.Warn("Failed to remove restored dashboard from trash", "dashboard", entry.UID, "error", err)
+	}
+	return rsp
+}
+
+// swagger:route DELETE /dashboards/trash/{uid} dashboards deleteTrashedDashboard
+//
+// Permanently delete a deleted dashboard.
+//
+// Removes the dashboard from the trash so that it can no longer be restored.
+//
+// Responses:
+// 200: deleteDashboardResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) DeleteTrashedDashboard(c *contextmodel.ReqContext) response.Response {
+	entry, rsp := hs.getTrashedDashboard(c)
+	if rsp != nil {
+		return rsp
+	}
+
+	if err := hs.trashStore.Delete(c.Req.Context(), c.SignedInUser.GetOrgID(), entry.UID); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to delete dashboard from trash", err)
+	}
+	return response.JSON(http.StatusOK, util.DynMap{
+		"title":   entry.Title,
+		"message": fmt.Sprintf("Dashboard %s permanently deleted", entry.Title),
+	})
+}
+
+// getTrashedDashboard returns the trashed dashboard of the uid route
+// parameter, provided the signed in user may delete it.
+func (hs *HTTPServer) getTrashedDashboard(c *contextmodel.ReqContext) (*dashboardtrash.Entry, response.Response) {
+	entry, ok, err := hs.trashStore.Get(c.Req.Context(), c.SignedInUser.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Failed to get deleted dashboard", err)
+	}
+	if !ok {
+		return nil, response.Error(http.StatusNotFound, "Deleted dashboard not found", nil)
+	}
+
+	if canDelete, err := hs.canDeleteTrashedDashboard(c, entry); err != nil || !canDelete {
+		return nil, dashboardGuardianResponse(err)
+	}
+	return entry, nil
+}
+
+// canDeleteTrashedDashboard checks the delete permission of the signed in
+// user on the dashboard as it was before it was deleted.
+func (hs *HTTPServer) canDeleteTrashedDashboard(c *contextmodel.ReqContext, entry *dashboardtrash.Entry) (bool, error) {
+	dash := &dashboards.Dashboard{
+		UID:       entry.UID,
+		Title:     entry.Title,
+		FolderUID: entry.FolderUID,
+		OrgID:     c.SignedInUser.GetOrgID(),
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return false, err
+	}
+	return guardian.CanDelete()
+}
+
+// swagger:parameters restoreDeletedDashboard
+type RestoreDeletedDashboardParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:parameters deleteTrashedDashboard
+type DeleteTrashedDashboardParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardTrashResponse
+type DashboardTrashResponse struct {
+	// in: body
+	Body []dtos.TrashedDashboard `json:"body"`
+}
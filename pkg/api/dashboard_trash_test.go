@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardtrash"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/api"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestDashboardTrash(t *testing.T) {
+	newDash := func(uid string, id int64) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = id
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.FolderUID = "folder"
+		dash.Data = simplejson.NewFromAny(map[string]any{"id": id, "uid": uid, "title": uid, "version": 4})
+		return dash
+	}
+
+	var dashSvc *dashboards.FakeDashboardService
+	var saved []*dashboards.SaveDashboardDTO
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc = dashboards.NewFakeDashboardService(t)
+		for _, dash := range []*dashboards.Dashboard{newDash("a", 1), newDash("b", 2)} {
+			dash := dash
+			dashSvc.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool { return q.UID == dash.UID })).Return(dash, nil).Maybe()
+		}
+		dashSvc.On("DeleteDashboard", mock.Anything, mock.Anything, int64(1)).Return(nil)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO))
+		}).Return(&dashboards.Dashboard{ID: 3, UID: "a", Title: "a", FolderUID: "folder", Version: 1}, nil)
+		hs.DashboardService = dashSvc
+		hs.trashStore = dashboardtrash.NewStore(kvstore.NewFakeKVStore(), time.Hour)
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.userService = &usertest.FakeUserService{ExpectedUsers: []*user.User{{ID: 1, Login: "admin"}}}
+
+		pubDashService := publicdashboards.NewFakePublicDashboardService(t)
+		pubDashService.On("DeleteByDashboard", mock.Anything, mock.Anything).Return(nil)
+		hs.PublicDashboardsApi = api.ProvideApi(pubDashService, nil, hs.AccessControl, featuremgmt.WithFeatures())
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	admin := userWithPermissions(1, []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+		{Action: dashboards.ActionDashboardsDelete, Scope: dashboards.ScopeDashboardsAll},
+		{Action: dashboards.ActionDashboardsCreate, Scope: dashboards.ScopeFoldersAll},
+		{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+	})
+	admin.UserID, admin.IsAnonymous = 1, false
+	restricted := userWithPermissions(1, []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsDelete, Scope: "dashboards:uid:b"},
+	})
+	send := func(req *http.Request, usr *user.SignedInUser) *http.Response {
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, usr))
+		require.NoError(t, err)
+		return res
+	}
+	list := func(usr *user.SignedInUser) []dtos.TrashedDashboard {
+		res := send(server.NewGetRequest("/api/dashboards/trash"), usr)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var trash []dtos.TrashedDashboard
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&trash))
+		require.NoError(t, res.Body.Close())
+		return trash
+	}
+
+	for _, uid := range []string{"a", "b"} {
+		res := send(server.NewRequest(http.MethodDelete, "/api/dashboards/uid/"+uid, nil), admin)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	t.Run("should list deleted dashboards the user may delete", func(t *testing.T) {
+		trash := list(admin)
+		require.Len(t, trash, 2)
+		assert.Equal(t, "admin", trash[0].DeletedBy)
+		assert.Equal(t, "folder", trash[0].FolderUID)
+		assert.Equal(t, trash[0].Deleted.Add(time.Hour), trash[0].Expires)
+
+		trash = list(restricted)
+		require.Len(t, trash, 1)
+		assert.Equal(t, "b", trash[0].UID)
+	})
+
+	t.Run("should not permanently delete without delete permission", func(t *testing.T) {
+		res := send(server.NewRequest(http.MethodDelete, "/api/dashboards/trash/a", nil), restricted)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should restore a dashboard with its uid and folder", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboards/trash/a/restore", nil)
+		res := send(req, admin)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, saved, 1)
+		assert.Equal(t, int64(0), saved[0].Dashboard.ID)
+		assert.Equal(t, "a", saved[0].Dashboard.UID)
+		assert.Equal(t, "folder", saved[0].Dashboard.FolderUID)
+		assert.False(t, saved[0].Overwrite)
+
+		trash := list(admin)
+		require.Len(t, trash, 1)
+		assert.Equal(t, "b", trash[0].UID)
+	})
+
+	t.Run("should permanently delete a dashboard", func(t *testing.T) {
+		res := send(server.NewRequest(http.MethodDelete, "/api/dashboards/trash/b", nil), restricted)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		assert.Empty(t, list(admin))
+
+		res = send(server.NewRequest(http.MethodDelete, "/api/dashboards/trash/b", nil), admin)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// unchangedDashboardResponse returns the response to saving a dashboard that
+// is stored with the same content, in the same folder and at the version the
+// command is based on, so that saving it would only add an identical version.
+// It returns nil when the dashboard has to be saved.
+func (hs *HTTPServer) unchangedDashboardResponse(c *contextmodel.ReqContext, cmd dashboards.SaveDashboardCommand) response.Response {
+	if cmd.Dashboard == nil || cmd.SaveAsCopy {
+		return nil
+	}
+	uid := cmd.Dashboard.Get("uid").MustString()
+	if uid == "" {
+		return nil
+	}
+
+	// errors are left to the save, which reports them the usual way
+	existing, err := hs.DashboardService.GetDashboard(c.Req.Context(), &dashboards.GetDashboardQuery{UID: uid, OrgID: c.SignedInUser.GetOrgID()})
+	if err != nil || existing.IsFolder || existing.FolderUID != cmd.FolderUID {
+		return nil
+	}
+	if !cmd.Overwrite && cmd.Dashboard.Get("version").MustInt() != existing.Version {
+		return nil
+	}
+
+	// compare the dashboard the way it would be stored
+	cleanPanelDescriptionSources(cmd.Dashboard)
+	stripOrgDashboardVariables(cmd.Dashboard)
+	hash, err := dashboardContentHash(cmd.Dashboard)
+	if err != nil {
+		return nil
+	}
+	existingHash, err := dashboardContentHash(existing.Data)
+	if err != nil || hash != existingHash {
+		return nil
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), existing, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	return response.JSON(http.StatusOK, util.DynMap{
+		"status":    "success",
+		"unchanged": true,
+		"slug":      existing.Slug,
+		"version":   existing.Version,
+		"id":        existing.ID,
+		"uid":       existing.UID,
+		"url":       existing.GetURL(),
+		"folderUid": existing.FolderUID,
+
+		"consistencyToken": dashboardConsistencyToken(existing),
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestPostDashboard_Unchanged(t *testing.T) {
+	existing := dashboards.NewDashboard("Dash")
+	existing.ID = 1
+	existing.UID = "dash"
+	existing.OrgID = 1
+	existing.FolderUID = "folder"
+	existing.Version = 2
+	existing.Data = simplejson.NewFromAny(map[string]any{
+		"id":      1,
+		"uid":     "dash",
+		"title":   "Dash",
+		"version": 2,
+		"panels":  []any{map[string]any{"id": 1, "type": "timeseries"}},
+	})
+
+	var saves int
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(existing, nil)
+		dashSvc.On("SaveDashboard", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saves++
+		}).Return(&dashboards.Dashboard{ID: 1, UID: "dash", Title: "Dash", FolderUID: "folder", Version: 3}, nil).Maybe()
+		hs.DashboardService = dashSvc
+
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.accesscontrolService = actest.FakeService{}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		hs.LibraryPanelService = &mockLibraryPanelService{}
+		hs.LibraryElementService = &mockLibraryElementService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	save := func(t *testing.T, url string, title string, permissions []accesscontrol.Permission) (*http.Response, map[string]any) {
+		t.Helper()
+		body := `{"folderUid": "folder", "dashboard": {"id": 1, "uid": "dash", "title": "` + title + `", "version": 2, "panels": [{"id": 1, "type": "timeseries"}]}}`
+		req := server.NewPostRequest(url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		result := map[string]any{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		return res, result
+	}
+	canSave := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+		{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"},
+	}
+
+	t.Run("should not save an unchanged dashboard", func(t *testing.T) {
+		saves = 0
+		res, result := save(t, "/api/dashboards/db", "Dash", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, true, result["unchanged"])
+		assert.Equal(t, float64(2), result["version"])
+		assert.Zero(t, saves)
+	})
+
+	t.Run("should not report an unchanged dashboard without save permission", func(t *testing.T) {
+		res, _ := save(t, "/api/dashboards/db", "Dash", canSave[:1])
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should save a changed dashboard", func(t *testing.T) {
+		saves = 0
+		res, result := save(t, "/api/dashboards/db", "Renamed", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotContains(t, result, "unchanged")
+		assert.Equal(t, 1, saves)
+	})
+
+	t.Run("should save an unchanged dashboard when forced", func(t *testing.T) {
+		saves = 0
+		res, result := save(t, "/api/dashboards/db?force=true", "Dash", canSave)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, float64(3), result["version"])
+		assert.Equal(t, 1, saves)
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// templateVariableQueryPrefix prefixes the query parameters overriding the
+// value of a template variable, the same way dashboard URLs do.
+const templateVariableQueryPrefix = "var-"
+
+// applyTemplateVariableOverrides sets the current value of the template
+// variables of the dashboard body named in the query, e.g. var-env=prod.
+// Repeated parameters select several values of a multi value variable.
+// Parameters not matching a variable are ignored.
+func applyTemplateVariableOverrides(data *simplejson.Json, query url.Values) {
+	overrides := make(map[string][]string)
+	for key, values := range query {
+		if name, ok := strings.CutPrefix(key, templateVariableQueryPrefix); ok && name != "" && len(values) > 0 {
+			overrides[name] = values
+		}
+	}
+	if len(overrides) == 0 {
+		return
+	}
+
+	list := data.GetPath("templating", "list")
+	for i := range list.MustArray() {
+		variable := list.GetIndex(i)
+		values, ok := overrides[variable.Get("name").MustString()]
+		if !ok {
+			continue
+		}
+
+		selected := make(map[string]bool, len(values))
+		for _, value := range values {
+			selected[value] = true
+		}
+		options := variable.Get("options")
+		for j := range options.MustArray() {
+			option := options.GetIndex(j)
+			option.Set("selected", selected[option.Get("value").MustString()])
+		}
+
+		if len(values) == 1 && !variable.Get("multi").MustBool() {
+			variable.Set("current", map[string]any{"text": values[0], "value": values[0], "selected": true})
+			continue
+		}
+		current := make([]any, 0, len(values))
+		for _, value := range values {
+			current = append(current, value)
+		}
+		variable.Set("current", map[string]any{"text": current, "value": current, "selected": true})
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestApplyTemplateVariableOverrides(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"templating": {"list": [
+			{"name": "env", "current": {"text": "dev", "value": "dev"}, "options": [
+				{"text": "dev", "value": "dev", "selected": true},
+				{"text": "prod", "value": "prod", "selected": false}
+			]},
+			{"name": "region", "multi": true, "current": {"text": ["eu"], "value": ["eu"]}},
+			{"name": "untouched", "current": {"text": "a", "value": "a"}}
+		]}
+	}`))
+	require.NoError(t, err)
+
+	query, err := url.ParseQuery("var-env=prod&var-region=us&var-region=ap&var-unknown=x&orgId=1")
+	require.NoError(t, err)
+	applyTemplateVariableOverrides(data, query)
+
+	list := data.GetPath("templating", "list")
+	env := list.GetIndex(0)
+	assert.Equal(t, map[string]any{"text": "prod", "value": "prod", "selected": true}, env.Get("current").MustMap())
+	assert.False(t, env.Get("options").GetIndex(0).Get("selected").MustBool())
+	assert.True(t, env.Get("options").GetIndex(1).Get("selected").MustBool())
+
+	region := list.GetIndex(1)
+	assert.Equal(t, []any{"us", "ap"}, region.GetPath("current", "value").MustArray())
+	assert.Equal(t, []any{"us", "ap"}, region.GetPath("current", "text").MustArray())
+
+	assert.Equal(t, "a", list.GetIndex(2).GetPath("current", "value").MustString())
+	assert.Len(t, list.MustArray(), 3)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const dashboardApprovedVersionNamespace = "dashboard-approved-version"
+
+// swagger:route POST /dashboards/uid/{uid}/versions/{DashboardVersionID}/approve dashboard_versions approveDashboardVersion
+//
+// Approve a dashboard version.
+//
+// Flags the version as the approved baseline of the dashboard, replacing the version approved before, as a
+// dashboard has at most one approved version. Getting the dashboard then reports the approved version and
+// whether the dashboard has been saved since. Only users who can administer the dashboard can approve a
+// version.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) ApproveDashboardVersion(c *contextmodel.ReqContext) response.Response {
+	version, err := strconv.Atoi(web.Params(c.Req)[":id"])
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canAdmin, err := guardian.CanAdmin(); err != nil || !canAdmin {
+		return dashboardGuardianResponse(err)
+	}
+
+	if _, err := hs.dashboardVersionService.Get(c.Req.Context(), &dashver.GetDashboardVersionQuery{
+		OrgID:        dash.OrgID,
+		DashboardID:  dash.ID,
+		DashboardUID: dash.UID,
+		Version:      version,
+	}); err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, "Dashboard version not found", err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard version", err)
+	}
+
+	store := kvstore.WithNamespace(hs.kvStore, dash.OrgID, dashboardApprovedVersionNamespace)
+	if err := store.Set(c.Req.Context(), dash.UID, strconv.Itoa(version)); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to approve dashboard version", err)
+	}
+	return response.Success("Dashboard version approved")
+}
+
+// dashboardApprovedVersion returns the approved version of the dashboard, 0
+// when no version has been approved.
+func (hs *HTTPServer) dashboardApprovedVersion(ctx context.Context, orgID int64, uid string) (int, error) {
+	if hs.kvStore == nil {
+		return 0, nil
+	}
+	value, ok, err := kvstore.WithNamespace(hs.kvStore, orgID, dashboardApprovedVersionNamespace).Get(ctx, uid)
+	if err != nil || !ok {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// swagger:parameters approveDashboardVersion
+type ApproveDashboardVersionParams struct {
+	// in:path
+	// required:true
+	DashboardVersionID int64
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
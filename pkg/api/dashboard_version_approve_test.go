@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestApproveDashboardVersion(t *testing.T) {
+	versions := &dashvertest.FakeDashboardVersionService{}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "dash"
+		dash.OrgID = 1
+		dash.Version = 3
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.dashboardVersionService = versions
+
+		hs.kvStore = kvstore.NewFakeKVStore()
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:dash"},
+		{Action: dashboards.ActionDashboardsPermissionsRead, Scope: "dashboards:uid:dash"},
+		{Action: dashboards.ActionDashboardsPermissionsWrite, Scope: "dashboards:uid:dash"},
+	}
+	approve := func(t *testing.T, version string) int {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewPostRequest("/api/dashboards/uid/dash/versions/"+version+"/approve", nil), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+	getMeta := func(t *testing.T) dtos.DashboardMeta {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/dashboards/uid/dash"), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+		return data.Meta
+	}
+
+	t.Run("should not report a divergence before a version is approved", func(t *testing.T) {
+		meta := getMeta(t)
+		assert.Zero(t, meta.ApprovedVersion)
+		assert.False(t, meta.DivergedFromApproved)
+	})
+
+	t.Run("should report whether the dashboard diverged from the approved version", func(t *testing.T) {
+		versions.ExpectedDashboardVersion = &dashver.DashboardVersionDTO{DashboardID: 1, Version: 2}
+		require.Equal(t, http.StatusOK, approve(t, "2"))
+		meta := getMeta(t)
+		assert.Equal(t, 2, meta.ApprovedVersion)
+		assert.True(t, meta.DivergedFromApproved)
+
+		versions.ExpectedDashboardVersion = &dashver.DashboardVersionDTO{DashboardID: 1, Version: 3}
+		require.Equal(t, http.StatusOK, approve(t, "3"))
+		meta = getMeta(t)
+		assert.Equal(t, 3, meta.ApprovedVersion)
+		assert.False(t, meta.DivergedFromApproved)
+	})
+
+	t.Run("should return 404 for a version that doesn't exist", func(t *testing.T) {
+		versions.ExpectedError = dashver.ErrDashboardVersionNotFound
+		defer func() { versions.ExpectedError = nil }()
+		assert.Equal(t, http.StatusNotFound, approve(t, "9"))
+	})
+
+	t.Run("should reject an invalid version", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, approve(t, "latest"))
+	})
+}
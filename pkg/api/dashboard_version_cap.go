@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	dashboardVersionCapNamespace = "dashboard-version-cap"
+	// orgDashboardVersionCapKey holds the cap applied to every dashboard of
+	// the org without a cap of its own.
+	orgDashboardVersionCapKey = "org"
+)
+
+func dashboardVersionCapKey(uid string) string {
+	return "dashboard/" + uid
+}
+
+// swagger:route GET /org/dashboard-version-cap org getOrgDashboardVersionCap
+//
+// Get the maximum number of versions kept per dashboard.
+//
+// A max versions of 0 means dashboard versions are not capped.
+//
+// Responses:
+// 200: dashboardVersionCapResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetOrgDashboardVersionCap(c *contextmodel.ReqContext) response.Response {
+	maxVersions, err := hs.getDashboardVersionCapSetting(c.Req.Context(), c.SignedInUser.GetOrgID(), orgDashboardVersionCapKey)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard version cap", err)
+	}
+	return response.JSON(http.StatusOK, dtos.DashboardVersionCap{MaxVersions: maxVersions})
+}
+
+// swagger:route PUT /org/dashboard-version-cap org updateOrgDashboardVersionCap
+//
+// Set the maximum number of versions kept per dashboard.
+//
+// After each save of a dashboard without a cap of its own, its oldest versions beyond the cap are deleted.
+// The current, tagged and approved versions are always kept. A max versions of 0 disables the cap.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateOrgDashboardVersionCap(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.DashboardVersionCap{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if cmd.MaxVersions < 0 {
+		return response.Error(http.StatusBadRequest, "maxVersions must not be negative", nil)
+	}
+
+	if err := hs.setDashboardVersionCapSetting(c.Req.Context(), c.SignedInUser.GetOrgID(), orgDashboardVersionCapKey, cmd.MaxVersions); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to update dashboard version cap", err)
+	}
+	return response.Success("Dashboard version cap updated")
+}
+
+// swagger:route PUT /dashboards/uid/{uid}/version-cap dashboard_versions updateDashboardVersionCap
+//
+// Set the maximum number of versions kept for a dashboard.
+//
+// Overrides the org wide cap for this dashboard. After each save the oldest versions beyond the cap are
+// deleted, always keeping the current, tagged and approved versions. A max versions of 0 removes the
+// override. Requires permission to delete the dashboard.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateDashboardVersionCap(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.DashboardVersionCap{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if cmd.MaxVersions < 0 {
+		return response.Error(http.StatusBadRequest, "maxVersions must not be negative", nil)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	// a cap deletes versions on every save
+	if canDelete, err := guardian.CanDelete(); err != nil || !canDelete {
+		return dashboardGuardianResponse(err)
+	}
+
+	if err := hs.setDashboardVersionCapSetting(c.Req.Context(), dash.OrgID, dashboardVersionCapKey(dash.UID), cmd.MaxVersions); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to update dashboard version cap", err)
+	}
+	return response.Success("Dashboard version cap updated")
+}
+
+// dashboardVersionCap returns the maximum number of versions kept for the
+// dashboard, or 0 when its versions are not capped.
+func (hs *HTTPServer) dashboardVersionCap(ctx context.Context, orgID int64, uid string) (int, error) {
+	maxVersions, err := hs.getDashboardVersionCapSetting(ctx, orgID, dashboardVersionCapKey(uid))
+	if err != nil || maxVersions > 0 {
+		return maxVersions, err
+	}
+	return hs.getDashboardVersionCapSetting(ctx, orgID, orgDashboardVersionCapKey)
+}
+
+// enforceDashboardVersionCap deletes the oldest versions of a saved dashboard
+// beyond its version cap, keeping its tagged and approved versions.
+func (hs *HTTPServer) enforceDashboardVersionCap(ctx context.Context, dash *dashboards.Dashboard) error {
+	maxVersions, err := hs.dashboardVersionCap(ctx, dash.OrgID, dash.UID)
+	if err != nil || maxVersions == 0 {
+		return err
+	}
+	approved, err := hs.dashboardApprovedVersion(ctx, dash.OrgID, dash.UID)
+	if err != nil {
+		return err
+	}
+	cmd := &dashver.PruneVersionsCommand{DashboardID: dash.ID, VersionsToKeep: maxVersions}
+	if approved != 0 {
+		cmd.Keep = []int{approved}
+	}
+	return hs.dashboardVersionService.Prune(ctx, cmd)
+}
+
+func (hs *HTTPServer) getDashboardVersionCapSetting(ctx context.Context, orgID int64, key string) (int, error) {
+	if hs.kvStore == nil {
+		return 0, nil
+	}
+
+	value, ok, err := kvstore.WithNamespace(hs.kvStore, orgID, dashboardVersionCapNamespace).Get(ctx, key)
+	if err != nil || !ok {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+func (hs *HTTPServer) setDashboardVersionCapSetting(ctx context.Context, orgID int64, key string, maxVersions int) error {
+	store := kvstore.WithNamespace(hs.kvStore, orgID, dashboardVersionCapNamespace)
+	if maxVersions == 0 {
+		return store.Del(ctx, key)
+	}
+	return store.Set(ctx, key, strconv.Itoa(maxVersions))
+}
+
+// swagger:parameters updateOrgDashboardVersionCap
+type UpdateOrgDashboardVersionCapParams struct {
+	// in:body
+	// required:true
+	Body dtos.DashboardVersionCap
+}
+
+// swagger:parameters updateDashboardVersionCap
+type UpdateDashboardVersionCapParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:body
+	// required:true
+	Body dtos.DashboardVersionCap
+}
+
+// swagger:response dashboardVersionCapResponse
+type DashboardVersionCapResponse struct {
+	// in: body
+	Body dtos.DashboardVersionCap `json:"body"`
+}
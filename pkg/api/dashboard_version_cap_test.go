@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestEnforceDashboardVersionCap(t *testing.T) {
+	ctx := context.Background()
+	versions := &pruneRecorder{}
+	hs := &HTTPServer{kvStore: kvstore.NewFakeKVStore(), dashboardVersionService: versions}
+
+	capped := &dashboards.Dashboard{ID: 1, UID: "capped", OrgID: 1}
+	other := &dashboards.Dashboard{ID: 2, UID: "other", OrgID: 1}
+
+	t.Run("should not delete versions without a cap", func(t *testing.T) {
+		require.NoError(t, hs.enforceDashboardVersionCap(ctx, capped))
+		assert.Empty(t, versions.commands)
+	})
+
+	require.NoError(t, hs.setDashboardVersionCapSetting(ctx, 1, orgDashboardVersionCapKey, 10))
+	require.NoError(t, hs.setDashboardVersionCapSetting(ctx, 1, dashboardVersionCapKey("capped"), 3))
+
+	t.Run("should prefer the dashboard cap over the org cap", func(t *testing.T) {
+		maxVersions, err := hs.dashboardVersionCap(ctx, 1, "capped")
+		require.NoError(t, err)
+		assert.Equal(t, 3, maxVersions)
+
+		maxVersions, err = hs.dashboardVersionCap(ctx, 1, "other")
+		require.NoError(t, err)
+		assert.Equal(t, 10, maxVersions)
+	})
+
+	t.Run("should delete the versions beyond the effective cap", func(t *testing.T) {
+		require.NoError(t, hs.enforceDashboardVersionCap(ctx, capped))
+		require.NoError(t, hs.enforceDashboardVersionCap(ctx, other))
+		require.Len(t, versions.commands, 2)
+		assert.Equal(t, dashver.PruneVersionsCommand{DashboardID: 1, VersionsToKeep: 3, DeletedRows: 2}, *versions.commands[0])
+		assert.Equal(t, dashver.PruneVersionsCommand{DashboardID: 2, VersionsToKeep: 10, DeletedRows: 2}, *versions.commands[1])
+		assert.Empty(t, versions.excessCommands)
+	})
+
+	t.Run("should keep the approved version", func(t *testing.T) {
+		versions.commands = nil
+		require.NoError(t, kvstore.WithNamespace(hs.kvStore, 1, dashboardApprovedVersionNamespace).Set(ctx, "capped", "4"))
+		require.NoError(t, hs.enforceDashboardVersionCap(ctx, capped))
+		require.Len(t, versions.commands, 1)
+		assert.Equal(t, []int{4}, versions.commands[0].Keep)
+	})
+
+	t.Run("should fall back to the org cap once the dashboard cap is removed", func(t *testing.T) {
+		require.NoError(t, hs.setDashboardVersionCapSetting(ctx, 1, dashboardVersionCapKey("capped"), 0))
+		maxVersions, err := hs.dashboardVersionCap(ctx, 1, "capped")
+		require.NoError(t, err)
+		assert.Equal(t, 10, maxVersions)
+	})
+}
+
+func TestUpdateDashboardVersionCap(t *testing.T) {
+	kvStore := kvstore.NewFakeKVStore()
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "dash"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.kvStore = kvStore
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	updateCap := func(t *testing.T, permissions []accesscontrol.Permission) *http.Response {
+		t.Helper()
+		req := server.NewRequest(http.MethodPut, "/api/dashboards/uid/dash/version-cap", strings.NewReader(`{"maxVersions": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("should require permission to delete the dashboard", func(t *testing.T) {
+		res := updateCap(t, []accesscontrol.Permission{{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"}})
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		_, ok, err := kvstore.WithNamespace(kvStore, 1, dashboardVersionCapNamespace).Get(context.Background(), dashboardVersionCapKey("dash"))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("should set the cap with permission to delete the dashboard", func(t *testing.T) {
+		res := updateCap(t, []accesscontrol.Permission{{Action: dashboards.ActionDashboardsDelete, Scope: "dashboards:uid:dash"}})
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route POST /dashboards/uid/{uid}/versions/prune dashboard_versions pruneDashboardVersions
+//
+// Delete the versions of a dashboard beyond the configured retention.
+//
+// Applies the same rules as on save: versions beyond `version_retention_count` or older than
+// `version_retention_age` are deleted. Tagged versions, the approved version and the current version are
+// always kept.
+//
+// Responses:
+// 200: pruneDashboardVersionsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) PruneDashboardVersions(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	deleted, err := hs.pruneDashboardVersions(c.Req.Context(), dash)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to prune dashboard versions", err)
+	}
+	return response.JSON(http.StatusOK, dtos.PruneDashboardVersionsResult{Deleted: deleted})
+}
+
+// pruneDashboardVersions deletes the versions of the dashboard beyond the
+// configured retention, keeping its approved version, and returns how many
+// were deleted.
+func (hs *HTTPServer) pruneDashboardVersions(ctx context.Context, dash *dashboards.Dashboard) (int64, error) {
+	cmd := &dashver.PruneVersionsCommand{
+		DashboardID:    dash.ID,
+		VersionsToKeep: hs.Cfg.DashboardVersionRetentionCount,
+	}
+	if hs.Cfg.DashboardVersionRetentionAge > 0 {
+		cmd.CreatedBefore = time.Now().Add(-hs.Cfg.DashboardVersionRetentionAge)
+	}
+	if cmd.VersionsToKeep <= 0 && cmd.CreatedBefore.IsZero() {
+		return 0, nil
+	}
+
+	approved, err := hs.dashboardApprovedVersion(ctx, dash.OrgID, dash.UID)
+	if err != nil {
+		return 0, err
+	}
+	if approved != 0 {
+		cmd.Keep = []int{approved}
+	}
+	if err := hs.dashboardVersionService.Prune(ctx, cmd); err != nil {
+		return 0, err
+	}
+	return cmd.DeletedRows, nil
+}
+
+// swagger:route DELETE /dashboards/uid/{uid}/versions dashboard_versions deleteDashboardVersions
+//
+// Delete the version history of a dashboard.
+//
+// With `keep=current`, deletes every version of the dashboard but the current one, e.g. to erase the
+// history of a dashboard or to shrink the history of a dashboard saved for years. The dashboard itself is
+// left untouched. Tagged versions and the approved version are kept unless `force=true`.
+//
+// Responses:
+// 200: pruneDashboardVersionsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) DeleteDashboardVersions(c *contextmodel.ReqContext) response.Response {
+	if c.Query("keep") != "current" {
+		return response.Error(http.StatusBadRequest, "keep=current is required", nil)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canDelete, err := guardian.CanDelete(); err != nil || !canDelete {
+		return dashboardGuardianResponse(err)
+	}
+
+	if c.QueryBool("force") {
+		cmd := &dashver.DeleteExcessVersionsCommand{DashboardID: dash.ID, VersionsToKeep: 1}
+		if err := hs.dashboardVersionService.DeleteExcess(c.Req.Context(), cmd); err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to delete dashboard versions", err)
+		}
+		return response.JSON(http.StatusOK, dtos.PruneDashboardVersionsResult{Deleted: cmd.DeletedRows})
+	}
+
+	approved, err := hs.dashboardApprovedVersion(c.Req.Context(), dash.OrgID, dash.UID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get approved dashboard version", err)
+	}
+	// tagged versions are kept by pruning
+	cmd := &dashver.PruneVersionsCommand{DashboardID: dash.ID, VersionsToKeep: 1}
+	if approved != 0 {
+		cmd.Keep = []int{approved}
+	}
+	if err := hs.dashboardVersionService.Prune(c.Req.Context(), cmd); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to delete dashboard versions", err)
+	}
+	return response.JSON(http.StatusOK, dtos.PruneDashboardVersionsResult{Deleted: cmd.DeletedRows})
+}
+
+// swagger:parameters pruneDashboardVersions
+type PruneDashboardVersionsParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response pruneDashboardVersionsResponse
+type PruneDashboardVersionsResponse struct {
+	// in: body
+	Body dtos.PruneDashboardVersionsResult `json:"body"`
+}
+
+// swagger:parameters deleteDashboardVersions
+type DeleteDashboardVersionsParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// Versions to keep, only current is supported.
+	// in:query
+	// required:true
+	// enum: current
+	Keep string `json:"keep"`
+	// Also delete tagged and approved versions.
+	// in:query
+	// required:false
+	Force bool `json:"force"`
+}
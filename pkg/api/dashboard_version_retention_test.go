@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+type pruneRecorder struct {
+	dashvertest.FakeDashboardVersionService
+	commands       []*dashver.PruneVersionsCommand
+	excessCommands []*dashver.DeleteExcessVersionsCommand
+}
+
+func (r *pruneRecorder) Prune(ctx context.Context, cmd *dashver.PruneVersionsCommand) error {
+	r.commands = append(r.commands, cmd)
+	cmd.DeletedRows = 2
+	return nil
+}
+
+func (r *pruneRecorder) DeleteExcess(ctx context.Context, cmd *dashver.DeleteExcessVersionsCommand) error {
+	r.excessCommands = append(r.excessCommands, cmd)
+	cmd.DeletedRows = 3
+	return nil
+}
+
+func TestPruneDashboardVersions(t *testing.T) {
+	ctx := context.Background()
+	dash := &dashboards.Dashboard{ID: 1, UID: "dash", OrgID: 1}
+
+	t.Run("should not prune without a retention", func(t *testing.T) {
+		versions := &pruneRecorder{}
+		hs := &HTTPServer{Cfg: setting.NewCfg(), dashboardVersionService: versions}
+
+		deleted, err := hs.pruneDashboardVersions(ctx, dash)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+		assert.Empty(t, versions.commands)
+	})
+
+	t.Run("should prune using the configured count and age", func(t *testing.T) {
+		versions := &pruneRecorder{}
+		hs := &HTTPServer{Cfg: setting.NewCfg(), dashboardVersionService: versions}
+		hs.Cfg.DashboardVersionRetentionCount = 50
+		hs.Cfg.DashboardVersionRetentionAge = 24 * time.Hour
+
+		deleted, err := hs.pruneDashboardVersions(ctx, dash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, deleted)
+
+		require.Len(t, versions.commands, 1)
+		assert.Equal(t, int64(1), versions.commands[0].DashboardID)
+		assert.Equal(t, 50, versions.commands[0].VersionsToKeep)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), versions.commands[0].CreatedBefore, time.Minute)
+		assert.Empty(t, versions.commands[0].Keep)
+	})
+
+	t.Run("should keep the approved version", func(t *testing.T) {
+		versions := &pruneRecorder{}
+		hs := &HTTPServer{Cfg: setting.NewCfg(), dashboardVersionService: versions, kvStore: kvstore.NewFakeKVStore()}
+		hs.Cfg.DashboardVersionRetentionCount = 50
+		require.NoError(t, kvstore.WithNamespace(hs.kvStore, 1, dashboardApprovedVersionNamespace).Set(ctx, "dash", "3"))
+
+		_, err := hs.pruneDashboardVersions(ctx, dash)
+		require.NoError(t, err)
+		require.Len(t, versions.commands, 1)
+		assert.Equal(t, []int{3}, versions.commands[0].Keep)
+	})
+}
+
+func TestDeleteDashboardVersions(t *testing.T) {
+	versions := &pruneRecorder{}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "dash"
+		dash.OrgID = 1
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.dashboardVersionService = versions
+
+		hs.kvStore = kvstore.NewFakeKVStore()
+		require.NoError(t, kvstore.WithNamespace(hs.kvStore, 1, dashboardApprovedVersionNamespace).Set(context.Background(), "dash", "2"))
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	deleteVersions := func(t *testing.T, url string) *http.Response {
+		t.Helper()
+		permissions := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsDelete, Scope: "dashboards:uid:dash"}}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewRequest(http.MethodDelete, url, nil), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should keep the current, tagged and approved versions", func(t *testing.T) {
+		res := deleteVersions(t, "/api/dashboards/uid/dash/versions?keep=current")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result dtos.PruneDashboardVersionsResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		require.NoError(t, res.Body.Close())
+		assert.EqualValues(t, 2, result.Deleted)
+
+		require.Len(t, versions.commands, 1)
+		assert.Equal(t, 1, versions.commands[0].VersionsToKeep)
+		assert.Equal(t, []int{2}, versions.commands[0].Keep)
+	})
+
+	t.Run("should only keep the current version when forced", func(t *testing.T) {
+		res := deleteVersions(t, "/api/dashboards/uid/dash/versions?keep=current&force=true")
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		require.Len(t, versions.excessCommands, 1)
+		assert.Equal(t, 1, versions.excessCommands[0].VersionsToKeep)
+	})
+
+	t.Run("should require keep=current", func(t *testing.T) {
+		res := deleteVersions(t, "/api/dashboards/uid/dash/versions")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+type tagVersionRecorder struct {
+	dashvertest.FakeDashboardVersionService
+	commands []*dashver.TagDashboardVersionCommand
+}
+
+func (r *tagVersionRecorder) Tag(ctx context.Context, cmd *dashver.TagDashboardVersionCommand) error {
+	r.commands = append(r.commands, cmd)
+	return r.ExpectedError
+}
+
+func TestTagDashboardVersion(t *testing.T) {
+	versions := &tagVersionRecorder{}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("some dash")
+		dash.ID = 1
+		dash.UID = "dash"
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil)
+		hs.DashboardService = dashSvc
+		hs.dashboardVersionService = versions
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	tagVersion := func(version string, permissions []accesscontrol.Permission) *http.Response {
+		req := server.NewPostRequest("/api/dashboards/uid/dash/versions/"+version+"/tag", strings.NewReader(`{"name": "pre-migration"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+	canWrite := []accesscontrol.Permission{{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:dash"}}
+
+	t.Run("should tag the version", func(t *testing.T) {
+		res := tagVersion("3", canWrite)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, versions.commands, 1)
+		assert.Equal(t, dashver.TagDashboardVersionCommand{DashboardID: 1, Version: 3, Name: "pre-migration"}, *versions.commands[0])
+	})
+
+	t.Run("should report a tag used by another version", func(t *testing.T) {
+		versions.ExpectedError = dashver.ErrDashboardVersionTagTaken
+		t.Cleanup(func() { versions.ExpectedError = nil })
+
+		res := tagVersion("2", canWrite)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("should require permission to save the dashboard", func(t *testing.T) {
+		res := tagVersion("3", []accesscontrol.Permission{{Action: dashboards.ActionDashboardsWrite, Scope: "dashboards:uid:other"}})
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+}
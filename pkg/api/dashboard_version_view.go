@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /dashboards/uid/{uid}/versions/{DashboardVersionID}/dashboard dashboard_versions getDashboardAtVersion
+//
+// Get a dashboard as it was at a version.
+//
+// Returns the dashboard of the given version in the same form as getting the dashboard, to show an old
+// version without restoring it. The view is read-only: canSave, canEdit, canDelete and canAdmin are false,
+// and version, updated and updatedBy describe the requested version.
+//
+// Responses:
+// 200: dashboardResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardAtVersion(c *contextmodel.ReqContext) response.Response {
+	version, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 32)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	// versions are only available to users who can save the dashboard
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	res, err := hs.dashboardVersionService.Get(c.Req.Context(), &dashver.GetDashboardVersionQuery{
+		OrgID:        dash.OrgID,
+		DashboardID:  dash.ID,
+		DashboardUID: dash.UID,
+		Version:      int(version),
+	})
+	if err != nil {
+		if errors.Is(err, dashver.ErrDashboardVersionNotFound) {
+			return response.Error(http.StatusNotFound, fmt.Sprintf("Dashboard version %d not found", version), err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to get dashboard version", err)
+	}
+
+	meta, rsp := hs.getDashboardMeta(c, dash, guardian)
+	if rsp != nil {
+		return rsp
+	}
+	meta.CanSave, meta.CanEdit, meta.CanDelete, meta.CanAdmin = false, false, false, false
+	meta.Version = res.Version
+	meta.Updated = res.Created
+	meta.UpdatedBy = anonString
+	if login, ok := hs.getUserLogins(c.Req.Context(), []int64{res.CreatedBy})[res.CreatedBy]; ok {
+		meta.UpdatedBy = login
+	}
+
+	// the stored data may predate syncing the version into the body
+	res.Data.Set("version", res.Version)
+	return response.JSON(http.StatusOK, dtos.DashboardFullWithMeta{Dashboard: res.Data, Meta: meta})
+}
+
+// swagger:parameters getDashboardAtVersion
+type GetDashboardAtVersionParams struct {
+	// in:path
+	// required:true
+	DashboardVersionID int64
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
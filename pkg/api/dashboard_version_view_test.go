@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/dashboardversion/dashvertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestGetDashboardAtVersion(t *testing.T) {
+	created := time.Date(2023, 5, 6, 7, 8, 9, 0, time.UTC)
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dash := dashboards.NewDashboard("Current title")
+		dash.ID = 1
+		dash.UID = "dash"
+		dash.OrgID = 1
+		dash.Version = 5
+
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		dashSvc.On("GetDashboard", mock.Anything, mock.Anything).Return(dash, nil).Maybe()
+		hs.DashboardService = dashSvc
+		hs.dashboardVersionService = &dashvertest.FakeDashboardVersionService{
+			ExpectedDashboardVersion: &dashver.DashboardVersionDTO{
+				DashboardID: 1,
+				Version:     2,
+				Created:     created,
+				CreatedBy:   2,
+				Data:        simplejson.NewFromAny(map[string]any{"uid": "dash", "title": "Old title"}),
+			},
+		}
+
+		hs.Cfg = setting.NewCfg()
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.userService = &usertest.FakeUserService{ExpectedUsers: []*user.User{{ID: 2, Login: "editor"}}}
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	get := func(t *testing.T, url string) *http.Response {
+		t.Helper()
+		permissions := []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+			{Action: dashboards.ActionDashboardsWrite, Scope: dashboards.ScopeDashboardsAll},
+		}
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(url), userWithPermissions(1, permissions)))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("should return the dashboard of the version as read-only", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/dash/versions/2/dashboard")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var data dtos.DashboardFullWithMeta
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&data))
+		require.NoError(t, res.Body.Close())
+
+		assert.Equal(t, "Old title", data.Dashboard.Get("title").MustString())
+		assert.Equal(t, 2, data.Dashboard.Get("version").MustInt())
+		assert.False(t, data.Meta.CanSave)
+		assert.False(t, data.Meta.CanEdit)
+		assert.Equal(t, 2, data.Meta.Version)
+		assert.Equal(t, "editor", data.Meta.UpdatedBy)
+		assert.True(t, created.Equal(data.Meta.Updated))
+	})
+
+	t.Run("should reject an invalid version", func(t *testing.T) {
+		res := get(t, "/api/dashboards/uid/dash/versions/latest/dashboard")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
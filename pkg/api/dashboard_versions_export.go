@@ -0,0 +1,163 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// dashboardVersionsExportPageSize is the number of versions listed at once
+// when building the manifest of a version history export.
+const dashboardVersionsExportPageSize = 1000
+
+// swagger:route GET /dashboards/uid/{uid}/versions/export dashboard_versions exportDashboardVersions
+//
+// Export the version history of a dashboard.
+//
+// Returns a zip archive holding the dashboard JSON of every version in a v<version>.json file, and a
+// manifest.json listing the versions with their creation time, author and message. It requires the same
+// permissions as listing the versions.
+//
+// Produces:
+// - application/zip
+//
+// Responses:
+// 200: dashboardVersionsExportResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) ExportDashboardVersions(c *contextmodel.ReqContext) response.Response {
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, web.Params(c.Req)[":uid"])
+	if rsp != nil {
+		return rsp
+	}
+
+	guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return response.Err(err)
+	}
+	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+		return dashboardGuardianResponse(err)
+	}
+
+	var versions []*dashver.DashboardVersionDTO
+	for start := 0; ; start += dashboardVersionsExportPageSize {
+		page, err := hs.dashboardVersionService.List(c.Req.Context(), &dashver.ListDashboardVersionsQuery{
+			OrgID:        dash.OrgID,
+			DashboardID:  dash.ID,
+			DashboardUID: dash.UID,
+			Limit:        dashboardVersionsExportPageSize,
+			Start:        start,
+		})
+		if errors.Is(err, dashver.ErrNoVersionsForDashboardID) && start > 0 {
+			break
+		}
+		if err != nil {
+			return response.Error(http.StatusNotFound, fmt.Sprintf("No versions found for dashboard %s", dash.UID), err)
+		}
+		versions = append(versions, page...)
+		if len(page) < dashboardVersionsExportPageSize {
+			break
+		}
+	}
+
+	manifest := dtos.DashboardVersionsExportManifest{UID: dash.UID, Title: dash.Title}
+	for _, version := range hs.dashboardVersionMetas(c.Req.Context(), dash.UID, versions) {
+		manifest.Versions = append(manifest.Versions, dtos.DashboardVersionsExportEntry{
+			File:      fmt.Sprintf("v%d.json", version.Version),
+			Version:   version.Version,
+			Created:   version.Created,
+			CreatedBy: version.CreatedBy,
+			Message:   version.Message,
+		})
+	}
+
+	return &dashboardVersionsExportResponse{
+		manifest: manifest,
+		versionData: func(ctx context.Context, version int) (*simplejson.Json, error) {
+			v, err := hs.dashboardVersionService.Get(ctx, &dashver.GetDashboardVersionQuery{OrgID: dash.OrgID, DashboardUID: dash.UID, Version: version})
+			if err != nil {
+				return nil, err
+			}
+			return v.Data, nil
+		},
+	}
+}
+
+// dashboardVersionsExportResponse streams the version history of a dashboard
+// as a zip archive, loading the versions one at a time.
+type dashboardVersionsExportResponse struct {
+	manifest    dtos.DashboardVersionsExportManifest
+	versionData func(ctx context.Context, version int) (*simplejson.Json, error)
+}
+
+func (r *dashboardVersionsExportResponse) Status() int {
+	return http.StatusOK
+}
+
+func (r *dashboardVersionsExportResponse) Body() []byte {
+	return nil
+}
+
+func (r *dashboardVersionsExportResponse) WriteTo(ctx *contextmodel.ReqContext) {
+	ctx.Resp.Header().Set("Content-Type", "application/zip")
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-versions.zip"`, r.manifest.UID))
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	if err := writeDashboardVersionsExport(ctx.Req.Context(), ctx.Resp, r.manifest, r.versionData); err != nil {
+		ctx.Logger.Error("Error writing dashboard versions export", "dashboard", r.manifest.UID, "err", err)
+	}
+}
+
+func writeDashboardVersionsExport(ctx context.Context, w http.ResponseWriter, manifest dtos.DashboardVersionsExportManifest,
+	versionData func(ctx context.Context, version int) (*simplejson.Json, error)) error {
+	archive := zip.NewWriter(w)
+	writeJSON := func(name string, v any) error {
+		file, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(file)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	if err := writeJSON("manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, entry := range manifest.Versions {
+		data, err := versionData(ctx, entry.Version)
+		if err != nil {
+			return err
+		}
+		if err := writeJSON(entry.File, data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// swagger:parameters exportDashboardVersions
+type ExportDashboardVersionsParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:response dashboardVersionsExportResponse
+type DashboardVersionsExportResponse struct {
+	// in: body
+	Body []byte `json:"body"`
+}
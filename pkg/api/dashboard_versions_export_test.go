@@ -0,0 +1,56 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestWriteDashboardVersionsExport(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	manifest := dtos.DashboardVersionsExportManifest{
+		UID:   "dash",
+		Title: "Dash",
+		Versions: []dtos.DashboardVersionsExportEntry{
+			{File: "v2.json", Version: 2, Created: created, CreatedBy: "admin", Message: "Add panel"},
+			{File: "v1.json", Version: 1, Created: created.Add(-time.Hour), CreatedBy: "admin", Message: "Initial save"},
+		},
+	}
+	versionData := func(_ context.Context, version int) (*simplejson.Json, error) {
+		return simplejson.NewFromAny(map[string]any{"uid": "dash", "version": version}), nil
+	}
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, writeDashboardVersionsExport(context.Background(), rec, manifest, versionData))
+
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 3)
+	assert.Equal(t, "manifest.json", archive.File[0].Name)
+	assert.Equal(t, "v2.json", archive.File[1].Name)
+	assert.Equal(t, "v1.json", archive.File[2].Name)
+
+	file, err := archive.File[0].Open()
+	require.NoError(t, err)
+	var exported dtos.DashboardVersionsExportManifest
+	require.NoError(t, json.NewDecoder(file).Decode(&exported))
+	require.NoError(t, file.Close())
+	assert.Equal(t, manifest, exported)
+
+	file, err = archive.File[2].Open()
+	require.NoError(t, err)
+	data, err := simplejson.NewFromReader(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, 1, data.Get("version").MustInt())
+}
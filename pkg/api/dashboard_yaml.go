@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+var yamlMediaTypes = map[string]bool{
+	"application/yaml":   true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+}
+
+// wantsYAML tells whether the client asked for a YAML response, with
+// format=yaml or a YAML media type in the Accept header.
+func wantsYAML(c *contextmodel.ReqContext) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "yaml"
+	}
+	for _, accepted := range strings.Split(c.Req.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && yamlMediaTypes[mediaType] {
+			return true
+		}
+	}
+	return false
+}
+
+// isYAMLRequest tells whether the request body is sent as YAML.
+func isYAMLRequest(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && yamlMediaTypes[mediaType]
+}
+
+// dashboardResponse writes the body as JSON, or as YAML when the client asked
+// for it.
+func dashboardResponse(c *contextmodel.ReqContext, body any) *response.NormalResponse {
+	if !wantsYAML(c) {
+		return response.JSON(http.StatusOK, body)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "body json marshal", err)
+	}
+	b, err = jsonToYAML(b)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "body yaml marshal", err)
+	}
+	// As with response.YAML, application/yaml is downloaded by chrome so we use text/yaml instead.
+	return response.Respond(http.StatusOK, b).SetHeader("Content-Type", "text/yaml")
+}
+
+// jsonToYAML converts a JSON document to YAML. JSON is decoded into a YAML
+// node rather than a map so that keys keep their order and scalars keep their
+// type, e.g. strings holding numbers stay quoted.
+func jsonToYAML(b []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// resetYAMLStyle drops the flow style the JSON syntax gives to every node, so
+// that the document is written in block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// yamlBodyToJSON replaces a YAML request body by its JSON form, so that it can
+// be bound like any JSON request.
+func yamlBodyToJSON(req *http.Request) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := yaml.Unmarshal(body, &value); err != nil {
+		return err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	req.ContentLength = int64(len(b))
+	req.Header.Set("Content-Type", "application/json")
+	return nil
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestDashboardYAML(t *testing.T) {
+	dashboardJSON := `{"title":"Prod","uid":"abc","version":3,"refresh":"10","editable":true,"panels":[{"id":1,"gridPos":{"h":8,"w":12.5},"title":"on"}]}`
+
+	t.Run("should keep the key order and types of the JSON", func(t *testing.T) {
+		b, err := jsonToYAML([]byte(dashboardJSON))
+		require.NoError(t, err)
+		assert.Equal(t, `title: Prod
+uid: abc
+version: 3
+refresh: "10"
+editable: true
+panels:
+    - id: 1
+      gridPos:
+        h: 8
+        w: 12.5
+      title: on
+`, string(b))
+	})
+
+	t.Run("should convert a YAML body back to the same JSON", func(t *testing.T) {
+		b, err := jsonToYAML([]byte(dashboardJSON))
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/dashboards/db", strings.NewReader(string(b)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/yaml")
+		require.True(t, isYAMLRequest(req))
+		require.NoError(t, yamlBodyToJSON(req))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		got, err := simplejson.NewJson(body)
+		require.NoError(t, err)
+		want, err := simplejson.NewJson([]byte(dashboardJSON))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("should reject invalid YAML", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/dashboards/db", strings.NewReader("dashboard: [unclosed"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/yaml")
+		assert.Error(t, yamlBodyToJSON(req))
+	})
+}
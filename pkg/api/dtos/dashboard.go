@@ -26,16 +26,65 @@ type DashboardMeta struct {
 	HasACL     bool      `json:"hasAcl" xorm:"has_acl"`
 	IsFolder   bool      `json:"isFolder"`
 	// Deprecated: use FolderUID instead
-	FolderId               int64                 `json:"folderId"`
-	FolderUid              string                `json:"folderUid"`
-	FolderTitle            string                `json:"folderTitle"`
-	FolderUrl              string                `json:"folderUrl"`
+	FolderId    int64  `json:"folderId"`
+	FolderUid   string `json:"folderUid"`
+	FolderTitle string `json:"folderTitle"`
+	FolderUrl   string `json:"folderUrl"`
+	// FolderPath lists the folders containing the dashboard, from the root
+	// folder to the folder of the dashboard. Empty for the General folder.
+	FolderPath             []FolderPathItem      `json:"folderPath"`
 	Provisioned            bool                  `json:"provisioned"`
 	ProvisionedExternalId  string                `json:"provisionedExternalId"`
 	AnnotationsPermissions *AnnotationPermission `json:"annotationsPermissions"`
 	PublicDashboardUID     string                `json:"publicDashboardUid,omitempty"`
 	PublicDashboardEnabled bool                  `json:"publicDashboardEnabled,omitempty"`
+	// ProvisioningDrift is set when a provisioned dashboard differs from its provisioning file.
+	ProvisioningDrift bool `json:"provisioningDrift,omitempty"`
+	// InjectedVariables lists the org wide template variables added to the served dashboard.
+	InjectedVariables []string `json:"injectedVariables,omitempty"`
+	// MaxVersions is the maximum number of versions kept for the dashboard, 0 when not capped.
+	MaxVersions int `json:"maxVersions,omitempty"`
+	// Protected dashboards can only be deleted with confirm=true.
+	Protected bool `json:"protected,omitempty"`
+	// ApprovedVersion is the version approved as the baseline of the dashboard, 0 when none is.
+	ApprovedVersion int `json:"approvedVersion,omitempty"`
+	// DivergedFromApproved is set when the dashboard was saved since the approved version.
+	DivergedFromApproved bool `json:"divergedFromApproved,omitempty"`
+	// ViewCount, LastViewedAt and StarCount are only set when requested with withUsage.
+	ViewCount    *int64     `json:"viewCount,omitempty"`
+	LastViewedAt *time.Time `json:"lastViewedAt,omitempty"`
+	StarCount    *int64     `json:"starCount,omitempty"`
+	// PanelCount, DatasourceTypes and TemplateVariables are only set when
+	// requested with withStats. DatasourceTypes counts the panels using each
+	// data source type.
+	PanelCount        *int           `json:"panelCount,omitempty"`
+	DatasourceTypes   map[string]int `json:"datasourceTypes,omitempty"`
+	TemplateVariables []string       `json:"templateVariables,omitempty"`
+	// RedactedPanels lists the ids of the panels replaced because of data
+	// sources the user can't query, only set when requested with redactNoAccess.
+	RedactedPanels []int64 `json:"redactedPanels,omitempty"`
+	// EffectivePermissions lists the permissions granting the Can* flags,
+	// only set when requested with explainPermissions.
+	EffectivePermissions []EffectivePermission `json:"effectivePermissions,omitempty"`
 }
+
+// EffectivePermission is a permission of the signed in user granting one of
+// the Can* flags of a dashboard. Source is direct for a permission on the
+// dashboard itself, inherited for a permission on one of its folders, given
+// in FolderUID, and wildcard for a permission on all dashboards or folders.
+type EffectivePermission struct {
+	Flag      string `json:"flag"`
+	Action    string `json:"action"`
+	Scope     string `json:"scope"`
+	Source    string `json:"source"`
+	FolderUID string `json:"folderUid,omitempty"`
+}
+
+type FolderPathItem struct {
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+}
+
 type AnnotationPermission struct {
 	Dashboard    AnnotationActions `json:"dashboard"`
 	Organization AnnotationActions `json:"organization"`
@@ -54,20 +103,498 @@ type DashboardFullWithMeta struct {
 
 type DashboardRedirect struct {
 	RedirectUri string `json:"redirectUri"`
+	// UID of the dashboard redirected to, unset for other pages.
+	UID string `json:"uid,omitempty"`
 }
 
 type CalculateDiffOptions struct {
-	Base     CalculateDiffTarget `json:"base" binding:"Required"`
-	New      CalculateDiffTarget `json:"new" binding:"Required"`
-	DiffType string              `json:"diffType" binding:"Required"`
+	Base CalculateDiffTarget `json:"base" binding:"Required"`
+	New  CalculateDiffTarget `json:"new" binding:"Required"`
+	// DiffType is one of basic, json, delta or semantic. When empty, the
+	// configured default diff type is used.
+	DiffType string `json:"diffType"`
+	// Page and PageSize select a page of the changes of a semantic diff.
+	Page     int `json:"page,omitempty"`
+	PageSize int `json:"pageSize,omitempty"`
+	// MaxLines caps the number of lines of a basic diff.
+	MaxLines int `json:"maxLines,omitempty"`
 }
 
 type CalculateDiffTarget struct {
 	DashboardId      int64            `json:"dashboardId"`
 	Version          int              `json:"version"`
 	UnsavedDashboard *simplejson.Json `json:"unsavedDashboard"`
+	// Data is a dashboard body to diff instead of a stored version.
+	Data *simplejson.Json `json:"data,omitempty"`
 }
 
 type RestoreDashboardVersionCommand struct {
-	Version int `json:"version" binding:"Required"`
+	// Version, VersionTag and BeforeTimestamp are mutually exclusive.
+	Version    int    `json:"version"`
+	VersionTag string `json:"versionTag"`
+	// BeforeTimestamp restores the newest version created at or before it.
+	BeforeTimestamp time.Time `json:"beforeTimestamp"`
+	// PreserveCurrent saves the current dashboard as a new version before
+	// restoring.
+	PreserveCurrent bool `json:"preserveCurrent"`
+}
+
+// BulkRestoreDashboardEntry selects the version a dashboard of a bulk restore
+// is restored to.
+type BulkRestoreDashboardEntry struct {
+	UID string `json:"uid" binding:"Required"`
+	RestoreDashboardVersionCommand
+}
+
+type TagDashboardVersionCommand struct {
+	// Name of the tag, an empty name removes the tag of the version.
+	Name string `json:"name"`
+}
+
+type HardcodedDatasourceDashboard struct {
+	UID       string                     `json:"uid"`
+	Title     string                     `json:"title"`
+	URL       string                     `json:"url"`
+	FolderUID string                     `json:"folderUid"`
+	Panels    []HardcodedDatasourcePanel `json:"panels"`
+}
+
+type DashboardContentMatch struct {
+	UID       string                    `json:"uid"`
+	Title     string                    `json:"title"`
+	URL       string                    `json:"url"`
+	FolderUID string                    `json:"folderUid"`
+	Matches   []DashboardContentSnippet `json:"matches"`
+}
+
+type DashboardContentSnippet struct {
+	// Path of the matching value in the dashboard, e.g. panels[0].targets[1].expr.
+	Path    string `json:"path"`
+	Snippet string `json:"snippet"`
+}
+
+type HardcodedDatasourcePanel struct {
+	ID          int64           `json:"id"`
+	Title       string          `json:"title"`
+	Datasources []DatasourceRef `json:"datasources"`
+}
+
+type DatasourceRef struct {
+	UID  string `json:"uid,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+type ImportDiffCommand struct {
+	Dashboard *simplejson.Json `json:"dashboard" binding:"Required"`
+	DiffType  string           `json:"diffType"`
+}
+
+type SingleVersionDashboard struct {
+	UID       string    `json:"uid"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	FolderUID string    `json:"folderUid"`
+	Created   time.Time `json:"created"`
+	CreatedBy string    `json:"createdBy"`
+}
+
+type DashboardPanelVersion struct {
+	// Version is the dashboard version in which the panel got this state.
+	Version   int              `json:"version"`
+	Created   time.Time        `json:"created"`
+	CreatedBy string           `json:"createdBy"`
+	Message   string           `json:"message"`
+	Panel     *simplejson.Json `json:"panel"`
+}
+
+// DashboardPanelEmbed is a single panel together with the parts of its
+// dashboard needed to render it on its own.
+type DashboardPanelEmbed struct {
+	DashboardUID   string           `json:"dashboardUid"`
+	DashboardTitle string           `json:"dashboardTitle"`
+	Version        int              `json:"version"`
+	Panel          *simplejson.Json `json:"panel"`
+	Templating     *simplejson.Json `json:"templating"`
+	Time           *simplejson.Json `json:"time"`
+	Timezone       string           `json:"timezone,omitempty"`
+	WeekStart      string           `json:"weekStart,omitempty"`
+}
+
+type MoveDashboardCommand struct {
+	// FolderUID is the destination folder, empty for the General folder.
+	FolderUID string `json:"folderUid"`
+}
+
+type RevertDashboardPanelCommand struct {
+	// Version is the dashboard version to take the panel from.
+	Version int `json:"version"`
+}
+
+type DashboardTimeAnalysis struct {
+	UID      string `json:"uid"`
+	Title    string `json:"title"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relative bool   `json:"relative"`
+	// AbsolutePanels lists the panels whose time overrides are hardcoded absolute times.
+	AbsolutePanels []AbsoluteTimePanel `json:"absolutePanels"`
+}
+
+type AbsoluteTimePanel struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+type BulkFixTimeCommand struct {
+	// DashboardUIDs lists the dashboards to fix. When empty the dashboards matching Query are fixed.
+	DashboardUIDs []string `json:"dashboardUids"`
+	Query         string   `json:"query"`
+	// From and To is the relative time range replacing absolute dashboard time ranges, e.g. now-6h and now.
+	From   string `json:"from" binding:"Required"`
+	To     string `json:"to" binding:"Required"`
+	DryRun bool   `json:"dryRun"`
+}
+
+type BulkDeleteDashboardsCommand struct {
+	DashboardUIDs []string `json:"dashboardUids"`
+}
+
+type DashboardTagCommand struct {
+	Tag string `json:"tag" binding:"Required"`
+}
+
+// DashboardTags are the tags of a dashboard after a tag was added or removed.
+type DashboardTags struct {
+	UID     string   `json:"uid"`
+	Version int      `json:"version"`
+	Tags    []string `json:"tags"`
+}
+
+type BulkTagDashboardsCommand struct {
+	DashboardUIDs []string `json:"dashboardUids" binding:"Required"`
+	Tag           string   `json:"tag" binding:"Required"`
+	DryRun        bool     `json:"dryRun"`
+}
+
+type BatchGetDashboardsCommand struct {
+	DashboardUIDs []string `json:"dashboardUids"`
+}
+
+// BatchDashboardResult is the dashboard and meta of a batch get, or the
+// reason it could not be returned.
+type BatchDashboardResult struct {
+	UID string `json:"uid"`
+	// Status is one of found, forbidden, notFound and failed.
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	*DashboardFullWithMeta
+}
+
+type CheckDashboardPermissionsCommand struct {
+	DashboardUIDs []string `json:"dashboardUids"`
+	// Actions to check, any of read, write, delete and admin.
+	Actions []string `json:"actions"`
+}
+
+type BulkDashboardResult struct {
+	UID     string `json:"uid"`
+	Title   string `json:"title,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Version int    `json:"version,omitempty"`
+}
+
+type CardinalityRiskDashboards struct {
+	High   []CardinalityRiskDashboard `json:"high"`
+	Medium []CardinalityRiskDashboard `json:"medium"`
+	Low    []CardinalityRiskDashboard `json:"low"`
+}
+
+type CardinalityRiskDashboard struct {
+	UID       string                 `json:"uid"`
+	Title     string                 `json:"title"`
+	URL       string                 `json:"url"`
+	FolderUID string                 `json:"folderUid"`
+	Risk      string                 `json:"risk"`
+	Panels    []CardinalityRiskPanel `json:"panels"`
+}
+
+type CardinalityRiskPanel struct {
+	ID      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Risk    string   `json:"risk"`
+	Reasons []string `json:"reasons"`
+}
+
+type CloneDashboardCommand struct {
+	Title string `json:"title" binding:"Required"`
+	// FolderUID is the folder of the clone, defaults to the folder of the source dashboard.
+	FolderUID string `json:"folderUid"`
+}
+
+type AutosaveDashboardCommand struct {
+	Dashboard *simplejson.Json `json:"dashboard" binding:"Required"`
+}
+
+type PromoteDashboardAutosaveCommand struct {
+	Message   string `json:"message"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+type DashboardAutosave struct {
+	Dashboard *simplejson.Json `json:"dashboard"`
+	// Version is the dashboard version the draft is based on.
+	Version int       `json:"version"`
+	Updated time.Time `json:"updated"`
+}
+
+type DashboardLineage struct {
+	UID string `json:"uid"`
+	// Ancestors lists what the dashboard was cloned or imported from, closest first.
+	Ancestors []DashboardLineageEntry `json:"ancestors"`
+	// Descendants lists the dashboards cloned or imported from the dashboard, directly or transitively.
+	Descendants []DashboardLineageEntry `json:"descendants"`
+}
+
+type DashboardLineageEntry struct {
+	UID       string    `json:"uid,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	ParentUID string    `json:"parentUid,omitempty"`
+	Origin    string    `json:"origin"`
+	GnetID    int64     `json:"gnetId,omitempty"`
+	PluginID  string    `json:"pluginId,omitempty"`
+	Created   time.Time `json:"created"`
+}
+
+type DashboardDependent struct {
+	// Type is the kind of construct referencing the dashboard, e.g. playlist or public_dashboard.
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type DeprecatedOptionDashboards struct {
+	TotalCount int                         `json:"totalCount"`
+	Page       int                         `json:"page"`
+	PerPage    int                         `json:"perPage"`
+	Dashboards []DeprecatedOptionDashboard `json:"dashboards"`
+}
+
+type DeprecatedOptionDashboard struct {
+	UID       string                  `json:"uid"`
+	Title     string                  `json:"title"`
+	URL       string                  `json:"url"`
+	FolderUID string                  `json:"folderUid"`
+	Panels    []DeprecatedOptionPanel `json:"panels"`
+}
+
+type DeprecatedOptionPanel struct {
+	ID      int64                   `json:"id"`
+	Title   string                  `json:"title"`
+	Options []DeprecatedPanelOption `json:"options"`
+}
+
+type DeprecatedPanelOption struct {
+	Path        string `json:"path"`
+	Replacement string `json:"replacement"`
+}
+
+type DashboardAccessibilityReport struct {
+	UID      string                          `json:"uid"`
+	Title    string                          `json:"title"`
+	Findings []DashboardAccessibilityFinding `json:"findings"`
+}
+
+type DashboardAccessibilityFinding struct {
+	PanelID    int64  `json:"panelId"`
+	PanelTitle string `json:"panelTitle"`
+	Rule       string `json:"rule"`
+	// Severity is one of error, warning or info.
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+type EditorTeamDashboards struct {
+	TotalCount int                   `json:"totalCount"`
+	Page       int                   `json:"page"`
+	PerPage    int                   `json:"perPage"`
+	Dashboards []EditorTeamDashboard `json:"dashboards"`
+}
+
+type EditorTeamDashboard struct {
+	UID          string    `json:"uid"`
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	FolderUID    string    `json:"folderUid"`
+	Version      int       `json:"version"`
+	LastEditedBy string    `json:"lastEditedBy"`
+	LastEdited   time.Time `json:"lastEdited"`
+}
+
+// DashboardReindexResult describes the index entries rebuilt for a dashboard.
+type DashboardReindexResult struct {
+	UID     string `json:"uid"`
+	Title   string `json:"title"`
+	Version int    `json:"version"`
+	// Panels, Datasources and TemplateVariables are the number of entries
+	// now indexed for the dashboard.
+	Panels            int `json:"panels"`
+	Datasources       int `json:"datasources"`
+	TemplateVariables int `json:"templateVariables"`
+	// LibraryPanels reports whether the library panel connections were rebuilt.
+	LibraryPanels bool `json:"libraryPanels"`
+}
+
+type DashboardVersionCap struct {
+	// MaxVersions is the maximum number of versions kept, 0 for no cap.
+	MaxVersions int `json:"maxVersions"`
+}
+
+type PruneDashboardVersionsResult struct {
+	// Deleted is the number of versions deleted.
+	Deleted int64 `json:"deleted"`
+}
+
+type DashboardChangedSince struct {
+	// Changed is true when the dashboard is no longer at the given version
+	// or no longer matches the given hash.
+	Changed        bool   `json:"changed"`
+	CurrentVersion int    `json:"currentVersion"`
+	Hash           string `json:"hash"`
+}
+
+type TrashedDashboard struct {
+	UID       string    `json:"uid"`
+	Title     string    `json:"title"`
+	FolderUID string    `json:"folderUid"`
+	Deleted   time.Time `json:"deleted"`
+	DeletedBy string    `json:"deletedBy"`
+	// Expires is when the dashboard is permanently deleted.
+	Expires time.Time `json:"expires"`
+}
+
+type DashboardQuotaUsage struct {
+	Org  *QuotaUsage `json:"org,omitempty"`
+	User *QuotaUsage `json:"user,omitempty"`
+}
+
+type QuotaUsage struct {
+	Used int64 `json:"used"`
+	// Limit is -1 when the quota is unlimited.
+	Limit int64 `json:"limit"`
+}
+
+// DashboardExportLine is a line of the NDJSON export of all dashboards.
+type DashboardExportLine struct {
+	UID       string `json:"uid"`
+	FolderUID string `json:"folderUid"`
+	// FolderTitle is only read when importing a bundle, to name the folder
+	// if it has to be created.
+	FolderTitle string           `json:"folderTitle,omitempty"`
+	Dashboard   *simplejson.Json `json:"dashboard"`
+}
+
+// DashboardsModifiedSince lists the dashboards saved or deleted after a point
+// in time.
+type DashboardsModifiedSince struct {
+	Modified []ModifiedDashboard `json:"modified"`
+	Deleted  []DeletedDashboard  `json:"deleted"`
+	// Until is the time of the check, to pass as ts when polling next.
+	Until time.Time `json:"until"`
+}
+
+type ModifiedDashboard struct {
+	UID     string    `json:"uid"`
+	Version int       `json:"version"`
+	Updated time.Time `json:"updated"`
+}
+
+type DeletedDashboard struct {
+	UID     string    `json:"uid"`
+	Deleted time.Time `json:"deleted"`
+}
+
+// DashboardImportBundleResult reports the outcome of importing a bundle of
+// dashboards.
+type DashboardImportBundleResult struct {
+	Results []BulkDashboardResult `json:"results"`
+	// QuotaReached is set when the import stopped because the dashboard quota
+	// was reached.
+	QuotaReached bool `json:"quotaReached"`
+}
+
+// DashboardSlugCandidate is one of the dashboards sharing a slug.
+type DashboardSlugCandidate struct {
+	UID       string `json:"uid"`
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid"`
+	URL       string `json:"url"`
+}
+
+type LintDashboardCommand struct {
+	// Dashboard is the dashboard JSON as a string, as for the validate endpoint.
+	Dashboard string `json:"dashboard"`
+	// Rules limits the rules to run, all rules run when empty.
+	Rules []string `json:"rules"`
+}
+
+type DashboardLintResult struct {
+	Warnings []DashboardLintWarning `json:"warnings"`
+}
+
+type DashboardLintWarning struct {
+	// Path is the JSON path of the offending field, e.g. panels[3].title.
+	Path string `json:"path"`
+	Rule string `json:"rule"`
+	// Severity is one of error, warning or info.
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// DashboardProtection marks a dashboard as protected against accidental
+// deletion.
+type DashboardProtection struct {
+	Protected bool `json:"protected"`
+}
+
+// DashboardSaveWarning notes a side effect of saving a dashboard that the
+// save didn't fail for.
+type DashboardSaveWarning struct {
+	// Code is libraryPanelDisconnected for a library panel the dashboard no
+	// longer uses.
+	Code             string `json:"code"`
+	Message          string `json:"message"`
+	LibraryPanelUID  string `json:"libraryPanelUid,omitempty"`
+	LibraryPanelName string `json:"libraryPanelName,omitempty"`
+}
+
+// DashboardVersionsExportManifest describes the versions in a version history
+// export archive.
+type DashboardVersionsExportManifest struct {
+	UID      string                         `json:"uid"`
+	Title    string                         `json:"title"`
+	Versions []DashboardVersionsExportEntry `json:"versions"`
+}
+
+// DashboardChangelogEntry is a version of a dashboard in its changelog.
+type DashboardChangelogEntry struct {
+	Version   int       `json:"version"`
+	Created   time.Time `json:"created"`
+	CreatedBy string    `json:"createdBy"`
+	Message   string    `json:"message"`
+	// Summary describes the changes since the previous version in one line.
+	Summary string `json:"summary"`
+}
+
+type DashboardVersionsExportEntry struct {
+	// File is the name of the file holding the dashboard JSON of the version.
+	File      string    `json:"file"`
+	Version   int       `json:"version"`
+	Created   time.Time `json:"created"`
+	CreatedBy string    `json:"createdBy"`
+	Message   string    `json:"message"`
 }
@@ -3,6 +3,7 @@ package dtos
 import (
 	"time"
 
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 )
 
@@ -35,3 +36,53 @@ type FolderSearchHit struct {
 	Title     string `json:"title"`
 	ParentUID string `json:"parentUid,omitempty"`
 }
+
+type FolderStructureViolation struct {
+	FolderUID string `json:"folderUid,omitempty"`
+	Path      string `json:"path"`
+	Rule      string `json:"rule"`
+	Message   string `json:"message"`
+}
+
+type FolderVariableDrift struct {
+	Name        string                     `json:"name"`
+	Definitions []FolderVariableDefinition `json:"definitions"`
+}
+
+type FolderVariableDefinition struct {
+	// Definition is the variable model without its current value and resolved options.
+	Definition *simplejson.Json          `json:"definition"`
+	Dashboards []FolderVariableDashboard `json:"dashboards"`
+}
+
+type FolderVariableDashboard struct {
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+}
+
+type AlignFolderVariableCommand struct {
+	Name       string           `json:"name" binding:"Required"`
+	Definition *simplejson.Json `json:"definition" binding:"Required"`
+	DryRun     bool             `json:"dryRun"`
+}
+
+// FolderExportDashboard is a dashboard as stored in a folder export archive.
+type FolderExportDashboard struct {
+	Meta      FolderExportDashboardMeta `json:"meta"`
+	Dashboard *simplejson.Json          `json:"dashboard"`
+}
+
+type FolderExportDashboardMeta struct {
+	UID       string    `json:"uid"`
+	Title     string    `json:"title"`
+	FolderUID string    `json:"folderUid"`
+	Version   int       `json:"version"`
+	Created   time.Time `json:"created"`
+	Updated   time.Time `json:"updated"`
+}
+
+// SetFolderHomeDashboardCommand sets the landing dashboard of a folder. An
+// empty uid unsets it.
+type SetFolderHomeDashboardCommand struct {
+	DashboardUID string `json:"dashboardUid"`
+}
@@ -1,5 +1,7 @@
 package dtos
 
+import "github.com/grafana/grafana/pkg/components/simplejson"
+
 type UpdateOrgForm struct {
 	Name string `json:"name" binding:"Required"`
 }
@@ -12,3 +14,8 @@ type UpdateOrgAddressForm struct {
 	State    string `json:"state"`
 	Country  string `json:"country"`
 }
+
+type OrgDashboardVariables struct {
+	// Variables are template variable definitions added to every dashboard of the org.
+	Variables []*simplejson.Json `json:"variables"`
+}
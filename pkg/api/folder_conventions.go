@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+// Rules reported by the folder structure check.
+const (
+	folderRuleMaxDepth         = "maxDepth"
+	folderRuleRequiredTopLevel = "requiredTopLevelFolder"
+	folderRuleNamePattern      = "namePattern"
+)
+
+// swagger:route GET /folders/structure-violations folders getFolderStructureViolations
+//
+// Check the folder tree against the configured conventions.
+//
+// Walks the folder tree of the organization and reports every folder breaking one of the conventions
+// configured in the `folder_conventions` section: maximum depth, required top level folders and
+// folder naming.
+//
+// Responses:
+// 200: folderStructureViolationsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetFolderStructureViolations(c *contextmodel.ReqContext) response.Response {
+	violations, err := hs.checkFolderConventions(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, violations)
+}
+
+func (hs *HTTPServer) checkFolderConventions(ctx context.Context, signedInUser *user.SignedInUser) ([]dtos.FolderStructureViolation, error) {
+	conventions := hs.Cfg.FolderConventions
+	violations := make([]dtos.FolderStructureViolation, 0)
+
+	var walk func(parentUID string, path []string) ([]*folder.Folder, error)
+	walk = func(parentUID string, path []string) ([]*folder.Folder, error) {
+		children, err := hs.folderService.GetChildren(ctx, &folder.GetChildrenQuery{
+			UID:          parentUID,
+			OrgID:        signedInUser.GetOrgID(),
+			SignedInUser: signedInUser,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			childPath := append(append([]string{}, path...), child.Title)
+			if conventions.MaxDepth > 0 && len(childPath) > conventions.MaxDepth {
+				violations = append(violations, dtos.FolderStructureViolation{
+					FolderUID: child.UID,
+					Path:      strings.Join(childPath, "/"),
+					Rule:      folderRuleMaxDepth,
+					Message:   fmt.Sprintf("folder is nested %d levels deep, the maximum is %d", len(childPath), conventions.MaxDepth),
+				})
+			}
+			if conventions.NamePattern != nil && !conventions.NamePattern.MatchString(child.Title) {
+				violations = append(violations, dtos.FolderStructureViolation{
+					FolderUID: child.UID,
+					Path:      strings.Join(childPath, "/"),
+					Rule:      folderRuleNamePattern,
+					Message:   fmt.Sprintf("folder title does not match %q", conventions.NamePattern.String()),
+				})
+			}
+
+			if _, err := walk(child.UID, childPath); err != nil {
+				return nil, err
+			}
+		}
+		return children, nil
+	}
+
+	topLevel, err := walk("", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(topLevel))
+	for _, f := range topLevel {
+		existing[f.Title] = true
+	}
+	for _, title := range conventions.RequiredTopLevelFolders {
+		if !existing[title] {
+			violations = append(violations, dtos.FolderStructureViolation{
+				Path:    title,
+				Rule:    folderRuleRequiredTopLevel,
+				Message: fmt.Sprintf("required top level folder %q does not exist", title),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// swagger:response folderStructureViolationsResponse
+type FolderStructureViolationsResponse struct {
+	// in: body
+	Body []dtos.FolderStructureViolation `json:"body"`
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+type folderTreeFake struct {
+	*foldertest.FakeService
+	children map[string][]*folder.Folder
+}
+
+func (f *folderTreeFake) GetChildren(ctx context.Context, cmd *folder.GetChildrenQuery) ([]*folder.Folder, error) {
+	return f.children[cmd.UID], nil
+}
+
+func TestCheckFolderConventions(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.FolderConventions = setting.FolderConventionSettings{
+		MaxDepth:                2,
+		RequiredTopLevelFolders: []string{"Teams", "Platform"},
+		NamePattern:             regexp.MustCompile(`^[A-Z]`),
+	}
+	hs := &HTTPServer{
+		Cfg: cfg,
+		folderService: &folderTreeFake{
+			FakeService: foldertest.NewFakeService(),
+			children: map[string][]*folder.Folder{
+				"":       {{UID: "teams", Title: "Teams"}},
+				"teams":  {{UID: "team-a", Title: "team-a"}},
+				"team-a": {{UID: "deep", Title: "Deep"}},
+			},
+		},
+	}
+
+	violations, err := hs.checkFolderConventions(context.Background(), &user.SignedInUser{OrgID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []dtos.FolderStructureViolation{
+		{FolderUID: "team-a", Path: "Teams/team-a", Rule: folderRuleNamePattern, Message: `folder title does not match "^[A-Z]"`},
+		{FolderUID: "deep", Path: "Teams/team-a/Deep", Rule: folderRuleMaxDepth, Message: "folder is nested 3 levels deep, the maximum is 2"},
+		{Path: "Platform", Rule: folderRuleRequiredTopLevel, Message: `required top level folder "Platform" does not exist`},
+	}, violations)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// swagger:route GET /folders/{folder_uid}/export folders exportFolderDashboards
+//
+// Export the dashboards of a folder.
+//
+// Returns a zip archive holding one <uid>.json file per dashboard of the folder the signed in user can
+// view, with the current dashboard JSON and its uid, title, folder, version and timestamps. Dashboards of
+// subfolders are not included.
+//
+// Produces:
+// - application/zip
+//
+// Responses:
+// 200: folderExportResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) ExportFolderDashboards(c *contextmodel.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	if _, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser}); err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	dashes, err := hs.readableDashboards(c, uid)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	exported := make([]*dashboards.Dashboard, 0, len(dashes))
+	for _, dash := range dashes {
+		guardian, err := guardian.NewByDashboard(c.Req.Context(), dash, c.SignedInUser.GetOrgID(), c.SignedInUser)
+		if err != nil {
+			return response.Err(err)
+		}
+		if canView, err := guardian.CanView(); err != nil || !canView {
+			continue
+		}
+		exported = append(exported, dash)
+	}
+
+	return &folderExportResponse{folderUID: uid, dashboards: exported}
+}
+
+// folderExportResponse streams the dashboards of a folder as a zip archive.
+type folderExportResponse struct {
+	folderUID  string
+	dashboards []*dashboards.Dashboard
+}
+
+func (r *folderExportResponse) Status() int {
+	return http.StatusOK
+}
+
+func (r *folderExportResponse) Body() []byte {
+	return nil
+}
+
+func (r *folderExportResponse) WriteTo(ctx *contextmodel.ReqContext) {
+	ctx.Resp.Header().Set("Content-Type", "application/zip")
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, r.folderUID))
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	if err := writeFolderExport(ctx.Resp, r.dashboards); err != nil {
+		ctx.Logger.Error("Error writing folder export", "folder", r.folderUID, "err", err)
+	}
+}
+
+func writeFolderExport(w http.ResponseWriter, dashes []*dashboards.Dashboard) error {
+	archive := zip.NewWriter(w)
+	for _, dash := range dashes {
+		file, err := archive.Create(dash.UID + ".json")
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(file)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(dtos.FolderExportDashboard{
+			Meta: dtos.FolderExportDashboardMeta{
+				UID:       dash.UID,
+				Title:     dash.Title,
+				FolderUID: dash.FolderUID,
+				Version:   dash.Version,
+				Created:   dash.Created,
+				Updated:   dash.Updated,
+			},
+			Dashboard: dash.Data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// swagger:parameters exportFolderDashboards
+type ExportFolderDashboardsParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+}
+
+// swagger:response folderExportResponse
+type FolderExportResponse struct {
+	// in: body
+	Body []byte `json:"body"`
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+)
+
+func TestWriteFolderExport(t *testing.T) {
+	dashes := []*dashboards.Dashboard{
+		{UID: "a", Title: "A", FolderUID: "f", Version: 3, Data: simplejson.NewFromAny(map[string]any{"title": "A"})},
+		{UID: "b", Title: "B", FolderUID: "f", Version: 1, Data: simplejson.NewFromAny(map[string]any{"title": "B"})},
+	}
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, writeFolderExport(rec, dashes))
+
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 2)
+	assert.Equal(t, "a.json", archive.File[0].Name)
+	assert.Equal(t, "b.json", archive.File[1].Name)
+
+	file, err := archive.File[0].Open()
+	require.NoError(t, err)
+	var exported dtos.FolderExportDashboard
+	require.NoError(t, json.NewDecoder(file).Decode(&exported))
+	require.NoError(t, file.Close())
+
+	assert.Equal(t, dtos.FolderExportDashboardMeta{UID: "a", Title: "A", FolderUID: "f", Version: 3}, exported.Meta)
+	assert.Equal(t, "A", exported.Dashboard.Get("title").MustString())
+}
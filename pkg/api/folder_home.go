@@ -0,0 +1,122 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const folderHomeDashboardNamespace = "folder-home-dashboard"
+
+// swagger:route GET /folders/{folder_uid}/home folders getFolderHomeDashboard
+//
+// Get the landing dashboard of a folder.
+//
+// Returns the dashboard set as the landing dashboard of the folder, the same way as getting it by uid. When
+// the folder has no landing dashboard, or it was deleted or moved out of the folder, the home dashboard of
+// the user is returned instead.
+//
+// Responses:
+// 200: dashboardResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetFolderHomeDashboard(c *contextmodel.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	if _, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser}); err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	dashUID, ok, err := kvstore.WithNamespace(hs.kvStore, c.SignedInUser.GetOrgID(), folderHomeDashboardNamespace).Get(c.Req.Context(), uid)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get the folder landing dashboard", err)
+	}
+	if !ok {
+		return hs.GetHomeDashboard(c)
+	}
+
+	dash, err := hs.DashboardService.GetDashboard(c.Req.Context(), &dashboards.GetDashboardQuery{UID: dashUID, OrgID: c.SignedInUser.GetOrgID()})
+	switch {
+	case errors.Is(err, dashboards.ErrDashboardNotFound):
+		return hs.GetHomeDashboard(c)
+	case err != nil:
+		return response.Error(http.StatusInternalServerError, "Failed to get the folder landing dashboard", err)
+	case dash.FolderUID != uid:
+		return hs.GetHomeDashboard(c)
+	}
+
+	c.Req = web.SetURLParams(c.Req, map[string]string{":uid": dash.UID})
+	return hs.GetDashboard(c)
+}
+
+// swagger:route PUT /folders/{folder_uid}/home folders setFolderHomeDashboard
+//
+// Set the landing dashboard of a folder.
+//
+// The dashboard must be in the folder. An empty dashboardUid unsets the landing dashboard.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) SetFolderHomeDashboard(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.SetFolderHomeDashboardCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	uid := web.Params(c.Req)[":uid"]
+	if _, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser}); err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	store := kvstore.WithNamespace(hs.kvStore, c.SignedInUser.GetOrgID(), folderHomeDashboardNamespace)
+	if cmd.DashboardUID == "" {
+		if err := store.Del(c.Req.Context(), uid); err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to unset the folder landing dashboard", err)
+		}
+		return response.Success("Folder landing dashboard unset")
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, cmd.DashboardUID)
+	if rsp != nil {
+		return rsp
+	}
+	if dash.FolderUID != uid {
+		return response.Error(http.StatusBadRequest, "Dashboard is not in the folder", nil)
+	}
+
+	if err := store.Set(c.Req.Context(), uid, dash.UID); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to set the folder landing dashboard", err)
+	}
+	return response.Success("Folder landing dashboard set")
+}
+
+// swagger:parameters getFolderHomeDashboard
+type GetFolderHomeDashboardParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+}
+
+// swagger:parameters setFolderHomeDashboard
+type SetFolderHomeDashboardParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+	// in:body
+	// required:true
+	Body dtos.SetFolderHomeDashboardCommand
+}
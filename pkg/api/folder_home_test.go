@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	pref "github.com/grafana/grafana/pkg/services/preference"
+	"github.com/grafana/grafana/pkg/services/preference/preftest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestFolderHomeDashboard(t *testing.T) {
+	newDash := func(uid, folderUID string) *dashboards.Dashboard {
+		dash := dashboards.NewDashboard(uid)
+		dash.ID = 1
+		dash.UID = uid
+		dash.OrgID = 1
+		dash.FolderUID = folderUID
+		return dash
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		dashSvc := dashboards.NewFakeDashboardService(t)
+		for _, dash := range []*dashboards.Dashboard{newDash("landing", "f1"), newDash("elsewhere", "f2")} {
+			dash := dash
+			dashSvc.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool { return q.UID == dash.UID })).Return(dash, nil).Maybe()
+		}
+		hs.DashboardService = dashSvc
+		hs.folderService = &foldertest.FakeService{ExpectedFolder: &folder.Folder{UID: "f1"}}
+		hs.preferenceService = &preftest.FakePreferenceService{ExpectedPreference: &pref.Preference{}}
+
+		hs.kvStore = kvstore.NewFakeKVStore()
+		hs.log = log.New("test-logger")
+		hs.Cfg = setting.NewCfg()
+		hs.Cfg.HomePage = "/org-home"
+		hs.AccessControl = acimpl.ProvideAccessControl(hs.Cfg)
+		hs.dashboardProvisioningService = mockDashboardProvisioningService{}
+		guardian.InitAccessControlGuardian(hs.Cfg, hs.AccessControl, hs.DashboardService)
+	})
+
+	usr := userWithPermissions(1, []accesscontrol.Permission{
+		{Action: dashboards.ActionFoldersRead, Scope: dashboards.ScopeFoldersAll},
+		{Action: dashboards.ActionFoldersWrite, Scope: dashboards.ScopeFoldersAll},
+		{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsAll},
+	})
+	setHome := func(t *testing.T, dashUID string) int {
+		t.Helper()
+		req := server.NewRequest(http.MethodPut, "/api/folders/f1/home", strings.NewReader(`{"dashboardUid": "`+dashUID+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, usr))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+	getHome := func(t *testing.T) map[string]json.RawMessage {
+		t.Helper()
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest("/api/folders/f1/home"), usr))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		body := map[string]json.RawMessage{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.NoError(t, res.Body.Close())
+		return body
+	}
+
+	t.Run("should fall back to the home dashboard without a landing dashboard", func(t *testing.T) {
+		assert.JSONEq(t, `"/org-home"`, string(getHome(t)["redirectUri"]))
+	})
+
+	t.Run("should return the landing dashboard of the folder", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, setHome(t, "landing"))
+
+		var data dtos.DashboardFullWithMeta
+		body, err := json.Marshal(getHome(t))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &data))
+		assert.Equal(t, "landing", data.Dashboard.Get("title").MustString())
+	})
+
+	t.Run("should reject a dashboard of another folder", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, setHome(t, "elsewhere"))
+	})
+
+	t.Run("should fall back to the home dashboard once unset", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, setHome(t, ""))
+		assert.JSONEq(t, `"/org-home"`, string(getHome(t)["redirectUri"]))
+	})
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/grafana/grafana/pkg/api/apierrors"
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// variableStateKeys are template variable properties that hold the selected
+// value and resolved options rather than the definition of the variable.
+var variableStateKeys = []string{"current", "options"}
+
+// swagger:route GET /folders/{folder_uid}/variable-drift folders getFolderVariableDrift
+//
+// Find template variables defined inconsistently across a folder.
+//
+// Returns the template variables that appear in several dashboards of the folder with different
+// definitions, with the dashboards using each definition. The current value and the resolved options of
+// a variable are not compared.
+//
+// Responses:
+// 200: folderVariableDriftResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetFolderVariableDrift(c *contextmodel.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	if _, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser}); err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	dashes, err := hs.readableDashboards(c, uid)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	// variable name -> encoded definition -> definition and the dashboards using it
+	byName := make(map[string]map[string]*dtos.FolderVariableDefinition)
+	for _, dash := range dashes {
+		for _, variable := range dash.Data.GetPath("templating", "list").MustArray() {
+			definition := variableDefinition(simplejson.NewFromAny(variable))
+			name := definition.Get("name").MustString()
+			key, err := definition.Encode()
+			if name == "" || err != nil {
+				continue
+			}
+
+			if _, ok := byName[name]; !ok {
+				byName[name] = make(map[string]*dtos.FolderVariableDefinition)
+			}
+			if _, ok := byName[name][string(key)]; !ok {
+				byName[name][string(key)] = &dtos.FolderVariableDefinition{Definition: definition}
+			}
+			entry := byName[name][string(key)]
+			entry.Dashboards = append(entry.Dashboards, dtos.FolderVariableDashboard{UID: dash.UID, Title: dash.Title})
+		}
+	}
+
+	result := make([]dtos.FolderVariableDrift, 0)
+	for name, definitions := range byName {
+		if len(definitions) < 2 {
+			continue
+		}
+		keys := make([]string, 0, len(definitions))
+		for key := range definitions {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		drift := dtos.FolderVariableDrift{Name: name}
+		for _, key := range keys {
+			drift.Definitions = append(drift.Definitions, *definitions[key])
+		}
+		sort.SliceStable(drift.Definitions, func(i, j int) bool {
+			return len(drift.Definitions[i].Dashboards) > len(drift.Definitions[j].Dashboards)
+		})
+		result = append(result, drift)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route POST /folders/{folder_uid}/variable-align folders alignFolderVariable
+//
+// Standardize a template variable across a folder.
+//
+// Replaces the definition of the named template variable in every dashboard of the folder that defines it
+// with the given definition, keeping the current value of each dashboard, and saves the changed
+// dashboards as new versions. With dryRun set nothing is saved.
+//
+// Responses:
+// 200: bulkDashboardResultsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) AlignFolderVariable(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.AlignFolderVariableCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	uid := web.Params(c.Req)[":uid"]
+	if _, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser}); err != nil {
+		return apierrors.ToFolderErrorResponse(err)
+	}
+
+	dashes, err := hs.readableDashboards(c, uid)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to list dashboards", err)
+	}
+
+	cmd.Definition.Set("name", cmd.Name)
+	results := make([]dtos.BulkDashboardResult, 0)
+	for _, dash := range dashes {
+		if !dashboardDefinesVariable(dash.Data, cmd.Name) {
+			continue
+		}
+		results = append(results, hs.bulkUpdateDashboard(c, dash, cmd.DryRun, "Aligned template variable "+cmd.Name, func(data *simplejson.Json) bool {
+			return alignTemplateVariable(data, cmd.Definition)
+		}))
+	}
+
+	return response.JSON(http.StatusOK, results)
+}
+
+// variableDefinition returns a copy of the template variable without the
+// properties describing its current state.
+func variableDefinition(variable *simplejson.Json) *simplejson.Json {
+	definition, err := cloneDashboardJSON(variable)
+	if err != nil {
+		return simplejson.New()
+	}
+	for _, key := range variableStateKeys {
+		definition.Del(key)
+	}
+	return definition
+}
+
+func dashboardDefinesVariable(data *simplejson.Json, name string) bool {
+	for _, variable := range data.GetPath("templating", "list").MustArray() {
+		if simplejson.NewFromAny(variable).Get("name").MustString() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// alignTemplateVariable replaces the definition of the template variable
+// named like definition, keeping its current state. It reports whether the
+// body was changed.
+func alignTemplateVariable(data *simplejson.Json, definition *simplejson.Json) bool {
+	name := definition.Get("name").MustString()
+	expected, err := variableDefinition(definition).Encode()
+	if err != nil {
+		return false
+	}
+
+	list := data.GetPath("templating", "list").MustArray()
+	changed := false
+	for i, item := range list {
+		variable := simplejson.NewFromAny(item)
+		if variable.Get("name").MustString() != name {
+			continue
+		}
+		actual, err := variableDefinition(variable).Encode()
+		if err != nil || string(actual) == string(expected) {
+			continue
+		}
+
+		aligned := variableDefinition(definition)
+		for _, key := range variableStateKeys {
+			if value, ok := variable.CheckGet(key); ok {
+				aligned.Set(key, value.Interface())
+			}
+		}
+		list[i] = aligned.Interface()
+		changed = true
+	}
+
+	if changed {
+		data.SetPath([]string{"templating", "list"}, list)
+	}
+	return changed
+}
+
+// swagger:parameters getFolderVariableDrift
+type GetFolderVariableDriftParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+}
+
+// swagger:parameters alignFolderVariable
+type AlignFolderVariableParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+	// in:body
+	// required:true
+	Body dtos.AlignFolderVariableCommand
+}
+
+// swagger:response folderVariableDriftResponse
+type FolderVariableDriftResponse struct {
+	// in: body
+	Body []dtos.FolderVariableDrift `json:"body"`
+}
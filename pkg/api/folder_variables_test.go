@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestAlignTemplateVariable(t *testing.T) {
+	data, err := simplejson.NewJson([]byte(`{
+		"templating": {"list": [
+			{"name": "namespace", "type": "query", "query": "label_values(namespace)", "current": {"value": "prod"}},
+			{"name": "pod", "type": "query", "query": "label_values(pod)"}
+		]}
+	}`))
+	require.NoError(t, err)
+	definition, err := simplejson.NewJson([]byte(`{"name": "namespace", "type": "query", "query": "label_values(kube_namespace_labels, namespace)", "includeAll": true}`))
+	require.NoError(t, err)
+
+	assert.True(t, dashboardDefinesVariable(data, "namespace"))
+	assert.False(t, dashboardDefinesVariable(data, "cluster"))
+
+	require.True(t, alignTemplateVariable(data, definition))
+	list := data.GetPath("templating", "list")
+	assert.Equal(t, "label_values(kube_namespace_labels, namespace)", list.GetIndex(0).Get("query").MustString())
+	assert.True(t, list.GetIndex(0).Get("includeAll").MustBool())
+	assert.Equal(t, "prod", list.GetIndex(0).GetPath("current", "value").MustString())
+	assert.Equal(t, "label_values(pod)", list.GetIndex(1).Get("query").MustString())
+
+	assert.False(t, alignTemplateVariable(data, definition))
+}
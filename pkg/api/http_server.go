@@ -47,8 +47,13 @@ import (
 	"github.com/grafana/grafana/pkg/services/cleanup"
 	"github.com/grafana/grafana/pkg/services/contexthandler"
 	"github.com/grafana/grafana/pkg/services/correlations"
+	"github.com/grafana/grafana/pkg/services/dashboardaudit"
+	"github.com/grafana/grafana/pkg/services/dashboarddraft"
+	"github.com/grafana/grafana/pkg/services/dashboardlineage"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
+	"github.com/grafana/grafana/pkg/services/dashboardtrash"
+	"github.com/grafana/grafana/pkg/services/dashboardusage"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
 	"github.com/grafana/grafana/pkg/services/datasourceproxy"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -192,6 +197,14 @@ type HTTPServer struct {
 	apiKeyService                apikey.Service
 	kvStore                      kvstore.KVStore
 	pluginsCDNService            *pluginscdn.Service
+	dashboardIndex               *dashboardIndex
+	lineageStore                 *dashboardlineage.Store
+	trashStore                   *dashboardtrash.Store
+	dashboardMutators            []DashboardMutator
+	draftStore                   *dashboarddraft.Store
+	usageStore                   *dashboardusage.Store
+	DashboardAuditSink           dashboardaudit.Sink
+	homeDashboard                homeDashboardCache
 
 	userService          user.Service
 	tempUserService      tempUser.Service
@@ -347,11 +360,20 @@ func ProvideHTTPServer(opts ServerOptions, cfg *setting.Cfg, routeRegister routi
 		statsService:                 statsService,
 		authnService:                 authnService,
 		pluginsCDNService:            pluginsCDNService,
+		dashboardIndex:               newDashboardIndex(dashboardIndexMaxEntries),
+		lineageStore:                 dashboardlineage.NewStore(kvStore),
+		trashStore:                   dashboardtrash.NewStore(kvStore, cfg.DashboardTrashRetention),
+		draftStore:                   dashboarddraft.NewStore(kvStore),
+		usageStore:                   dashboardusage.NewStore(kvStore),
+		DashboardAuditSink:           dashboardaudit.NoopSink{},
 		starApi:                      starApi,
 		promRegister:                 promRegister,
 		clientConfigProvider:         clientConfigProvider,
 		namespacer:                   request.GetNamespaceMapper(cfg),
 	}
+	if cfg.DashboardAuditLog {
+		hs.DashboardAuditSink = dashboardaudit.NewLogSink(log.New("dashboard.audit"))
+	}
 	if hs.Listener != nil {
 		hs.log.Debug("Using provided listener")
 	}
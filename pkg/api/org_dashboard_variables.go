@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	orgDashboardVariablesNamespace = "org-dashboard-variables"
+	orgDashboardVariablesKey       = "variables"
+
+	// orgVariableKey marks template variables injected from the org wide
+	// definitions, so that they are not stored when the dashboard is saved.
+	orgVariableKey = "orgVariable"
+	// skipOrgVariablesKey opts a dashboard out of org wide variables.
+	skipOrgVariablesKey = "skipOrgVariables"
+)
+
+// swagger:route GET /org/dashboard-variables org getOrgDashboardVariables
+//
+// Get the org wide dashboard variables.
+//
+// Responses:
+// 200: orgDashboardVariablesResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetOrgDashboardVariables(c *contextmodel.ReqContext) response.Response {
+	variables, err := hs.getOrgDashboardVariables(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get org dashboard variables", err)
+	}
+	return response.JSON(http.StatusOK, dtos.OrgDashboardVariables{Variables: variables})
+}
+
+// swagger:route PUT /org/dashboard-variables org updateOrgDashboardVariables
+//
+// Update the org wide dashboard variables.
+//
+// The variables are added to the templating of every dashboard of the org when it is served, unless the
+// dashboard defines a variable with the same name or sets skipOrgVariables. They are never stored in the
+// dashboards themselves.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateOrgDashboardVariables(c *contextmodel.ReqContext) response.Response {
+	cmd := dtos.OrgDashboardVariables{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	names := make(map[string]bool, len(cmd.Variables))
+	for _, variable := range cmd.Variables {
+		name := variable.Get("name").MustString()
+		if name == "" {
+			return response.Error(http.StatusBadRequest, "Every variable must have a name", nil)
+		}
+		if names[name] {
+			return response.Error(http.StatusBadRequest, fmt.Sprintf("Variable %q is defined more than once", name), nil)
+		}
+		names[name] = true
+		variable.Del(orgVariableKey)
+	}
+
+	value, err := json.Marshal(cmd.Variables)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Invalid variables", err)
+	}
+	if err := hs.kvStore.Set(c.Req.Context(), c.SignedInUser.GetOrgID(), orgDashboardVariablesNamespace, orgDashboardVariablesKey, string(value)); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to update org dashboard variables", err)
+	}
+
+	return response.Success("Org dashboard variables updated")
+}
+
+func (hs *HTTPServer) getOrgDashboardVariables(ctx context.Context, orgID int64) ([]*simplejson.Json, error) {
+	variables := []*simplejson.Json{}
+	if hs.kvStore == nil {
+		return variables, nil
+	}
+
+	value, ok, err := kvstore.WithNamespace(hs.kvStore, orgID, orgDashboardVariablesNamespace).Get(ctx, orgDashboardVariablesKey)
+	if err != nil || !ok {
+		return variables, err
+	}
+	if err := json.Unmarshal([]byte(value), &variables); err != nil {
+		return nil, errors.New("stored org dashboard variables are invalid")
+	}
+	return variables, nil
+}
+
+// injectOrgDashboardVariables adds the org wide variables to the templating of
+// the dashboard body and returns the names of the injected variables.
+// Variables the dashboard already defines are left untouched.
+func (hs *HTTPServer) injectOrgDashboardVariables(ctx context.Context, orgID int64, data *simplejson.Json) ([]string, error) {
+	if data.Get(skipOrgVariablesKey).MustBool() {
+		return nil, nil
+	}
+
+	variables, err := hs.getOrgDashboardVariables(ctx, orgID)
+	if err != nil || len(variables) == 0 {
+		return nil, err
+	}
+	return injectTemplateVariables(data, variables), nil
+}
+
+func injectTemplateVariables(data *simplejson.Json, variables []*simplejson.Json) []string {
+	list := data.GetPath("templating", "list").MustArray()
+	defined := make(map[string]bool, len(list))
+	for _, variable := range list {
+		defined[simplejson.NewFromAny(variable).Get("name").MustString()] = true
+	}
+
+	var injected []string
+	for _, variable := range variables {
+		name := variable.Get("name").MustString()
+		if defined[name] {
+			continue
+		}
+		variable.Set(orgVariableKey, true)
+		list = append(list, variable.Interface())
+		injected = append(injected, name)
+	}
+
+	if len(injected) > 0 {
+		data.SetPath([]string{"templating", "list"}, list)
+	}
+	return injected
+}
+
+// stripOrgDashboardVariables removes injected org wide variables from a
+// dashboard body about to be saved.
+func stripOrgDashboardVariables(data *simplejson.Json) {
+	list, err := data.GetPath("templating", "list").Array()
+	if err != nil {
+		return
+	}
+
+	kept := make([]any, 0, len(list))
+	for _, variable := range list {
+		if simplejson.NewFromAny(variable).Get(orgVariableKey).MustBool() {
+			continue
+		}
+		kept = append(kept, variable)
+	}
+	if len(kept) != len(list) {
+		data.SetPath([]string{"templating", "list"}, kept)
+	}
+}
+
+// swagger:parameters updateOrgDashboardVariables
+type UpdateOrgDashboardVariablesParams struct {
+	// in:body
+	// required:true
+	Body dtos.OrgDashboardVariables
+}
+
+// swagger:response orgDashboardVariablesResponse
+type OrgDashboardVariablesResponse struct {
+	// in: body
+	Body dtos.OrgDashboardVariables `json:"body"`
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+func TestInjectOrgDashboardVariables(t *testing.T) {
+	ctx := context.Background()
+	kv := kvstore.NewFakeKVStore()
+	require.NoError(t, kv.Set(ctx, 1, orgDashboardVariablesNamespace, orgDashboardVariablesKey,
+		`[{"name": "environment", "type": "custom", "query": "dev,prod"}, {"name": "region", "type": "custom"}]`))
+	hs := &HTTPServer{kvStore: kv}
+
+	t.Run("should inject variables the dashboard does not define", func(t *testing.T) {
+		data, err := simplejson.NewJson([]byte(`{"templating": {"list": [{"name": "region", "type": "query"}]}}`))
+		require.NoError(t, err)
+
+		injected, err := hs.injectOrgDashboardVariables(ctx, 1, data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"environment"}, injected)
+
+		list := data.GetPath("templating", "list")
+		require.Len(t, list.MustArray(), 2)
+		assert.Equal(t, "query", list.GetIndex(0).Get("type").MustString())
+		assert.True(t, list.GetIndex(1).Get(orgVariableKey).MustBool())
+
+		stripOrgDashboardVariables(data)
+		require.Len(t, data.GetPath("templating", "list").MustArray(), 1)
+		assert.Equal(t, "region", data.GetPath("templating", "list").GetIndex(0).Get("name").MustString())
+	})
+
+	t.Run("should skip dashboards that opted out", func(t *testing.T) {
+		data, err := simplejson.NewJson([]byte(`{"skipOrgVariables": true}`))
+		require.NoError(t, err)
+
+		injected, err := hs.injectOrgDashboardVariables(ctx, 1, data)
+		require.NoError(t, err)
+		assert.Empty(t, injected)
+		_, ok := data.CheckGet("templating")
+		assert.False(t, ok)
+	})
+
+	t.Run("should not inject variables of other orgs", func(t *testing.T) {
+		injected, err := hs.injectOrgDashboardVariables(ctx, 2, simplejson.New())
+		require.NoError(t, err)
+		assert.Empty(t, injected)
+	})
+}
@@ -37,6 +37,11 @@ type OKResponse struct {
 	Body SuccessResponseBody `json:"body"`
 }
 
+// A NotModifiedResponse is returned without body when the resource matches the ETag sent in If-None-Match.
+//
+// swagger:response notModifiedResponse
+type NotModifiedResponse struct{}
+
 // ForbiddenError is returned if the user/token has insufficient permissions to access the requested resource.
 //
 // swagger:response forbiddenError
@@ -67,11 +72,26 @@ type PreconditionFailedError GenericError
 // swagger:response unprocessableEntityError
 type UnprocessableEntityError GenericError
 
+// ContentTooLargeError is returned when a request asks for more than the server allows at once.
+//
+// swagger:response contentTooLargeError
+type ContentTooLargeError GenericError
+
+// TooManyRequestsError is returned when requests are sent more often than allowed.
+//
+// swagger:response tooManyRequestsError
+type TooManyRequestsError GenericError
+
 // InternalServerError is a general error indicating something went wrong internally.
 //
 // swagger:response internalServerError
 type InternalServerError GenericError
 
+// ServiceUnavailableError is returned when the server can't handle the request yet and it should be retried.
+//
+// swagger:response serviceUnavailableError
+type ServiceUnavailableError GenericError
+
 // UnauthorizedError is returned when the request is not authenticated.
 //
 // swagger:response unauthorisedError
@@ -23,6 +23,7 @@ const (
 	DiffJSON DiffType = iota
 	DiffBasic
 	DiffDelta
+	DiffSemantic
 )
 
 type Options struct {
@@ -30,6 +31,12 @@ type Options struct {
 	Base     DiffTarget
 	New      DiffTarget
 	DiffType DiffType
+	// Page and PageSize select a page of the changes of a semantic diff,
+	// PageSize 0 returns all of them.
+	Page     int
+	PageSize int
+	// MaxLines caps the length of a basic diff, 0 for no cap.
+	MaxLines int
 }
 
 type DiffTarget struct {
@@ -40,6 +47,10 @@ type DiffTarget struct {
 
 type Result struct {
 	Delta []byte `json:"delta"`
+	// TotalChanges is the number of changes of a semantic diff, on all pages.
+	TotalChanges int `json:"totalChanges,omitempty"`
+	// Truncated is set when a basic diff was cut at MaxLines.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 func ParseDiffType(diff string) DiffType {
@@ -50,10 +61,22 @@ func ParseDiffType(diff string) DiffType {
 		return DiffBasic
 	case "delta":
 		return DiffDelta
+	case "semantic":
+		return DiffSemantic
 	}
 	return DiffBasic
 }
 
+// ParseDiffTypeStrict is like ParseDiffType but returns
+// ErrUnsupportedDiffType for unknown types instead of the basic diff.
+func ParseDiffTypeStrict(diff string) (DiffType, error) {
+	switch diff {
+	case "json", "basic", "delta", "semantic":
+		return ParseDiffType(diff), nil
+	}
+	return DiffBasic, ErrUnsupportedDiffType
+}
+
 // CompareDashboardVersionsCommand computes the JSON diff of two versions,
 // assigning the delta of the diff to the `Delta` field.
 func CalculateDiff(ctx context.Context, options *Options, baseData, newData *simplejson.Json) (*Result, error) {
@@ -81,11 +104,23 @@ func CalculateDiff(ctx context.Context, options *Options, baseData, newData *sim
 		result.Delta = []byte(jsonOutput)
 
 	case DiffBasic:
-		basicOutput, err := NewBasicFormatter(left).Format(jsonDiff)
+		formatter := NewBasicFormatter(left)
+		formatter.MaxLines = options.MaxLines
+		basicOutput, err := formatter.Format(jsonDiff)
 		if err != nil {
 			return nil, err
 		}
 		result.Delta = basicOutput
+		result.Truncated = formatter.Truncated
+
+	case DiffSemantic:
+		changes := SemanticDiff(baseData, newData)
+		result.TotalChanges = len(changes)
+		semanticOutput, err := json.Marshal(pageChanges(changes, options.Page, options.PageSize))
+		if err != nil {
+			return nil, err
+		}
+		result.Delta = semanticOutput
 
 	default:
 		return nil, ErrUnsupportedDiffType
@@ -94,6 +129,25 @@ func CalculateDiff(ctx context.Context, options *Options, baseData, newData *sim
 	return result, nil
 }
 
+// pageChanges returns the changes of the given page, counting pages from 1.
+func pageChanges(changes []Change, page, pageSize int) []Change {
+	if pageSize <= 0 {
+		return changes
+	}
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * pageSize
+	if start >= len(changes) {
+		return []Change{}
+	}
+	end := start + pageSize
+	if end > len(changes) {
+		end = len(changes)
+	}
+	return changes[start:end]
+}
+
 // getDiff computes the diff of two dashboard versions.
 func getDiff(baseData, newData *simplejson.Json) (any, diff.Diff, error) {
 	leftBytes, err := baseData.Encode()
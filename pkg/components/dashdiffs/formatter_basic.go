@@ -58,6 +58,11 @@ type BasicSummary struct {
 type BasicFormatter struct {
 	jsonDiff *JSONFormatter
 	tpl      *template.Template
+
+	// MaxLines caps the number of lines of the output, 0 for no cap. Blocks
+	// that would exceed it are left out and Truncated is set.
+	MaxLines  int
+	Truncated bool
 }
 
 func NewBasicFormatter(left any) *BasicFormatter {
@@ -85,9 +90,28 @@ func (b *BasicFormatter) Format(d diff.Diff) ([]byte, error) {
 	blocks := bd.Basic(b.jsonDiff.Lines)
 	buf := &bytes.Buffer{}
 
-	err = b.tpl.ExecuteTemplate(buf, "block", blocks)
-	if err != nil {
-		return nil, err
+	if b.MaxLines <= 0 {
+		err = b.tpl.ExecuteTemplate(buf, "block", blocks)
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	// render block by block so the output is only cut between blocks
+	lines := 0
+	for _, block := range blocks {
+		blockBuf := &bytes.Buffer{}
+		if err := b.tpl.ExecuteTemplate(blockBuf, "block", []*BasicBlock{block}); err != nil {
+			return nil, err
+		}
+		blockLines := bytes.Count(blockBuf.Bytes(), []byte("\n"))
+		if lines+blockLines > b.MaxLines {
+			b.Truncated = true
+			break
+		}
+		lines += blockLines
+		buf.Write(blockBuf.Bytes())
 	}
 	return buf.Bytes(), nil
 }
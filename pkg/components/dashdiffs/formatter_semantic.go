@@ -0,0 +1,304 @@
+package dashdiffs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// Kinds of changes reported by the semantic diff.
+const (
+	ChangeDashboard       = "dashboardChanged"
+	ChangePanelAdded      = "panelAdded"
+	ChangePanelRemoved    = "panelRemoved"
+	ChangePanelMoved      = "panelMoved"
+	ChangePanelChanged    = "panelChanged"
+	ChangeTargetAdded     = "targetAdded"
+	ChangeTargetRemoved   = "targetRemoved"
+	ChangeTargetChanged   = "targetChanged"
+	ChangeVariableAdded   = "variableAdded"
+	ChangeVariableRemoved = "variableRemoved"
+	ChangeVariableChanged = "variableChanged"
+)
+
+// semanticIgnoredKeys are dashboard properties that change on every save and
+// are not reported by the semantic diff.
+var semanticIgnoredKeys = map[string]bool{"id": true, "version": true}
+
+// Change is a single change reported by the semantic diff.
+type Change struct {
+	Kind    string `json:"kind"`
+	Path    string `json:"path"`
+	PanelID *int64 `json:"panelId,omitempty"`
+	Before  any    `json:"before,omitempty"`
+	After   any    `json:"after,omitempty"`
+}
+
+// ChangeSummary counts the changes reported by the semantic diff. A panel
+// with several changes is counted once as modified.
+type ChangeSummary struct {
+	PropertiesChanged int `json:"propertiesChanged"`
+	PanelsAdded       int `json:"panelsAdded"`
+	PanelsRemoved     int `json:"panelsRemoved"`
+	PanelsModified    int `json:"panelsModified"`
+	VariablesAdded    int `json:"variablesAdded"`
+	VariablesRemoved  int `json:"variablesRemoved"`
+	VariablesModified int `json:"variablesModified"`
+}
+
+// semanticPanel is a panel together with the row it is nested in, if any.
+type semanticPanel struct {
+	data  *simplejson.Json
+	rowID int64
+}
+
+// SemanticDiff compares two dashboard bodies panel by panel and variable by
+// variable. Changes are grouped by dashboard properties, then panels ordered
+// by id, then variables ordered by name.
+func SemanticDiff(baseData, newData *simplejson.Json) []Change {
+	changes := make([]Change, 0)
+	changes = append(changes, diffDashboardProperties(baseData, newData)...)
+	changes = append(changes, diffPanels(baseData, newData)...)
+	changes = append(changes, diffVariables(baseData, newData)...)
+	return changes
+}
+
+// SummarizeChanges counts the changes returned by SemanticDiff.
+func SummarizeChanges(changes []Change) ChangeSummary {
+	var summary ChangeSummary
+	modified := make(map[int64]bool)
+	for _, change := range changes {
+		switch change.Kind {
+		case ChangeDashboard:
+			summary.PropertiesChanged++
+		case ChangePanelAdded:
+			summary.PanelsAdded++
+		case ChangePanelRemoved:
+			summary.PanelsRemoved++
+		case ChangePanelMoved, ChangePanelChanged, ChangeTargetAdded, ChangeTargetRemoved, ChangeTargetChanged:
+			if change.PanelID != nil && !modified[*change.PanelID] {
+				modified[*change.PanelID] = true
+				summary.PanelsModified++
+			}
+		case ChangeVariableAdded:
+			summary.VariablesAdded++
+		case ChangeVariableRemoved:
+			summary.VariablesRemoved++
+		case ChangeVariableChanged:
+			summary.VariablesModified++
+		}
+	}
+	return summary
+}
+
+// String describes the summary in one line, e.g. "changed 1 property, added
+// 2 panels". It is empty when nothing changed.
+func (s ChangeSummary) String() string {
+	var parts []string
+	add := func(count int, verb, singular, plural string) {
+		switch {
+		case count == 1:
+			parts = append(parts, fmt.Sprintf("%s 1 %s", verb, singular))
+		case count > 1:
+			parts = append(parts, fmt.Sprintf("%s %d %s", verb, count, plural))
+		}
+	}
+	add(s.PropertiesChanged, "changed", "property", "properties")
+	add(s.PanelsAdded, "added", "panel", "panels")
+	add(s.PanelsRemoved, "removed", "panel", "panels")
+	add(s.PanelsModified, "modified", "panel", "panels")
+	add(s.VariablesAdded, "added", "variable", "variables")
+	add(s.VariablesRemoved, "removed", "variable", "variables")
+	add(s.VariablesModified, "modified", "variable", "variables")
+	return strings.Join(parts, ", ")
+}
+
+func diffDashboardProperties(baseData, newData *simplejson.Json) []Change {
+	var changes []Change
+	for _, key := range unionKeys(baseData.MustMap(), newData.MustMap()) {
+		if semanticIgnoredKeys[key] || key == "panels" || key == "templating" {
+			continue
+		}
+		before, after := baseData.Get(key).Interface(), newData.Get(key).Interface()
+		if !jsonEqual(before, after) {
+			changes = append(changes, Change{Kind: ChangeDashboard, Path: key, Before: before, After: after})
+		}
+	}
+	return changes
+}
+
+func diffPanels(baseData, newData *simplejson.Json) []Change {
+	basePanels, newPanels := semanticPanels(baseData), semanticPanels(newData)
+
+	ids := make([]int64, 0, len(basePanels)+len(newPanels))
+	for id := range basePanels {
+		ids = append(ids, id)
+	}
+	for id := range newPanels {
+		if _, ok := basePanels[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var changes []Change
+	for _, id := range ids {
+		panelID := id
+		path := fmt.Sprintf("panels[%d]", id)
+		before, inBase := basePanels[id]
+		after, inNew := newPanels[id]
+		switch {
+		case !inNew:
+			changes = append(changes, Change{Kind: ChangePanelRemoved, Path: path, PanelID: &panelID, Before: before.data.Interface()})
+			continue
+		case !inBase:
+			changes = append(changes, Change{Kind: ChangePanelAdded, Path: path, PanelID: &panelID, After: after.data.Interface()})
+			continue
+		}
+
+		if before.rowID != after.rowID || !jsonEqual(before.data.Get("gridPos").Interface(), after.data.Get("gridPos").Interface()) {
+			changes = append(changes, Change{
+				Kind:    ChangePanelMoved,
+				Path:    path + ".gridPos",
+				PanelID: &panelID,
+				Before:  before.data.Get("gridPos").Interface(),
+				After:   after.data.Get("gridPos").Interface(),
+			})
+		}
+
+		for _, key := range unionKeys(before.data.MustMap(), after.data.MustMap()) {
+			if key == "id" || key == "gridPos" || key == "targets" || key == "panels" {
+				continue
+			}
+			b, a := before.data.Get(key).Interface(), after.data.Get(key).Interface()
+			if !jsonEqual(b, a) {
+				changes = append(changes, Change{Kind: ChangePanelChanged, Path: path + "." + key, PanelID: &panelID, Before: b, After: a})
+			}
+		}
+
+		changes = append(changes, diffTargets(path, panelID, before.data, after.data)...)
+	}
+	return changes
+}
+
+func diffTargets(panelPath string, panelID int64, basePanel, newPanel *simplejson.Json) []Change {
+	baseTargets, baseOrder := keyedItems(basePanel.Get("targets"), "refId")
+	newTargets, newOrder := keyedItems(newPanel.Get("targets"), "refId")
+
+	var changes []Change
+	for _, refID := range mergeOrder(baseOrder, newOrder) {
+		id := panelID
+		path := fmt.Sprintf("%s.targets[%s]", panelPath, refID)
+		before, inBase := baseTargets[refID]
+		after, inNew := newTargets[refID]
+		switch {
+		case !inNew:
+			changes = append(changes, Change{Kind: ChangeTargetRemoved, Path: path, PanelID: &id, Before: before})
+		case !inBase:
+			changes = append(changes, Change{Kind: ChangeTargetAdded, Path: path, PanelID: &id, After: after})
+		case !jsonEqual(before, after):
+			changes = append(changes, Change{Kind: ChangeTargetChanged, Path: path, PanelID: &id, Before: before, After: after})
+		}
+	}
+	return changes
+}
+
+func diffVariables(baseData, newData *simplejson.Json) []Change {
+	baseVars, baseOrder := keyedItems(baseData.GetPath("templating", "list"), "name")
+	newVars, newOrder := keyedItems(newData.GetPath("templating", "list"), "name")
+
+	names := mergeOrder(baseOrder, newOrder)
+	sort.Strings(names)
+
+	var changes []Change
+	for _, name := range names {
+		path := fmt.Sprintf("templating.list[%s]", name)
+		before, inBase := baseVars[name]
+		after, inNew := newVars[name]
+		switch {
+		case !inNew:
+			changes = append(changes, Change{Kind: ChangeVariableRemoved, Path: path, Before: before})
+		case !inBase:
+			changes = append(changes, Change{Kind: ChangeVariableAdded, Path: path, After: after})
+		case !jsonEqual(before, after):
+			changes = append(changes, Change{Kind: ChangeVariableChanged, Path: path, Before: before, After: after})
+		}
+	}
+	return changes
+}
+
+// semanticPanels returns the panels of the dashboard by id, including the
+// panels nested in collapsed rows.
+func semanticPanels(data *simplejson.Json) map[int64]semanticPanel {
+	panels := make(map[int64]semanticPanel)
+	for _, item := range data.Get("panels").MustArray() {
+		panel := simplejson.NewFromAny(item)
+		panels[panel.Get("id").MustInt64()] = semanticPanel{data: panel}
+		for _, nested := range panel.Get("panels").MustArray() {
+			child := simplejson.NewFromAny(nested)
+			panels[child.Get("id").MustInt64()] = semanticPanel{data: child, rowID: panel.Get("id").MustInt64()}
+		}
+	}
+	return panels
+}
+
+// keyedItems indexes the objects of a JSON array by the given property,
+// falling back to their position when the property is not set.
+func keyedItems(list *simplejson.Json, key string) (map[string]any, []string) {
+	items := make(map[string]any)
+	var order []string
+	for i, item := range list.MustArray() {
+		id := simplejson.NewFromAny(item).Get(key).MustString()
+		if id == "" {
+			id = fmt.Sprintf("%d", i)
+		}
+		items[id] = item
+		order = append(order, id)
+	}
+	return items, order
+}
+
+// mergeOrder returns the keys of base followed by the keys only in other.
+func mergeOrder(base, other []string) []string {
+	seen := make(map[string]bool, len(base))
+	merged := make([]string, 0, len(base)+len(other))
+	for _, key := range base {
+		seen[key] = true
+		merged = append(merged, key)
+	}
+	for _, key := range other {
+		if !seen[key] {
+			merged = append(merged, key)
+		}
+	}
+	return merged
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonEqual(a, b any) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(left) == string(right)
+}
@@ -0,0 +1,116 @@
+package dashdiffs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestSemanticDiff(t *testing.T) {
+	base, err := simplejson.NewJson([]byte(`{
+		"title": "Dash",
+		"version": 1,
+		"panels": [
+			{"id": 1, "type": "timeseries", "title": "CPU", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8},
+				"targets": [{"refId": "A", "expr": "cpu"}, {"refId": "B", "expr": "load"}]},
+			{"id": 2, "type": "stat", "title": "Removed", "gridPos": {"x": 12, "y": 0, "w": 12, "h": 8}},
+			{"id": 3, "type": "row", "collapsed": true, "gridPos": {"x": 0, "y": 8, "w": 24, "h": 1}, "panels": [
+				{"id": 4, "type": "table", "title": "Nested", "gridPos": {"x": 0, "y": 9, "w": 24, "h": 8}}
+			]}
+		],
+		"templating": {"list": [{"name": "env", "query": "dev,prod"}, {"name": "region", "query": "eu"}]}
+	}`))
+	require.NoError(t, err)
+	updated, err := simplejson.NewJson([]byte(`{
+		"title": "Renamed dash",
+		"version": 2,
+		"panels": [
+			{"id": 1, "type": "timeseries", "title": "CPU usage", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8},
+				"targets": [{"refId": "A", "expr": "rate(cpu[5m])"}, {"refId": "C", "expr": "mem"}]},
+			{"id": 3, "type": "row", "collapsed": true, "gridPos": {"x": 0, "y": 8, "w": 24, "h": 1}, "panels": []},
+			{"id": 4, "type": "table", "title": "Nested", "gridPos": {"x": 0, "y": 9, "w": 24, "h": 8}},
+			{"id": 5, "type": "text", "title": "Added", "gridPos": {"x": 0, "y": 17, "w": 24, "h": 4}}
+		],
+		"templating": {"list": [{"name": "env", "query": "dev,staging,prod"}, {"name": "cluster", "query": "a"}]}
+	}`))
+	require.NoError(t, err)
+
+	var summary []string
+	for _, change := range SemanticDiff(base, updated) {
+		summary = append(summary, change.Kind+" "+change.Path)
+	}
+
+	assert.Equal(t, []string{
+		"dashboardChanged title",
+		"panelChanged panels[1].title",
+		"targetChanged panels[1].targets[A]",
+		"targetRemoved panels[1].targets[B]",
+		"targetAdded panels[1].targets[C]",
+		"panelRemoved panels[2]",
+		"panelMoved panels[4].gridPos",
+		"panelAdded panels[5]",
+		"variableAdded templating.list[cluster]",
+		"variableChanged templating.list[env]",
+		"variableRemoved templating.list[region]",
+	}, summary)
+}
+
+func TestSummarizeChanges(t *testing.T) {
+	panel := func(id int64) *int64 { return &id }
+	changes := []Change{
+		{Kind: ChangeDashboard, Path: "title"},
+		{Kind: ChangePanelChanged, Path: "panels[1].title", PanelID: panel(1)},
+		{Kind: ChangeTargetChanged, Path: "panels[1].targets[A]", PanelID: panel(1)},
+		{Kind: ChangePanelRemoved, Path: "panels[2]", PanelID: panel(2)},
+		{Kind: ChangePanelMoved, Path: "panels[4].gridPos", PanelID: panel(4)},
+		{Kind: ChangePanelAdded, Path: "panels[5]", PanelID: panel(5)},
+		{Kind: ChangeVariableAdded, Path: "templating.list[cluster]"},
+		{Kind: ChangeVariableChanged, Path: "templating.list[env]"},
+		{Kind: ChangeVariableRemoved, Path: "templating.list[region]"},
+	}
+
+	assert.Equal(t, ChangeSummary{
+		PropertiesChanged: 1,
+		PanelsAdded:       1,
+		PanelsRemoved:     1,
+		PanelsModified:    2,
+		VariablesAdded:    1,
+		VariablesRemoved:  1,
+		VariablesModified: 1,
+	}, SummarizeChanges(changes))
+}
+
+func TestChangeSummaryString(t *testing.T) {
+	assert.Equal(t, "", ChangeSummary{}.String())
+	assert.Equal(t, "changed 1 property, added 2 panels, modified 1 variable",
+		ChangeSummary{PropertiesChanged: 1, PanelsAdded: 2, VariablesModified: 1}.String())
+}
+
+func TestCalculateSemanticDiff(t *testing.T) {
+	base := simplejson.NewFromAny(map[string]any{"panels": []any{map[string]any{"id": 1, "title": "Before"}}})
+	updated := simplejson.NewFromAny(map[string]any{"panels": []any{map[string]any{"id": 1, "title": "After"}}})
+
+	result, err := CalculateDiff(context.Background(), &Options{DiffType: ParseDiffType("semantic")}, base, updated)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"kind": "panelChanged", "path": "panels[1].title", "panelId": 1, "before": "Before", "after": "After"}]`, string(result.Delta))
+}
+
+func TestCalculateSemanticDiffPage(t *testing.T) {
+	base := simplejson.NewFromAny(map[string]any{"panels": []any{}})
+	updated := simplejson.NewFromAny(map[string]any{"panels": []any{
+		map[string]any{"id": 1}, map[string]any{"id": 2}, map[string]any{"id": 3},
+	}})
+
+	result, err := CalculateDiff(context.Background(), &Options{DiffType: DiffSemantic, Page: 2, PageSize: 2}, base, updated)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.TotalChanges)
+	assert.JSONEq(t, `[{"kind": "panelAdded", "path": "panels[3]", "panelId": 3, "after": {"id": 3}}]`, string(result.Delta))
+
+	result, err = CalculateDiff(context.Background(), &Options{DiffType: DiffSemantic, Page: 3, PageSize: 2}, base, updated)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(result.Delta))
+}
@@ -1,6 +1,7 @@
 package dashdiffs
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,4 +128,19 @@ func TestDiff(t *testing.T) {
 		}
 		assert.EqualValues(t, expectedChangeCounts, changeCounts)
 	})
+
+	t.Run("BasicFormatter cuts the output between blocks at MaxLines", func(t *testing.T) {
+		full, err := NewBasicFormatter(left).Format(jsonDiff)
+		require.NoError(t, err)
+
+		f := NewBasicFormatter(left)
+		f.MaxLines = bytes.Count(full, []byte("\n")) - 1
+		truncated, err := f.Format(jsonDiff)
+		require.NoError(t, err)
+
+		assert.True(t, f.Truncated)
+		group := []byte(`class="diff-group"`)
+		assert.Equal(t, bytes.Count(full, group)-1, bytes.Count(truncated, group))
+		assert.LessOrEqual(t, bytes.Count(truncated, []byte("\n")), f.MaxLines)
+	})
 }
@@ -0,0 +1,43 @@
+package dashboardaudit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/grafana/pkg/infra/log/logtest"
+)
+
+func TestLogSink(t *testing.T) {
+	logger := &logtest.Fake{}
+	sink := NewLogSink(logger)
+
+	now := time.Now()
+	sink.Record(context.Background(), Entry{
+		Time:            now,
+		Action:          ActionUpdated,
+		OrgID:           1,
+		UserID:          2,
+		UserLogin:       "admin",
+		DashboardUID:    "dash",
+		PreviousVersion: 3,
+		Version:         4,
+		SourceIP:        "10.0.0.1",
+	})
+
+	assert.Equal(t, 1, logger.InfoLogs.Calls)
+	assert.Equal(t, "Dashboard updated", logger.InfoLogs.Message)
+	assert.Equal(t, []any{
+		"action", ActionUpdated,
+		"time", now,
+		"orgId", int64(1),
+		"userId", int64(2),
+		"userLogin", "admin",
+		"dashboardUid", "dash",
+		"previousVersion", 3,
+		"version", 4,
+		"sourceIp", "10.0.0.1",
+	}, logger.InfoLogs.Ctx)
+}
@@ -0,0 +1,96 @@
+// Package dashboarddraft keeps the autosaved drafts of dashboards being
+// edited, apart from the dashboard versions.
+package dashboarddraft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+const kvNamespace = "dashboard-draft"
+
+// Draft is the autosaved state of a dashboard edited by a user.
+type Draft struct {
+	Dashboard *simplejson.Json `json:"dashboard"`
+	// Version is the version of the dashboard the draft is based on.
+	Version int       `json:"version"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store keeps one draft per user and dashboard in the kv store. A nil store
+// keeps nothing.
+type Store struct {
+	kv kvstore.KVStore
+}
+
+func NewStore(kv kvstore.KVStore) *Store {
+	return &Store{kv: kv}
+}
+
+// Set stores the draft of the user for the dashboard, replacing the previous
+// one.
+func (s *Store) Set(ctx context.Context, orgID int64, uid string, userID int64, draft *Draft) error {
+	if s == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(draft)
+	if err != nil {
+		return err
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Set(ctx, draftKey(uid, userID), string(value))
+}
+
+// Get returns the draft of the user for the dashboard, if any.
+func (s *Store) Get(ctx context.Context, orgID int64, uid string, userID int64) (*Draft, bool, error) {
+	if s == nil {
+		return nil, false, nil
+	}
+
+	value, ok, err := kvstore.WithNamespace(s.kv, orgID, kvNamespace).Get(ctx, draftKey(uid, userID))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	draft := &Draft{}
+	if err := json.Unmarshal([]byte(value), draft); err != nil {
+		return nil, false, err
+	}
+	return draft, true, nil
+}
+
+// Delete removes the draft of the user for the dashboard.
+func (s *Store) Delete(ctx context.Context, orgID int64, uid string, userID int64) error {
+	if s == nil {
+		return nil
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Del(ctx, draftKey(uid, userID))
+}
+
+// DeleteDashboard removes the drafts of all users for the dashboard.
+func (s *Store) DeleteDashboard(ctx context.Context, orgID int64, uid string) error {
+	if s == nil {
+		return nil
+	}
+
+	kv := kvstore.WithNamespace(s.kv, orgID, kvNamespace)
+	keys, err := kv.Keys(ctx, uid+"/")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := kv.Del(ctx, key.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func draftKey(uid string, userID int64) string {
+	return fmt.Sprintf("%s/%d", uid, userID)
+}
@@ -0,0 +1,47 @@
+package dashboarddraft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(kvstore.NewFakeKVStore())
+
+	draft := func(title string) *Draft {
+		return &Draft{Dashboard: simplejson.NewFromAny(map[string]any{"title": title}), Version: 3}
+	}
+	require.NoError(t, store.Set(ctx, 1, "dash", 1, draft("first")))
+	require.NoError(t, store.Set(ctx, 1, "dash", 1, draft("second")))
+	require.NoError(t, store.Set(ctx, 1, "dash", 2, draft("other user")))
+	require.NoError(t, store.Set(ctx, 1, "dashboard", 1, draft("other dashboard")))
+
+	got, ok, err := store.Get(ctx, 1, "dash", 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "second", got.Dashboard.Get("title").MustString())
+	assert.Equal(t, 3, got.Version)
+
+	require.NoError(t, store.DeleteDashboard(ctx, 1, "dash"))
+	for _, userID := range []int64{1, 2} {
+		_, ok, err = store.Get(ctx, 1, "dash", userID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	_, ok, err = store.Get(ctx, 1, "dashboard", 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, store.Delete(ctx, 1, "dashboard", 1))
+	_, ok, err = store.Get(ctx, 1, "dashboard", 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
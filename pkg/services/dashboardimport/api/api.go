@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/grafana/grafana/pkg/api/apierrors"
@@ -10,9 +11,11 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboardimport"
+	"github.com/grafana/grafana/pkg/services/dashboardimport/utils"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
 	"github.com/grafana/grafana/pkg/services/quota"
+	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -48,6 +51,10 @@ func (api *ImportDashboardAPI) RegisterAPIEndpoints(routeRegister routing.RouteR
 //
 // Import dashboard.
 //
+// The inputs map the __inputs of the dashboard, such as data sources, to concrete values. Inputs without a
+// type apply to template inputs of any type with the same name. When inputs are missing the import fails
+// with a 400 listing all of them.
+//
 // Responses:
 // 200: importDashboardResponse
 // 400: badRequestError
@@ -77,6 +84,14 @@ func (api *ImportDashboardAPI) ImportDashboard(c *contextmodel.ReqContext) respo
 	req.User = c.SignedInUser
 	resp, err := api.dashboardImportService.ImportDashboard(c.Req.Context(), &req)
 	if err != nil {
+		var missingErr *utils.DashboardInputMissingError
+		if errors.As(err, &missingErr) {
+			return response.JSON(http.StatusBadRequest, util.DynMap{
+				"status":  "missing-inputs",
+				"message": missingErr.Error(),
+				"inputs":  missingErr.Inputs,
+			})
+		}
 		return apierrors.ToDashboardErrorResponse(c.Req.Context(), api.pluginStore, err)
 	}
 
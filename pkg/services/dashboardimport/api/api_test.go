@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboardimport"
+	"github.com/grafana/grafana/pkg/services/dashboardimport/utils"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/user"
@@ -133,6 +134,43 @@ func TestImportDashboardAPI(t *testing.T) {
 		})
 	})
 
+	t.Run("Missing inputs", func(t *testing.T) {
+		service := &serviceMock{
+			importDashboardFunc: func(ctx context.Context, req *dashboardimport.ImportDashboardRequest) (*dashboardimport.ImportDashboardResponse, error) {
+				return nil, &utils.DashboardInputMissingError{Inputs: []dashboardimport.ImportDashboardInput{{Name: "DS_PROM", Type: "datasource", PluginId: "prometheus"}}}
+			},
+		}
+		importDashboardAPI := New(service, quotaServiceFunc(quotaNotReached), nil, actest.FakeAccessControl{ExpectedEvaluate: true})
+
+		routeRegister := routing.NewRouteRegister()
+		importDashboardAPI.RegisterAPIEndpoints(routeRegister)
+		s := webtest.NewServer(t, routeRegister)
+
+		t.Run("Signed in, dashboard with unresolved inputs should return 400 listing them", func(t *testing.T) {
+			cmd := &dashboardimport.ImportDashboardRequest{
+				Dashboard: simplejson.New(),
+			}
+			jsonBytes, err := json.Marshal(cmd)
+			require.NoError(t, err)
+			req := s.NewPostRequest("/api/dashboards/import", bytes.NewReader(jsonBytes))
+			webtest.RequestWithSignedInUser(req, &user.SignedInUser{
+				UserID: 1,
+			})
+			resp, err := s.SendJSON(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var body struct {
+				Status string                                 `json:"status"`
+				Inputs []dashboardimport.ImportDashboardInput `json:"inputs"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, "missing-inputs", body.Status)
+			require.Equal(t, []dashboardimport.ImportDashboardInput{{Name: "DS_PROM", Type: "datasource", PluginId: "prometheus"}}, body.Inputs)
+		})
+	})
+
 	t.Run("Quota reached", func(t *testing.T) {
 		service := &serviceMock{}
 		importDashboardAPI := New(service, quotaServiceFunc(quotaReached), nil, actest.FakeAccessControl{ExpectedEvaluate: true})
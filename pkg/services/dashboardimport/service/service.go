@@ -5,11 +5,13 @@ import (
 
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/auth/identity"
 	"github.com/grafana/grafana/pkg/services/dashboardimport"
 	"github.com/grafana/grafana/pkg/services/dashboardimport/api"
 	"github.com/grafana/grafana/pkg/services/dashboardimport/utils"
+	"github.com/grafana/grafana/pkg/services/dashboardlineage"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/librarypanels"
@@ -22,13 +24,14 @@ func ProvideService(routeRegister routing.RouteRegister,
 	quotaService quota.Service,
 	pluginDashboardService plugindashboards.Service, pluginStore pluginstore.Store,
 	libraryPanelService librarypanels.Service, dashboardService dashboards.DashboardService,
-	ac accesscontrol.AccessControl, folderService folder.Service,
+	ac accesscontrol.AccessControl, folderService folder.Service, kvStore kvstore.KVStore,
 ) *ImportDashboardService {
 	s := &ImportDashboardService{
 		pluginDashboardService: pluginDashboardService,
 		dashboardService:       dashboardService,
 		libraryPanelService:    libraryPanelService,
 		folderService:          folderService,
+		lineageStore:           dashboardlineage.NewStore(kvStore),
 	}
 
 	dashboardImportAPI := api.New(s, quotaService, pluginStore, ac)
@@ -42,6 +45,7 @@ type ImportDashboardService struct {
 	dashboardService       dashboards.DashboardService
 	libraryPanelService    librarypanels.Service
 	folderService          folder.Service
+	lineageStore           *dashboardlineage.Store
 }
 
 func (s *ImportDashboardService) ImportDashboard(ctx context.Context, req *dashboardimport.ImportDashboardRequest) (*dashboardimport.ImportDashboardResponse, error) {
@@ -148,6 +152,19 @@ func (s *ImportDashboardService) ImportDashboard(ctx context.Context, req *dashb
 		return nil, err
 	}
 
+	parent := dashboardlineage.Parent{
+		Origin:   dashboardlineage.OriginImport,
+		GnetID:   generatedDash.Get("gnetId").MustInt64(),
+		PluginID: req.PluginId,
+		Created:  savedDashboard.Updated,
+	}
+	if sourceUID := draftDashboard.UID; sourceUID != savedDashboard.UID {
+		parent.UID = sourceUID
+	}
+	if err := s.lineageStore.SetParent(ctx, savedDashboard.OrgID, savedDashboard.UID, parent); err != nil {
+		return nil, err
+	}
+
 	revision := savedDashboard.Data.Get("revision").MustInt64(0)
 	return &dashboardimport.ImportDashboardResponse{
 		UID:              savedDashboard.UID,
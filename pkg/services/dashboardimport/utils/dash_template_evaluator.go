@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/expr"
@@ -12,12 +13,18 @@ import (
 
 var varRegex = regexp.MustCompile(`(\$\{.+?\})`)
 
+// DashboardInputMissingError lists the inputs of the dashboard template that
+// the import command has no value for.
 type DashboardInputMissingError struct {
-	VariableName string
+	Inputs []dashboardimport.ImportDashboardInput
 }
 
 func (e DashboardInputMissingError) Error() string {
-	return fmt.Sprintf("Dashboard input variable: %v missing from import command", e.VariableName)
+	names := make([]string, 0, len(e.Inputs))
+	for _, input := range e.Inputs {
+		names = append(names, input.Name)
+	}
+	return fmt.Sprintf("Dashboard input variables: %v missing from import command", strings.Join(names, ", "))
 }
 
 type DashTemplateEvaluator struct {
@@ -34,9 +41,11 @@ func NewDashTemplateEvaluator(template *simplejson.Json, inputs []dashboardimpor
 	}
 }
 
+// findInput returns the import input for the template input. Inputs without a
+// type match template inputs of any type.
 func (e *DashTemplateEvaluator) findInput(varName string, varType string) *dashboardimport.ImportDashboardInput {
 	for _, input := range e.inputs {
-		if varType == input.Type && (input.Name == varName || input.Name == "*") {
+		if (input.Type == "" || varType == input.Type) && (input.Name == varName || input.Name == "*") {
 			return &input
 		}
 	}
@@ -49,6 +58,7 @@ func (e *DashTemplateEvaluator) Eval() (*simplejson.Json, error) {
 	e.variables = make(map[string]string)
 
 	// check that we have all inputs we need
+	var missing []dashboardimport.ImportDashboardInput
 	for _, inputDef := range e.template.Get("__inputs").MustArray() {
 		inputDefJson := simplejson.NewFromAny(inputDef)
 		inputName := inputDefJson.Get("name").MustString()
@@ -63,11 +73,19 @@ func (e *DashTemplateEvaluator) Eval() (*simplejson.Json, error) {
 		}
 
 		if input == nil {
-			return nil, &DashboardInputMissingError{VariableName: inputName}
+			missing = append(missing, dashboardimport.ImportDashboardInput{
+				Name:     inputName,
+				Type:     inputType,
+				PluginId: inputDefJson.Get("pluginId").MustString(),
+			})
+			continue
 		}
 
 		e.variables["${"+inputName+"}"] = input.Value
 	}
+	if len(missing) > 0 {
+		return nil, &DashboardInputMissingError{Inputs: missing}
+	}
 
 	return simplejson.NewFromAny(e.evalObject(e.template)), nil
 }
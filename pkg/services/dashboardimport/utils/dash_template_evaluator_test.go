@@ -38,3 +38,42 @@ func TestDashTemplateEvaluator(t *testing.T) {
 	inputs := res.Get("__inputs")
 	require.Nil(t, inputs.Interface())
 }
+
+func TestDashTemplateEvaluatorInputs(t *testing.T) {
+	template, err := simplejson.NewJson([]byte(`{
+		"__inputs": [
+			{"name": "DS_PROM", "type": "datasource", "pluginId": "prometheus"},
+			{"name": "DS_LOKI", "type": "datasource", "pluginId": "loki"},
+			{"name": "VAR_ENV", "type": "constant"}
+		],
+		"panels": [{"datasource": {"uid": "${DS_PROM}"}}]
+	}`))
+	require.NoError(t, err)
+
+	t.Run("should match inputs without type by name", func(t *testing.T) {
+		evaluator := NewDashTemplateEvaluator(template, []dashboardimport.ImportDashboardInput{
+			{Name: "DS_PROM", Value: "prom-uid"},
+			{Name: "DS_LOKI", Value: "loki-uid"},
+			{Name: "VAR_ENV", Value: "prod"},
+		})
+
+		res, err := evaluator.Eval()
+		require.NoError(t, err)
+		require.Equal(t, "prom-uid", res.Get("panels").GetIndex(0).GetPath("datasource", "uid").MustString())
+	})
+
+	t.Run("should list all missing inputs", func(t *testing.T) {
+		evaluator := NewDashTemplateEvaluator(template, []dashboardimport.ImportDashboardInput{
+			{Name: "DS_LOKI", Type: "datasource", Value: "loki-uid"},
+		})
+
+		_, err := evaluator.Eval()
+		var missingErr *DashboardInputMissingError
+		require.ErrorAs(t, err, &missingErr)
+		require.Equal(t, []dashboardimport.ImportDashboardInput{
+			{Name: "DS_PROM", Type: "datasource", PluginId: "prometheus"},
+			{Name: "VAR_ENV", Type: "constant"},
+		}, missingErr.Inputs)
+		require.EqualError(t, err, "Dashboard input variables: DS_PROM, VAR_ENV missing from import command")
+	})
+}
@@ -0,0 +1,106 @@
+// Package dashboardlineage records where dashboards created by cloning or
+// importing another dashboard came from.
+package dashboardlineage
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+const kvNamespace = "dashboard-lineage"
+
+const (
+	OriginClone  = "clone"
+	OriginImport = "import"
+)
+
+// Parent describes the dashboard a dashboard was cloned or imported from.
+// UID is set when the parent is a dashboard of the same org, imports from
+// grafana.com or from a plugin are identified by GnetID and PluginID instead.
+type Parent struct {
+	Origin   string    `json:"origin"`
+	UID      string    `json:"uid,omitempty"`
+	GnetID   int64     `json:"gnetId,omitempty"`
+	PluginID string    `json:"pluginId,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+// Store keeps the parent of each cloned or imported dashboard in the kv
+// store. A nil store records nothing.
+type Store struct {
+	kv kvstore.KVStore
+}
+
+func NewStore(kv kvstore.KVStore) *Store {
+	return &Store{kv: kv}
+}
+
+// SetParent records the parent of the dashboard with the given uid.
+func (s *Store) SetParent(ctx context.Context, orgID int64, uid string, parent Parent) error {
+	if s == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(parent)
+	if err != nil {
+		return err
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Set(ctx, uid, string(value))
+}
+
+// GetParent returns the recorded parent of the dashboard, if any.
+func (s *Store) GetParent(ctx context.Context, orgID int64, uid string) (*Parent, bool, error) {
+	if s == nil {
+		return nil, false, nil
+	}
+
+	value, ok, err := kvstore.WithNamespace(s.kv, orgID, kvNamespace).Get(ctx, uid)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	parent := &Parent{}
+	if err := json.Unmarshal([]byte(value), parent); err != nil {
+		return nil, false, err
+	}
+	return parent, true, nil
+}
+
+// GetChildren returns, for every dashboard of the org that has recorded
+// children, the sorted uids of the dashboards cloned or imported from it.
+func (s *Store) GetChildren(ctx context.Context, orgID int64) (map[string][]string, error) {
+	children := make(map[string][]string)
+	if s == nil {
+		return children, nil
+	}
+
+	items, err := kvstore.WithNamespace(s.kv, orgID, kvNamespace).GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for uid, value := range items[orgID] {
+		parent := Parent{}
+		if err := json.Unmarshal([]byte(value), &parent); err != nil {
+			return nil, err
+		}
+		if parent.UID != "" {
+			children[parent.UID] = append(children[parent.UID], uid)
+		}
+	}
+	for _, uids := range children {
+		sort.Strings(uids)
+	}
+	return children, nil
+}
+
+// Delete drops the recorded parent of a deleted dashboard.
+func (s *Store) Delete(ctx context.Context, orgID int64, uid string) error {
+	if s == nil {
+		return nil
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Del(ctx, uid)
+}
@@ -0,0 +1,42 @@
+package dashboardlineage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(kvstore.NewFakeKVStore())
+
+	require.NoError(t, store.SetParent(ctx, 1, "copy-a", Parent{Origin: OriginClone, UID: "original"}))
+	require.NoError(t, store.SetParent(ctx, 1, "copy-b", Parent{Origin: OriginClone, UID: "original"}))
+	require.NoError(t, store.SetParent(ctx, 1, "original", Parent{Origin: OriginImport, GnetID: 1860}))
+	require.NoError(t, store.SetParent(ctx, 2, "other-org", Parent{Origin: OriginClone, UID: "original"}))
+
+	parent, ok, err := store.GetParent(ctx, 1, "original")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, int64(1860), parent.GnetID)
+
+	children, err := store.GetChildren(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"copy-a", "copy-b"}, children["original"])
+	assert.Len(t, children, 1)
+
+	require.NoError(t, store.Delete(ctx, 1, "copy-a"))
+	_, ok, err = store.GetParent(ctx, 1, "copy-a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	var nilStore *Store
+	require.NoError(t, nilStore.SetParent(ctx, 1, "x", Parent{}))
+	children, err = nilStore.GetChildren(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, children)
+}
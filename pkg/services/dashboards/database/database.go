@@ -459,6 +459,7 @@ func saveDashboard(sess *db.Session, cmd *dashboards.SaveDashboardCommand, emitE
 		CreatedBy:     dash.UpdatedBy,
 		Message:       cmd.Message,
 		Data:          dash.Data,
+		Source:        cmd.Source,
 	}
 
 	// insert version entry
@@ -896,21 +897,31 @@ func (d *dashboardStore) GetDashboardUIDByID(ctx context.Context, query *dashboa
 	return us, nil
 }
 
+// dashboardColumnsWithoutData are the columns of the dashboard table except
+// for the dashboard JSON.
+var dashboardColumnsWithoutData = []string{"id", "uid", "slug", "org_id", "gnet_id", "version", "plugin_id", "created", "updated", "updated_by", "created_by", "folder_id", "folder_uid", "is_folder", "has_acl", "title"}
+
 func (d *dashboardStore) GetDashboards(ctx context.Context, query *dashboards.GetDashboardsQuery) ([]*dashboards.Dashboard, error) {
 	var dashboards = make([]*dashboards.Dashboard, 0)
 	err := d.store.WithDbSession(ctx, func(sess *db.Session) error {
-		if len(query.DashboardIDs) == 0 && len(query.DashboardUIDs) == 0 {
+		if len(query.DashboardIDs) == 0 && len(query.DashboardUIDs) == 0 && query.Slug == "" {
 			return star.ErrCommandValidationFailed
 		}
 		var session *xorm.Session
-		if len(query.DashboardIDs) > 0 {
+		switch {
+		case len(query.DashboardIDs) > 0:
 			session = sess.In("id", query.DashboardIDs)
-		} else {
+		case len(query.DashboardUIDs) > 0:
 			session = sess.In("uid", query.DashboardUIDs)
+		default:
+			session = sess.Where("slug = ? AND is_folder = "+d.store.GetDialect().BooleanStr(false), query.Slug)
 		}
 		if query.OrgID > 0 {
 			session = sess.Where("org_id = ?", query.OrgID)
 		}
+		if query.WithoutData {
+			session = session.Cols(dashboardColumnsWithoutData...)
+		}
 
 		err := session.Find(&dashboards)
 		return err
@@ -180,6 +180,36 @@ func TestIntegrationDashboardDataAccess(t *testing.T) {
 		assert.Equal(t, len(queryResult), 2)
 	})
 
+	t.Run("Should be able to get dashboards without their data", func(t *testing.T) {
+		setup()
+		query := dashboards.GetDashboardsQuery{DashboardUIDs: []string{savedDash.UID}, WithoutData: true}
+		queryResult, err := dashboardStore.GetDashboards(context.Background(), &query)
+		require.NoError(t, err)
+		require.Len(t, queryResult, 1)
+		assert.Equal(t, savedDash.Version, queryResult[0].Version)
+		assert.Nil(t, queryResult[0].Data)
+	})
+
+	t.Run("Should be able to get dashboards by slug", func(t *testing.T) {
+		setup()
+		sameSlug := insertTestDashboard(t, dashboardStore, "test dash 23", 1, 0, "", false)
+		insertTestDashboard(t, dashboardStore, "test dash 23", 2, 0, "", false)
+
+		query := dashboards.GetDashboardsQuery{Slug: "test-dash-23", OrgID: 1}
+		queryResult, err := dashboardStore.GetDashboards(context.Background(), &query)
+		require.NoError(t, err)
+		uids := make([]string, 0, len(queryResult))
+		for _, dash := range queryResult {
+			uids = append(uids, dash.UID)
+		}
+		assert.ElementsMatch(t, []string{savedDash.UID, sameSlug.UID}, uids)
+
+		query = dashboards.GetDashboardsQuery{Slug: savedFolder.Slug, OrgID: 1}
+		queryResult, err = dashboardStore.GetDashboards(context.Background(), &query)
+		require.NoError(t, err)
+		assert.Empty(t, queryResult)
+	})
+
 	t.Run("Should be able to delete dashboard", func(t *testing.T) {
 		setup()
 		dash := insertTestDashboard(t, dashboardStore, "delete me", 1, 0, "", false, "delete this")
@@ -12,109 +12,140 @@ var (
 		Reason:     "Dashboard not found",
 		StatusCode: 404,
 		Status:     "not-found",
+		MessageID:  "dashboards.notFound",
 	}
 	ErrDashboardCorrupt = DashboardErr{
 		Reason:     "Dashboard data is missing or corrupt",
 		StatusCode: 500,
 		Status:     "not-found",
+		MessageID:  "dashboards.corrupt",
 	}
 	ErrDashboardPanelNotFound = DashboardErr{
 		Reason:     "Dashboard panel not found",
 		StatusCode: 404,
 		Status:     "not-found",
+		MessageID:  "dashboards.panelNotFound",
 	}
 	ErrDashboardFolderNotFound = DashboardErr{
 		Reason:     "Folder not found",
 		StatusCode: 404,
+		MessageID:  "dashboards.folderNotFound",
 	}
 	ErrDashboardWithSameUIDExists = DashboardErr{
 		Reason:     "A dashboard with the same uid already exists",
 		StatusCode: 400,
+		MessageID:  "dashboards.uidExists",
 	}
 	ErrDashboardWithSameNameInFolderExists = DashboardErr{
 		Reason:     "A dashboard with the same name in the folder already exists",
 		StatusCode: 412,
 		Status:     "name-exists",
+		MessageID:  "dashboards.nameExistsInFolder",
 	}
 	ErrDashboardVersionMismatch = DashboardErr{
 		Reason:     "The dashboard has been changed by someone else",
 		StatusCode: 412,
 		Status:     "version-mismatch",
+		MessageID:  "dashboards.versionMismatch",
+	}
+	ErrDashboardFolderChanged = DashboardErr{
+		Reason:     "The dashboard would be moved to another folder",
+		StatusCode: 412,
+		Status:     "folder-changed",
+		MessageID:  "dashboards.folderChanged",
 	}
 	ErrDashboardTitleEmpty = DashboardErr{
 		Reason:     "Dashboard title cannot be empty",
 		StatusCode: 400,
 		Status:     "empty-name",
+		MessageID:  "dashboards.titleEmpty",
 	}
 	ErrDashboardFolderCannotHaveParent = DashboardErr{
 		Reason:     "A Dashboard Folder cannot be added to another folder",
 		StatusCode: 400,
+		MessageID:  "dashboards.folderCannotHaveParent",
 	}
 	ErrDashboardsWithSameSlugExists = DashboardErr{
 		Reason:     "Multiple dashboards with the same slug exists",
 		StatusCode: 412,
+		MessageID:  "dashboards.slugExists",
 	}
 	ErrDashboardTypeMismatch = DashboardErr{
 		Reason:     "Dashboard cannot be changed to a folder",
 		StatusCode: 400,
+		MessageID:  "dashboards.typeMismatch",
 	}
 	ErrDashboardFolderWithSameNameAsDashboard = DashboardErr{
 		Reason:     "Folder name cannot be the same as one of its dashboards",
 		StatusCode: 400,
+		MessageID:  "dashboards.folderNameMatchesDashboard",
 	}
 	ErrDashboardWithSameNameAsFolder = DashboardErr{
 		Reason:     "Dashboard name cannot be the same as folder",
 		StatusCode: 400,
 		Status:     "name-match",
+		MessageID:  "dashboards.nameMatchesFolder",
 	}
 	ErrDashboardFolderNameExists = DashboardErr{
 		Reason:     "A folder with that name already exists",
 		StatusCode: 400,
+		MessageID:  "dashboards.folderNameExists",
 	}
 	ErrDashboardUpdateAccessDenied = DashboardErr{
 		Reason:     "Access denied to save dashboard",
 		StatusCode: 403,
+		MessageID:  "dashboards.saveAccessDenied",
 	}
 	ErrDashboardInvalidUid = DashboardErr{
 		Reason:     "uid contains illegal characters",
 		StatusCode: 400,
+		MessageID:  "dashboards.invalidUid",
 	}
 	ErrDashboardUidTooLong = DashboardErr{
 		Reason:     "uid too long, max 40 characters",
 		StatusCode: 400,
+		MessageID:  "dashboards.uidTooLong",
 	}
 	ErrDashboardCannotSaveProvisionedDashboard = DashboardErr{
 		Reason:     "Cannot save provisioned dashboard",
 		StatusCode: 400,
+		MessageID:  "dashboards.provisionedCannotSave",
 	}
 	ErrDashboardRefreshIntervalTooShort = DashboardErr{
 		Reason:     "Dashboard refresh interval is too low",
 		StatusCode: 400,
+		MessageID:  "dashboards.refreshIntervalTooShort",
 	}
 	ErrDashboardCannotDeleteProvisionedDashboard = DashboardErr{
 		Reason:     "provisioned dashboard cannot be deleted",
 		StatusCode: 400,
+		MessageID:  "dashboards.provisionedCannotDelete",
 	}
 	ErrDashboardIdentifierNotSet = DashboardErr{
 		Reason:     "Unique identifier needed to be able to get a dashboard",
 		StatusCode: 400,
+		MessageID:  "dashboards.identifierNotSet",
 	}
 	ErrDashboardIdentifierInvalid = DashboardErr{
 		Reason:     "Dashboard ID not a number",
 		StatusCode: 400,
+		MessageID:  "dashboards.identifierInvalid",
 	}
 	ErrDashboardPanelIdentifierInvalid = DashboardErr{
 		Reason:     "Dashboard panel ID not a number",
 		StatusCode: 400,
+		MessageID:  "dashboards.panelIdentifierInvalid",
 	}
 	ErrDashboardOrPanelIdentifierNotSet = DashboardErr{
 		Reason:     "Unique identifier needed to be able to get a dashboard panel",
 		StatusCode: 400,
+		MessageID:  "dashboards.panelIdentifierNotSet",
 	}
 	ErrProvisionedDashboardNotFound = DashboardErr{
 		Reason:     "Dashboard is not provisioned",
 		StatusCode: 404,
 		Status:     "not-found",
+		MessageID:  "dashboards.notProvisioned",
 	}
 
 	ErrFolderNotFound           = errors.New("folder not found")
@@ -132,11 +163,14 @@ type DashboardErr struct {
 	StatusCode int
 	Status     string
 	Reason     string
+	// MessageID is a stable identifier of the error that clients can branch
+	// on instead of the reason.
+	MessageID string
 }
 
 // Equal returns whether equal to another DashboardErr.
 func (e DashboardErr) Equal(o DashboardErr) bool {
-	return o.StatusCode == e.StatusCode && o.Status == e.Status && o.Reason == e.Reason
+	return o.StatusCode == e.StatusCode && o.Status == e.Status && o.Reason == e.Reason && o.MessageID == e.MessageID
 }
 
 // Error returns the error message.
@@ -149,11 +183,18 @@ func (e DashboardErr) Error() string {
 
 // Body returns the error's response body, if applicable.
 func (e DashboardErr) Body() util.DynMap {
-	if e.Status == "" {
+	if e.Status == "" && e.MessageID == "" {
 		return nil
 	}
 
-	return util.DynMap{"status": e.Status, "message": e.Error()}
+	body := util.DynMap{"message": e.Error()}
+	if e.Status != "" {
+		body["status"] = e.Status
+	}
+	if e.MessageID != "" {
+		body["messageId"] = e.MessageID
+	}
+	return body
 }
 
 type UpdatePluginDashboardError struct {
@@ -255,6 +255,15 @@ type SaveDashboardCommand struct {
 	FolderID  int64  `json:"folderId" xorm:"folder_id"`
 	FolderUID string `json:"folderUid" xorm:"folder_uid"`
 	IsFolder  bool   `json:"isFolder"`
+	// SaveAsCopy saves the dashboard as a new dashboard, appending " Copy" to
+	// its title when the title is already taken in the target folder.
+	SaveAsCopy bool `json:"saveAsCopy"`
+	// Source records how the dashboard was saved on the new version.
+	Source string `json:"-"`
+	// AllowFolderChange set to false fails the save with ErrDashboardFolderChanged
+	// when it would move an existing dashboard to another folder. Folder changes
+	// are allowed when it is not set.
+	AllowFolderChange *bool `json:"allowFolderChange,omitempty"`
 
 	UpdatedAt time.Time
 }
@@ -325,7 +334,13 @@ type GetDashboardTagsQuery struct {
 type GetDashboardsQuery struct {
 	DashboardIDs  []int64
 	DashboardUIDs []string
-	OrgID         int64
+	// Slug finds the dashboards with the given slug when neither ids nor
+	// uids are given. Folders are left out.
+	Slug  string
+	OrgID int64
+	// WithoutData leaves the dashboard JSON out, e.g. to compare the versions
+	// of many dashboards.
+	WithoutData bool
 }
 
 type GetDashboardsByPluginIDQuery struct {
@@ -349,6 +364,11 @@ type SaveDashboardDTO struct {
 	Message   string
 	Overwrite bool
 	Dashboard *Dashboard
+	// Source records how the dashboard was saved on the new version.
+	Source string
+	// AllowFolderChange set to false rejects saves moving an existing
+	// dashboard to another folder.
+	AllowFolderChange *bool
 }
 
 type DashboardSearchProjection struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/alerting"
 	"github.com/grafana/grafana/pkg/services/auth/identity"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
@@ -154,6 +155,16 @@ func (dr *DashboardServiceImpl) BuildSaveDashboardCommand(ctx context.Context, d
 		return nil, err
 	}
 
+	if isParentFolderChanged && dto.AllowFolderChange != nil && !*dto.AllowFolderChange && dash.ID != 0 {
+		existing, err := dr.dashboardStore.GetDashboard(ctx, &dashboards.GetDashboardQuery{ID: dash.ID, OrgID: dash.OrgID})
+		if err != nil {
+			return nil, err
+		}
+		if existing.FolderUID != dash.FolderUID {
+			return nil, dashboards.ErrDashboardFolderChanged
+		}
+	}
+
 	if isParentFolderChanged {
 		// Check that the user is allowed to add a dashboard to the folder
 		guardian, err := guardian.NewByDashboard(ctx, dash, dto.OrgID, dto.User)
@@ -217,6 +228,7 @@ func (dr *DashboardServiceImpl) BuildSaveDashboardCommand(ctx context.Context, d
 		FolderUID: dash.FolderUID,
 		IsFolder:  dash.IsFolder,
 		PluginID:  dash.PluginID,
+		Source:    dto.Source,
 	}
 
 	if !dto.UpdatedAt.IsZero() {
@@ -302,6 +314,7 @@ func (dr *DashboardServiceImpl) SaveProvisionedDashboard(ctx context.Context, dt
 	}
 
 	dto.User = accesscontrol.BackgroundUser("dashboard_provisioning", dto.OrgID, org.RoleAdmin, provisionerPermissions)
+	dto.Source = dashver.SourceProvisioning
 
 	cmd, err := dr.BuildSaveDashboardCommand(ctx, dto, setting.IsLegacyAlertingEnabled(), false)
 	if err != nil {
@@ -343,6 +356,7 @@ func (dr *DashboardServiceImpl) SaveProvisionedDashboard(ctx context.Context, dt
 
 func (dr *DashboardServiceImpl) SaveFolderForProvisionedDashboards(ctx context.Context, dto *dashboards.SaveDashboardDTO) (*dashboards.Dashboard, error) {
 	dto.User = accesscontrol.BackgroundUser("dashboard_provisioning", dto.OrgID, org.RoleAdmin, provisionerPermissions)
+	dto.Source = dashver.SourceProvisioning
 	cmd, err := dr.BuildSaveDashboardCommand(ctx, dto, false, false)
 	if err != nil {
 		return nil, err
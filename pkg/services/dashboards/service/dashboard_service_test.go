@@ -114,6 +114,19 @@ func TestDashboardService(t *testing.T) {
 				require.Equal(t, err, dashboards.ErrDashboardCannotSaveProvisionedDashboard)
 			})
 
+			t.Run("Should return folder changed error if the save moves the dashboard and folder changes are not allowed", func(t *testing.T) {
+				fakeStore.On("ValidateDashboardBeforeSave", mock.Anything, mock.Anything, mock.AnythingOfType("bool")).Return(true, nil).Once()
+				fakeStore.On("GetDashboard", mock.Anything, mock.AnythingOfType("*dashboards.GetDashboardQuery")).Return(&dashboards.Dashboard{ID: 3, FolderUID: "old"}, nil).Once()
+
+				dto.Dashboard = dashboards.NewDashboard("Dash")
+				dto.Dashboard.SetID(3)
+				dto.User = &user.SignedInUser{UserID: 1}
+				dto.AllowFolderChange = util.Pointer(false)
+				t.Cleanup(func() { dto.AllowFolderChange = nil })
+				_, err := service.SaveDashboard(context.Background(), dto, false)
+				require.Equal(t, err, dashboards.ErrDashboardFolderChanged)
+			})
+
 			t.Run("Should not return validation error if dashboard is provisioned but UI updates allowed", func(t *testing.T) {
 				fakeStore.On("ValidateDashboardBeforeSave", mock.Anything, mock.Anything, mock.AnythingOfType("bool")).Return(true, nil).Once()
 				fakeStore.On("SaveDashboard", mock.Anything, mock.AnythingOfType("dashboards.SaveDashboardCommand")).Return(&dashboards.Dashboard{Data: simplejson.New()}, nil).Once()
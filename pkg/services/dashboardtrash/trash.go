@@ -0,0 +1,124 @@
+// Package dashboardtrash keeps deleted dashboards for a retention period so
+// that they can be restored.
+package dashboardtrash
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+const kvNamespace = "dashboard-trash"
+
+// Entry is a deleted dashboard kept in the trash.
+type Entry struct {
+	UID       string           `json:"uid"`
+	Title     string           `json:"title"`
+	FolderUID string           `json:"folderUid"`
+	Data      *simplejson.Json `json:"data"`
+	DeletedBy int64            `json:"deletedBy"`
+	Deleted   time.Time        `json:"deleted"`
+}
+
+// Store keeps the trashed dashboards of each org in the kv store. Entries
+// older than the retention are dropped. A nil store or a store without
+// retention keeps nothing.
+type Store struct {
+	kv        kvstore.KVStore
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewStore(kv kvstore.KVStore, retention time.Duration) *Store {
+	return &Store{kv: kv, retention: retention, now: time.Now}
+}
+
+// Enabled reports whether deleted dashboards are kept in the trash.
+func (s *Store) Enabled() bool {
+	return s != nil && s.retention > 0
+}
+
+// Expires returns when the entry is dropped from the trash.
+func (s *Store) Expires(entry *Entry) time.Time {
+	return entry.Deleted.Add(s.retention)
+}
+
+// Add puts a deleted dashboard in the trash, replacing an earlier entry with
+// the same uid.
+func (s *Store) Add(ctx context.Context, orgID int64, entry *Entry) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Set(ctx, entry.UID, string(value))
+}
+
+// Get returns the trashed dashboard with the given uid, if any.
+func (s *Store) Get(ctx context.Context, orgID int64, uid string) (*Entry, bool, error) {
+	if !s.Enabled() {
+		return nil, false, nil
+	}
+
+	kv := kvstore.WithNamespace(s.kv, orgID, kvNamespace)
+	value, ok, err := kv.Get(ctx, uid)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	entry := &Entry{}
+	if err := json.Unmarshal([]byte(value), entry); err != nil {
+		return nil, false, err
+	}
+	if s.expired(entry) {
+		return nil, false, kv.Del(ctx, uid)
+	}
+	return entry, true, nil
+}
+
+// List returns the trashed dashboards of the org, most recently deleted
+// first, dropping the expired ones.
+func (s *Store) List(ctx context.Context, orgID int64) ([]*Entry, error) {
+	entries := make([]*Entry, 0)
+	if !s.Enabled() {
+		return entries, nil
+	}
+
+	keys, err := kvstore.WithNamespace(s.kv, orgID, kvNamespace).Keys(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		entry, ok, err := s.Get(ctx, orgID, key.Key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Deleted.After(entries[j].Deleted)
+	})
+	return entries, nil
+}
+
+// Delete removes a dashboard from the trash.
+func (s *Store) Delete(ctx context.Context, orgID int64, uid string) error {
+	if !s.Enabled() {
+		return nil
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Del(ctx, uid)
+}
+
+func (s *Store) expired(entry *Entry) bool {
+	return !s.now().Before(s.Expires(entry))
+}
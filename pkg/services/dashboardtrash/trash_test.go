@@ -0,0 +1,49 @@
+package dashboardtrash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	store := NewStore(kvstore.NewFakeKVStore(), 7*24*time.Hour)
+	store.now = func() time.Time { return now }
+
+	data := simplejson.NewFromAny(map[string]any{"title": "Recent"})
+	require.NoError(t, store.Add(ctx, 1, &Entry{UID: "old", Title: "Old", Deleted: now.Add(-8 * 24 * time.Hour)}))
+	require.NoError(t, store.Add(ctx, 1, &Entry{UID: "older", Title: "Older", Deleted: now.Add(-2 * time.Hour)}))
+	require.NoError(t, store.Add(ctx, 1, &Entry{UID: "recent", Title: "Recent", Data: data, Deleted: now.Add(-time.Hour)}))
+	require.NoError(t, store.Add(ctx, 2, &Entry{UID: "other-org", Deleted: now}))
+
+	entries, err := store.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "recent", entries[0].UID)
+	assert.Equal(t, "Recent", entries[0].Data.Get("title").MustString())
+	assert.Equal(t, "older", entries[1].UID)
+
+	_, ok, err := store.Get(ctx, 1, "old")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Delete(ctx, 1, "recent"))
+	_, ok, err = store.Get(ctx, 1, "recent")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	disabled := NewStore(kvstore.NewFakeKVStore(), 0)
+	assert.False(t, disabled.Enabled())
+	require.NoError(t, disabled.Add(ctx, 1, &Entry{UID: "x", Deleted: now}))
+	entries, err = disabled.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
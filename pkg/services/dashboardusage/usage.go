@@ -0,0 +1,81 @@
+// Package dashboardusage counts how often dashboards are viewed, to tell the
+// dashboards in use apart from stale ones.
+package dashboardusage
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+const kvNamespace = "dashboard-usage"
+
+// Usage is the view count of a dashboard and when it was last viewed.
+type Usage struct {
+	Views      int64     `json:"views"`
+	LastViewed time.Time `json:"lastViewed"`
+}
+
+// Store keeps the usage of each dashboard in the kv store. A nil store
+// records nothing.
+type Store struct {
+	kv kvstore.KVStore
+	// mu serializes the read-modify-write of the counters.
+	mu sync.Mutex
+}
+
+func NewStore(kv kvstore.KVStore) *Store {
+	return &Store{kv: kv}
+}
+
+// RecordView counts a view of the dashboard with the given uid.
+func (s *Store) RecordView(ctx context.Context, orgID int64, uid string, viewed time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, _, err := s.Get(ctx, orgID, uid)
+	if err != nil {
+		return err
+	}
+	usage.Views++
+	usage.LastViewed = viewed
+
+	value, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Set(ctx, uid, string(value))
+}
+
+// Get returns the usage of the dashboard, and whether it was ever viewed.
+func (s *Store) Get(ctx context.Context, orgID int64, uid string) (*Usage, bool, error) {
+	usage := &Usage{}
+	if s == nil {
+		return usage, false, nil
+	}
+
+	value, ok, err := kvstore.WithNamespace(s.kv, orgID, kvNamespace).Get(ctx, uid)
+	if err != nil || !ok {
+		return usage, false, err
+	}
+
+	if err := json.Unmarshal([]byte(value), usage); err != nil {
+		return nil, false, err
+	}
+	return usage, true, nil
+}
+
+// Delete drops the usage of a deleted dashboard.
+func (s *Store) Delete(ctx context.Context, orgID int64, uid string) error {
+	if s == nil {
+		return nil
+	}
+	return kvstore.WithNamespace(s.kv, orgID, kvNamespace).Del(ctx, uid)
+}
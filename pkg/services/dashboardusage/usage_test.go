@@ -0,0 +1,51 @@
+package dashboardusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(kvstore.NewFakeKVStore())
+	first := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	usage, ok, err := store.Get(ctx, 1, "dash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, &Usage{}, usage)
+
+	require.NoError(t, store.RecordView(ctx, 1, "dash", first))
+	require.NoError(t, store.RecordView(ctx, 1, "dash", first.Add(time.Hour)))
+	require.NoError(t, store.RecordView(ctx, 2, "dash", first))
+
+	usage, ok, err = store.Get(ctx, 1, "dash")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, usage.Views)
+	assert.True(t, first.Add(time.Hour).Equal(usage.LastViewed))
+
+	require.NoError(t, store.Delete(ctx, 1, "dash"))
+	_, ok, err = store.Get(ctx, 1, "dash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	usage, _, err = store.Get(ctx, 2, "dash")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, usage.Views)
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	require.NoError(t, store.RecordView(context.Background(), 1, "dash", time.Now()))
+	usage, ok, err := store.Get(context.Background(), 1, "dash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Zero(t, usage.Views)
+}
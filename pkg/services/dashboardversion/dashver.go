@@ -7,5 +7,9 @@ import (
 type Service interface {
 	Get(context.Context, *GetDashboardVersionQuery) (*DashboardVersionDTO, error)
 	DeleteExpired(context.Context, *DeleteExpiredVersionsCommand) error
+	DeleteExcess(context.Context, *DeleteExcessVersionsCommand) error
+	Prune(context.Context, *PruneVersionsCommand) error
 	List(context.Context, *ListDashboardVersionsQuery) ([]*DashboardVersionDTO, error)
+	Count(context.Context, *ListDashboardVersionsQuery) (int64, error)
+	Tag(context.Context, *TagDashboardVersionCommand) error
 }
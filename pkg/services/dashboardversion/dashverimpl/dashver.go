@@ -3,6 +3,7 @@ package dashverimpl
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -92,6 +93,39 @@ func (s *Service) DeleteExpired(ctx context.Context, cmd *dashver.DeleteExpiredV
 	return nil
 }
 
+// DeleteExcess deletes the oldest versions of a dashboard so that at most
+// VersionsToKeep versions remain. The latest version is always kept.
+func (s *Service) DeleteExcess(ctx context.Context, cmd *dashver.DeleteExcessVersionsCommand) error {
+	if cmd.VersionsToKeep < 1 {
+		cmd.VersionsToKeep = 1
+	}
+
+	deleted, err := s.store.DeleteExcess(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	cmd.DeletedRows = deleted
+	return nil
+}
+
+// Prune deletes the versions of a dashboard beyond VersionsToKeep or created
+// before CreatedBefore, keeping tagged versions and the latest version.
+func (s *Service) Prune(ctx context.Context, cmd *dashver.PruneVersionsCommand) error {
+	if cmd.VersionsToKeep < 0 {
+		cmd.VersionsToKeep = 0
+	}
+	if cmd.VersionsToKeep == 0 && cmd.CreatedBefore.IsZero() {
+		return nil
+	}
+
+	deleted, err := s.store.Prune(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	cmd.DeletedRows = deleted
+	return nil
+}
+
 // List all dashboard versions for the given dashboard ID.
 func (s *Service) List(ctx context.Context, query *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersionDTO, error) {
 	// Get the DashboardUID if not populated
@@ -127,6 +161,26 @@ func (s *Service) List(ctx context.Context, query *dashver.ListDashboardVersions
 	return dtos, nil
 }
 
+// Count returns the number of versions matching the query, ignoring its limit
+// and start.
+func (s *Service) Count(ctx context.Context, query *dashver.ListDashboardVersionsQuery) (int64, error) {
+	if query.DashboardID == 0 {
+		id, err := s.getDashIDMaybeEmpty(ctx, query.DashboardUID)
+		if err != nil {
+			return 0, err
+		}
+		query.DashboardID = id
+	}
+	return s.store.Count(ctx, query)
+}
+
+// Tag attaches a name to a dashboard version, or removes the tag of the
+// version when the name is empty.
+func (s *Service) Tag(ctx context.Context, cmd *dashver.TagDashboardVersionCommand) error {
+	cmd.Name = strings.TrimSpace(cmd.Name)
+	return s.store.Tag(ctx, cmd)
+}
+
 // getDashUIDMaybeEmpty is a helper function which takes a dashboardID and
 // returns the UID. If the dashboard is not found, it will return an empty
 // string.
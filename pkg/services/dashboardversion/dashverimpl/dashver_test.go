@@ -146,6 +146,7 @@ func TestListDashboardVersions(t *testing.T) {
 
 type FakeDashboardVersionStore struct {
 	ExpectedDashboardVersion *dashver.DashboardVersion
+	ExpectedCount            int64
 	ExptectedDeletedVersions int64
 	ExpectedVersions         []any
 	ExpectedListVersions     []*dashver.DashboardVersion
@@ -168,6 +169,22 @@ func (f *FakeDashboardVersionStore) DeleteBatch(ctx context.Context, cmd *dashve
 	return f.ExptectedDeletedVersions, f.ExpectedError
 }
 
+func (f *FakeDashboardVersionStore) DeleteExcess(ctx context.Context, cmd *dashver.DeleteExcessVersionsCommand) (int64, error) {
+	return f.ExptectedDeletedVersions, f.ExpectedError
+}
+
+func (f *FakeDashboardVersionStore) Prune(ctx context.Context, cmd *dashver.PruneVersionsCommand) (int64, error) {
+	return f.ExptectedDeletedVersions, f.ExpectedError
+}
+
 func (f *FakeDashboardVersionStore) List(ctx context.Context, query *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersion, error) {
 	return f.ExpectedListVersions, f.ExpectedError
 }
+
+func (f *FakeDashboardVersionStore) Count(ctx context.Context, query *dashver.ListDashboardVersionsQuery) (int64, error) {
+	return f.ExpectedCount, f.ExpectedError
+}
+
+func (f *FakeDashboardVersionStore) Tag(ctx context.Context, cmd *dashver.TagDashboardVersionCommand) error {
+	return f.ExpectedError
+}
@@ -10,5 +10,9 @@ type store interface {
 	Get(context.Context, *dashver.GetDashboardVersionQuery) (*dashver.DashboardVersion, error)
 	GetBatch(context.Context, *dashver.DeleteExpiredVersionsCommand, int, int) ([]any, error)
 	DeleteBatch(context.Context, *dashver.DeleteExpiredVersionsCommand, []any) (int64, error)
+	DeleteExcess(context.Context, *dashver.DeleteExcessVersionsCommand) (int64, error)
+	Prune(context.Context, *dashver.PruneVersionsCommand) (int64, error)
 	List(context.Context, *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersion, error)
+	Count(context.Context, *dashver.ListDashboardVersionsQuery) (int64, error)
+	Tag(context.Context, *dashver.TagDashboardVersionCommand) error
 }
@@ -38,6 +38,7 @@ func testIntegrationGetDashboardVersion(t *testing.T, fn getStore) {
 		assert.Equal(t, savedDash.ID, res.ID)
 		assert.Equal(t, savedDash.Version, res.Version)
 		assert.Equal(t, createdById, res.CreatedBy)
+		assert.Equal(t, dashver.SourceUI, res.Source)
 
 		dashCmd := &dashboards.Dashboard{
 			ID:    res.ID,
@@ -90,6 +91,7 @@ func testIntegrationGetDashboardVersion(t *testing.T, fn getStore) {
 		res, err := dashVerStore.List(context.Background(), &query)
 		require.Nil(t, err)
 		assert.Equal(t, 1, len(res))
+		assert.Equal(t, dashver.SourceUI, res[0].Source)
 	})
 
 	t.Run("Attempt to get the versions for a non-existent Dashboard ID", func(t *testing.T) {
@@ -111,6 +113,148 @@ func testIntegrationGetDashboardVersion(t *testing.T, fn getStore) {
 		require.Nil(t, err)
 		assert.Equal(t, 2, len(res))
 	})
+
+	t.Run("Only load the version data when requested", func(t *testing.T) {
+		query := dashver.ListDashboardVersionsQuery{DashboardID: savedDash.ID, OrgID: 1, Limit: 1000}
+		res, err := dashVerStore.List(context.Background(), &query)
+		require.Nil(t, err)
+		require.NotEmpty(t, res)
+		assert.Nil(t, res[0].Data)
+
+		query.IncludeData = true
+		res, err = dashVerStore.List(context.Background(), &query)
+		require.Nil(t, err)
+		require.NotEmpty(t, res)
+		assert.Equal(t, "different-tag", res[0].Data.Get("tags").MustString())
+	})
+
+	t.Run("Filter the versions of a dashboard", func(t *testing.T) {
+		query := dashver.ListDashboardVersionsQuery{DashboardID: savedDash.ID, OrgID: 1, Limit: 1000, CreatedBy: createdById}
+		res, err := dashVerStore.List(context.Background(), &query)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(res))
+		assert.Equal(t, 1, res[0].Version)
+
+		count, err := dashVerStore.Count(context.Background(), &query)
+		require.Nil(t, err)
+		assert.EqualValues(t, 1, count)
+
+		query = dashver.ListDashboardVersionsQuery{DashboardID: savedDash.ID, OrgID: 1, Limit: 1, From: time.Now().Add(-time.Hour)}
+		count, err = dashVerStore.Count(context.Background(), &query)
+		require.Nil(t, err)
+		assert.EqualValues(t, 2, count)
+
+		query.From = time.Now().Add(time.Hour)
+		count, err = dashVerStore.Count(context.Background(), &query)
+		require.Nil(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+
+	t.Run("Delete the versions beyond the versions to keep", func(t *testing.T) {
+		deleted, err := dashVerStore.DeleteExcess(context.Background(), &dashver.DeleteExcessVersionsCommand{DashboardID: savedDash.ID, VersionsToKeep: 1})
+		require.Nil(t, err)
+		assert.EqualValues(t, 1, deleted)
+
+		query := dashver.ListDashboardVersionsQuery{DashboardID: savedDash.ID, OrgID: 1, Limit: 1000}
+		res, err := dashVerStore.List(context.Background(), &query)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(res))
+		assert.Equal(t, 2, res[0].Version)
+	})
+
+	t.Run("Prune the versions beyond the retention except tagged versions", func(t *testing.T) {
+		prunedDash := insertTestDashboard(t, ss, "test dash prune", 1, 0, "", false, "prune")
+		for i := 0; i < 3; i++ {
+			updateTestDashboard(t, ss, prunedDash, map[string]any{"tags": "updated-" + strconv.Itoa(i)})
+		}
+		err := dashVerStore.Tag(context.Background(), &dashver.TagDashboardVersionCommand{DashboardID: prunedDash.ID, Version: 1, Name: "first"})
+		require.Nil(t, err)
+
+		listVersions := func() []int {
+			res, err := dashVerStore.List(context.Background(), &dashver.ListDashboardVersionsQuery{DashboardID: prunedDash.ID, OrgID: 1, Limit: 1000})
+			require.Nil(t, err)
+			versions := make([]int, 0, len(res))
+			for _, v := range res {
+				versions = append(versions, v.Version)
+			}
+			return versions
+		}
+
+		deleted, err := dashVerStore.Prune(context.Background(), &dashver.PruneVersionsCommand{DashboardID: prunedDash.ID, VersionsToKeep: 2})
+		require.Nil(t, err)
+		assert.EqualValues(t, 1, deleted)
+		assert.Equal(t, []int{4, 3, 1}, listVersions())
+
+		deleted, err = dashVerStore.Prune(context.Background(), &dashver.PruneVersionsCommand{DashboardID: prunedDash.ID, CreatedBefore: time.Now().Add(time.Hour)})
+		require.Nil(t, err)
+		assert.EqualValues(t, 1, deleted)
+		assert.Equal(t, []int{4, 1}, listVersions())
+
+		updateTestDashboard(t, ss, prunedDash, map[string]any{"tags": "updated-3"})
+		updateTestDashboard(t, ss, prunedDash, map[string]any{"tags": "updated-4"})
+		deleted, err = dashVerStore.Prune(context.Background(), &dashver.PruneVersionsCommand{DashboardID: prunedDash.ID, VersionsToKeep: 1, Keep: []int{5}})
+		require.Nil(t, err)
+		assert.EqualValues(t, 1, deleted)
+		assert.Equal(t, []int{6, 5, 1}, listVersions())
+	})
+
+	t.Run("Get the newest dashboard version created before a time", func(t *testing.T) {
+		restoredDash := insertTestDashboard(t, ss, "test dash created before", 1, 0, "", false, "before")
+		updateTestDashboard(t, ss, restoredDash, map[string]any{"tags": "updated"})
+		err := ss.WithDbSession(context.Background(), func(sess *db.Session) error {
+			_, err := sess.Exec("UPDATE dashboard_version SET created=? WHERE dashboard_id=? AND version=1", time.Now().Add(-48*time.Hour), restoredDash.ID)
+			return err
+		})
+		require.Nil(t, err)
+
+		res, err := dashVerStore.Get(context.Background(), &dashver.GetDashboardVersionQuery{DashboardID: restoredDash.ID, OrgID: 1, CreatedBefore: time.Now().Add(time.Hour)})
+		require.Nil(t, err)
+		assert.Equal(t, 2, res.Version)
+
+		res, err = dashVerStore.Get(context.Background(), &dashver.GetDashboardVersionQuery{DashboardID: restoredDash.ID, OrgID: 1, CreatedBefore: time.Now().Add(-24 * time.Hour)})
+		require.Nil(t, err)
+		assert.Equal(t, 1, res.Version)
+
+		_, err = dashVerStore.Get(context.Background(), &dashver.GetDashboardVersionQuery{DashboardID: restoredDash.ID, OrgID: 1, CreatedBefore: time.Now().Add(-72 * time.Hour)})
+		assert.ErrorIs(t, err, dashver.ErrDashboardVersionNotFound)
+	})
+
+	t.Run("Tag a dashboard version", func(t *testing.T) {
+		taggedDash := insertTestDashboard(t, ss, "test dash tags", 1, 0, "", false, "tags")
+		updateTestDashboard(t, ss, taggedDash, map[string]any{"tags": "updated"})
+
+		err := dashVerStore.Tag(context.Background(), &dashver.TagDashboardVersionCommand{DashboardID: taggedDash.ID, Version: 1, Name: "pre-migration"})
+		require.Nil(t, err)
+
+		res, err := dashVerStore.Get(context.Background(), &dashver.GetDashboardVersionQuery{DashboardID: taggedDash.ID, OrgID: 1, VersionTag: "pre-migration"})
+		require.Nil(t, err)
+		assert.Equal(t, 1, res.Version)
+		assert.Equal(t, "pre-migration", res.VersionTag)
+
+		versions, err := dashVerStore.List(context.Background(), &dashver.ListDashboardVersionsQuery{DashboardID: taggedDash.ID, OrgID: 1, Limit: 1000})
+		require.Nil(t, err)
+		require.Equal(t, 2, len(versions))
+		assert.Equal(t, "", versions[0].VersionTag)
+		assert.Equal(t, "pre-migration", versions[1].VersionTag)
+
+		err = dashVerStore.Tag(context.Background(), &dashver.TagDashboardVersionCommand{DashboardID: taggedDash.ID, Version: 2, Name: "pre-migration"})
+		assert.ErrorIs(t, err, dashver.ErrDashboardVersionTagTaken)
+
+		// the unique index holds when the check above is raced
+		err = ss.WithDbSession(context.Background(), func(sess *db.Session) error {
+			_, err := sess.Exec("UPDATE dashboard_version SET version_tag=? WHERE dashboard_id=? AND version=?", "pre-migration", taggedDash.ID, 2)
+			return err
+		})
+		assert.True(t, ss.GetDialect().IsUniqueConstraintViolation(err))
+
+		err = dashVerStore.Tag(context.Background(), &dashver.TagDashboardVersionCommand{DashboardID: taggedDash.ID, Version: 3, Name: "missing"})
+		assert.ErrorIs(t, err, dashver.ErrDashboardVersionNotFound)
+
+		err = dashVerStore.Tag(context.Background(), &dashver.TagDashboardVersionCommand{DashboardID: taggedDash.ID, Version: 1})
+		require.Nil(t, err)
+		_, err = dashVerStore.Get(context.Background(), &dashver.GetDashboardVersionQuery{DashboardID: taggedDash.ID, OrgID: 1, VersionTag: "pre-migration"})
+		assert.ErrorIs(t, err, dashver.ErrDashboardVersionNotFound)
+	})
 }
 
 func getDashboard(t *testing.T, sqlStore db.DB, dashboard *dashboards.Dashboard) error {
@@ -148,6 +292,7 @@ func insertTestDashboard(t *testing.T, sqlStore db.DB, title string, orgId int64
 			"tags":  tags,
 		}),
 		UserID: createdById,
+		Source: dashver.SourceUI,
 	}
 
 	var dash *dashboards.Dashboard
@@ -176,6 +321,7 @@ func insertTestDashboard(t *testing.T, sqlStore db.DB, title string, orgId int64
 			CreatedBy:     dash.UpdatedBy,
 			Message:       cmd.Message,
 			Data:          dash.Data,
+			Source:        cmd.Source,
 		}
 
 		if affectedRows, err := sess.Insert(dashVersion); err != nil {
@@ -17,8 +17,15 @@ type sqlStore struct {
 func (ss *sqlStore) Get(ctx context.Context, query *dashver.GetDashboardVersionQuery) (*dashver.DashboardVersion, error) {
 	var version dashver.DashboardVersion
 	err := ss.db.WithDbSession(ctx, func(sess *db.Session) error {
-		has, err := sess.Where("dashboard_version.dashboard_id=? AND dashboard_version.version=? AND dashboard.org_id=?", query.DashboardID, query.Version, query.OrgID).
-			Join("LEFT", "dashboard", `dashboard.id = dashboard_version.dashboard_id`).
+		sess.Where("dashboard_version.dashboard_id=? AND dashboard.org_id=?", query.DashboardID, query.OrgID)
+		if query.Version == 0 && query.VersionTag != "" {
+			sess.And("dashboard_version.version_tag=?", query.VersionTag)
+		} else if query.Version == 0 && !query.CreatedBefore.IsZero() {
+			sess.And("dashboard_version.created<=?", query.CreatedBefore).Desc("dashboard_version.version")
+		} else {
+			sess.And("dashboard_version.version=?", query.Version)
+		}
+		has, err := sess.Join("LEFT", "dashboard", `dashboard.id = dashboard_version.dashboard_id`).
 			Get(&version)
 
 		if err != nil {
@@ -71,11 +78,68 @@ func (ss *sqlStore) DeleteBatch(ctx context.Context, cmd *dashver.DeleteExpiredV
 	return deleted, err
 }
 
+func (ss *sqlStore) DeleteExcess(ctx context.Context, cmd *dashver.DeleteExcessVersionsCommand) (int64, error) {
+	var deleted int64
+	err := ss.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		var versionIds []int64
+		err := sess.Table("dashboard_version").
+			Cols("id").
+			Where("dashboard_id=?", cmd.DashboardID).
+			OrderBy("version DESC").
+			Find(&versionIds)
+		if err != nil {
+			return err
+		}
+		if len(versionIds) <= cmd.VersionsToKeep {
+			return nil
+		}
+
+		deleted, err = sess.In("id", versionIds[cmd.VersionsToKeep:]).Delete(&dashver.DashboardVersion{})
+		return err
+	})
+	return deleted, err
+}
+
+func (ss *sqlStore) Prune(ctx context.Context, cmd *dashver.PruneVersionsCommand) (int64, error) {
+	var deleted int64
+	err := ss.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		var versions []*dashver.DashboardVersion
+		err := sess.Table("dashboard_version").
+			Cols("id", "version", "created", "version_tag").
+			Where("dashboard_id=?", cmd.DashboardID).
+			OrderBy("version DESC").
+			Find(&versions)
+		if err != nil {
+			return err
+		}
+
+		keep := make(map[int]bool, len(cmd.Keep))
+		for _, version := range cmd.Keep {
+			keep[version] = true
+		}
+		var versionIds []int64
+		for i, v := range versions {
+			if i == 0 || v.VersionTag != "" || keep[v.Version] {
+				continue
+			}
+			if (cmd.VersionsToKeep > 0 && i >= cmd.VersionsToKeep) || (!cmd.CreatedBefore.IsZero() && v.Created.Before(cmd.CreatedBefore)) {
+				versionIds = append(versionIds, v.ID)
+			}
+		}
+		if len(versionIds) == 0 {
+			return nil
+		}
+
+		deleted, err = sess.In("id", versionIds).Delete(&dashver.DashboardVersion{})
+		return err
+	})
+	return deleted, err
+}
+
 func (ss *sqlStore) List(ctx context.Context, query *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersion, error) {
 	var dashboardVersion []*dashver.DashboardVersion
 	err := ss.db.WithDbSession(ctx, func(sess *db.Session) error {
-		err := sess.Table("dashboard_version").
-			Select(`dashboard_version.id,
+		columns := `dashboard_version.id,
 				dashboard_version.dashboard_id,
 				dashboard_version.parent_version,
 				dashboard_version.restored_from,
@@ -83,10 +147,17 @@ func (ss *sqlStore) List(ctx context.Context, query *dashver.ListDashboardVersio
 				dashboard_version.created,
 				dashboard_version.created_by,
 				dashboard_version.message,
-				dashboard_version.data`).
-			Join("LEFT", "dashboard", `dashboard.id = dashboard_version.dashboard_id`).
-			Where("dashboard_version.dashboard_id=? AND dashboard.org_id=?", query.DashboardID, query.OrgID).
-			OrderBy("dashboard_version.version DESC").
+				dashboard_version.version_tag,
+				dashboard_version.source`
+		if query.IncludeData {
+			columns += `,
+				dashboard_version.data`
+		}
+		sess.Table("dashboard_version").
+			Select(columns).
+			Join("LEFT", "dashboard", `dashboard.id = dashboard_version.dashboard_id`)
+		filterVersions(sess, query)
+		err := sess.OrderBy("dashboard_version.version DESC").
 			Limit(query.Limit, query.Start).
 			Find(&dashboardVersion)
 		if err != nil {
@@ -103,3 +174,69 @@ func (ss *sqlStore) List(ctx context.Context, query *dashver.ListDashboardVersio
 	}
 	return dashboardVersion, nil
 }
+
+func (ss *sqlStore) Count(ctx context.Context, query *dashver.ListDashboardVersionsQuery) (int64, error) {
+	var count int64
+	err := ss.db.WithDbSession(ctx, func(sess *db.Session) error {
+		sess.Table("dashboard_version").
+			Join("LEFT", "dashboard", `dashboard.id = dashboard_version.dashboard_id`)
+
+		filterVersions(sess, query)
+
+		var err error
+		count, err = sess.Count()
+		return err
+	})
+	return count, err
+}
+
+func (ss *sqlStore) Tag(ctx context.Context, cmd *dashver.TagDashboardVersionCommand) error {
+	return ss.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if cmd.Name != "" {
+			taken, err := sess.Table("dashboard_version").
+				Where("dashboard_id=? AND version_tag=? AND version<>?", cmd.DashboardID, cmd.Name, cmd.Version).
+				Exist()
+			if err != nil {
+				return err
+			}
+			if taken {
+				return dashver.ErrDashboardVersionTagTaken
+			}
+		}
+
+		var tag any
+		if cmd.Name != "" {
+			tag = cmd.Name
+		}
+		res, err := sess.Exec("UPDATE dashboard_version SET version_tag=? WHERE dashboard_id=? AND version=?", tag, cmd.DashboardID, cmd.Version)
+		if err != nil {
+			// the tag was taken by a concurrent request since it was checked
+			if ss.db.GetDialect().IsUniqueConstraintViolation(err) {
+				return dashver.ErrDashboardVersionTagTaken
+			}
+			return err
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return dashver.ErrDashboardVersionNotFound
+		}
+		return nil
+	})
+}
+
+// filterVersions restricts the session to the versions matching the query.
+func filterVersions(sess *db.Session, query *dashver.ListDashboardVersionsQuery) {
+	sess.Where("dashboard_version.dashboard_id=? AND dashboard.org_id=?", query.DashboardID, query.OrgID)
+	if query.CreatedBy > 0 {
+		sess.And("dashboard_version.created_by=?", query.CreatedBy)
+	}
+	if !query.From.IsZero() {
+		sess.And("dashboard_version.created>=?", query.From)
+	}
+	if !query.To.IsZero() {
+		sess.And("dashboard_version.created<=?", query.To)
+	}
+}
@@ -10,6 +10,7 @@ type FakeDashboardVersionService struct {
 	ExpectedDashboardVersion     *dashver.DashboardVersionDTO
 	ExpectedDashboardVersions    []*dashver.DashboardVersionDTO
 	ExpectedListDashboarVersions []*dashver.DashboardVersionDTO
+	ExpectedCount                int64
 	counter                      int
 	ExpectedError                error
 }
@@ -30,6 +31,22 @@ func (f *FakeDashboardVersionService) DeleteExpired(ctx context.Context, cmd *da
 	return f.ExpectedError
 }
 
+func (f *FakeDashboardVersionService) DeleteExcess(ctx context.Context, cmd *dashver.DeleteExcessVersionsCommand) error {
+	return f.ExpectedError
+}
+
+func (f *FakeDashboardVersionService) Prune(ctx context.Context, cmd *dashver.PruneVersionsCommand) error {
+	return f.ExpectedError
+}
+
 func (f *FakeDashboardVersionService) List(ctx context.Context, query *dashver.ListDashboardVersionsQuery) ([]*dashver.DashboardVersionDTO, error) {
 	return f.ExpectedListDashboarVersions, f.ExpectedError
 }
+
+func (f *FakeDashboardVersionService) Count(ctx context.Context, query *dashver.ListDashboardVersionsQuery) (int64, error) {
+	return f.ExpectedCount, f.ExpectedError
+}
+
+func (f *FakeDashboardVersionService) Tag(ctx context.Context, cmd *dashver.TagDashboardVersionCommand) error {
+	return f.ExpectedError
+}
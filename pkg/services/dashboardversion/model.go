@@ -10,6 +10,15 @@ import (
 var (
 	ErrDashboardVersionNotFound = errors.New("dashboard version not found")
 	ErrNoVersionsForDashboardID = errors.New("no dashboard versions found for the given DashboardId")
+	ErrDashboardVersionTagTaken = errors.New("the version tag is already used by another version of the dashboard")
+)
+
+// Sources of a dashboard version, telling how the dashboard was saved.
+// Versions saved before the source was recorded have no source.
+const (
+	SourceUI           = "ui"
+	SourceAPI          = "api"
+	SourceProvisioning = "provisioning"
 )
 
 // DashboardVersion represents a dashboard version in the database. Ideally this
@@ -26,8 +35,10 @@ type DashboardVersion struct {
 	Created   time.Time `json:"created" db:"created"`
 	CreatedBy int64     `json:"createdBy" db:"created_by"`
 
-	Message string           `json:"message" db:"message"`
-	Data    *simplejson.Json `json:"data" db:"data"`
+	Message    string           `json:"message" db:"message"`
+	Data       *simplejson.Json `json:"data" db:"data"`
+	VersionTag string           `json:"versionTag" xorm:"<- 'version_tag'" db:"version_tag"`
+	Source     string           `json:"source" xorm:"source" db:"source"`
 }
 
 // ToDTO converts a DashboardVersion to a DashboardVersionDTO.
@@ -43,6 +54,8 @@ func (v *DashboardVersion) ToDTO(dashUid string) *DashboardVersionDTO {
 		CreatedBy:     v.CreatedBy,
 		Message:       v.Message,
 		Data:          v.Data,
+		VersionTag:    v.VersionTag,
+		Source:        v.Source,
 	}
 }
 
@@ -53,18 +66,61 @@ type GetDashboardVersionQuery struct {
 	DashboardUID string
 	OrgID        int64
 	Version      int
+	// VersionTag looks the version up by its tag when Version is not set.
+	VersionTag string
+	// CreatedBefore looks up the newest version created at or before the
+	// given time when neither Version nor VersionTag is set.
+	CreatedBefore time.Time
+}
+
+// TagDashboardVersionCommand attaches a name to a dashboard version. A tag
+// name is unique among the versions of a dashboard, and an empty name removes
+// the tag of the version.
+type TagDashboardVersionCommand struct {
+	DashboardID int64
+	Version     int
+	Name        string
 }
 
 type DeleteExpiredVersionsCommand struct {
 	DeletedRows int64
 }
 
+// DeleteExcessVersionsCommand deletes the oldest versions of a dashboard
+// beyond the given number of versions to keep.
+type DeleteExcessVersionsCommand struct {
+	DashboardID    int64
+	VersionsToKeep int
+	DeletedRows    int64
+}
+
+// PruneVersionsCommand deletes the versions of a dashboard beyond the given
+// number of versions to keep or created before the given time. Tagged
+// versions and the latest version are always kept.
+type PruneVersionsCommand struct {
+	DashboardID int64
+	// VersionsToKeep is the number of latest versions kept, 0 keeps all.
+	VersionsToKeep int
+	// CreatedBefore, when set, deletes the versions created before it.
+	CreatedBefore time.Time
+	// Keep lists further versions that are never deleted.
+	Keep        []int
+	DeletedRows int64
+}
+
 type ListDashboardVersionsQuery struct {
 	DashboardID  int64
 	DashboardUID string
 	OrgID        int64
 	Limit        int
 	Start        int
+	// CreatedBy, when set, only matches versions saved by this user.
+	CreatedBy int64
+	// From and To, when set, bound the creation time of the versions.
+	From time.Time
+	To   time.Time
+	// IncludeData loads the dashboard JSON of each version.
+	IncludeData bool
 }
 type DashboardVersionDTO struct {
 	ID            int64            `json:"id"`
@@ -77,6 +133,8 @@ type DashboardVersionDTO struct {
 	CreatedBy     int64            `json:"createdBy"`
 	Message       string           `json:"message"`
 	Data          *simplejson.Json `json:"data" db:"data"`
+	VersionTag    string           `json:"versionTag"`
+	Source        string           `json:"source"`
 }
 
 // DashboardVersionMeta extends the DashboardVersionDTO with the names
@@ -91,6 +149,10 @@ type DashboardVersionMeta struct {
 	Version       int              `json:"version"`
 	Created       time.Time        `json:"created"`
 	Message       string           `json:"message"`
-	Data          *simplejson.Json `json:"data"`
+	Data          *simplejson.Json `json:"data,omitempty"`
 	CreatedBy     string           `json:"createdBy"`
+	VersionTag    string           `json:"versionTag,omitempty"`
+	// Source tells whether the version was saved from the UI, through the
+	// API or by provisioning, one of ui, api and provisioning.
+	Source string `json:"source"`
 }
@@ -27,6 +27,7 @@ func (l *LibraryElementService) registerAPIEndpoints() {
 			entities.Get("/", authorize(ac.EvalPermission(ActionLibraryPanelsRead)), routing.Wrap(l.getAllHandler))
 			entities.Get("/:uid", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getHandler))
 			entities.Get("/:uid/connections/", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getConnectionsHandler))
+			entities.Get("/:uid/dashboards", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getDashboardsHandler))
 			entities.Get("/name/:name", routing.Wrap(l.getByNameHandler))
 			entities.Patch("/:uid", authorize(ac.EvalPermission(ActionLibraryPanelsWrite, uidScope)), routing.Wrap(l.patchHandler))
 		} else {
@@ -35,6 +36,7 @@ func (l *LibraryElementService) registerAPIEndpoints() {
 			entities.Get("/", routing.Wrap(l.getAllHandler))
 			entities.Get("/:uid", routing.Wrap(l.getHandler))
 			entities.Get("/:uid/connections/", routing.Wrap(l.getConnectionsHandler))
+			entities.Get("/:uid/dashboards", routing.Wrap(l.getDashboardsHandler))
 			entities.Get("/name/:name", routing.Wrap(l.getByNameHandler))
 			entities.Patch("/:uid", routing.Wrap(l.patchHandler))
 		}
@@ -263,6 +265,28 @@ func (l *LibraryElementService) getConnectionsHandler(c *contextmodel.ReqContext
 	return response.JSON(http.StatusOK, model.LibraryElementConnectionsResponse{Result: connections})
 }
 
+// swagger:route GET /library-elements/{library_element_uid}/dashboards library_elements getLibraryElementDashboards
+//
+// Get dashboards using a library element.
+//
+// Returns the uid, title, folder and url of the dashboards connected to the library element with the given
+// UID, ordered by title. Only the dashboards the signed in user can view are returned.
+//
+// Responses:
+// 200: getLibraryElementDashboardsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) getDashboardsHandler(c *contextmodel.ReqContext) response.Response {
+	connected, err := l.getConnectedDashboards(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"])
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get dashboards")
+	}
+
+	return response.JSON(http.StatusOK, model.LibraryElementDashboardsResponse{Result: connected})
+}
+
 // swagger:route GET /library-elements/name/{library_element_name} library_elements getLibraryElementByName
 //
 // Get library element by name.
@@ -338,7 +362,7 @@ func toLibraryElementError(err error, message string) response.Response {
 	return response.ErrOrFallback(http.StatusInternalServerError, message, err)
 }
 
-// swagger:parameters getLibraryElementByUID getLibraryElementConnections
+// swagger:parameters getLibraryElementByUID getLibraryElementConnections getLibraryElementDashboards
 type LibraryElementByUID struct {
 	// in:path
 	// required:true
@@ -453,3 +477,9 @@ type GetLibraryElementConnectionsResponse struct {
 	// in: body
 	Body model.LibraryElementConnectionsResponse `json:"body"`
 }
+
+// swagger:response getLibraryElementDashboardsResponse
+type GetLibraryElementDashboardsResponse struct {
+	// in: body
+	Body model.LibraryElementDashboardsResponse `json:"body"`
+}
@@ -693,6 +693,54 @@ func (l *LibraryElementService) getConnections(c context.Context, signedInUser i
 	return connections, err
 }
 
+// getConnectedDashboards gets the dashboards connected to a library element
+// that the signed in user can view.
+func (l *LibraryElementService) getConnectedDashboards(c context.Context, signedInUser identity.Requester, uid string) ([]model.LibraryElementDashboardDTO, error) {
+	connected := make([]model.LibraryElementDashboardDTO, 0)
+	recursiveQueriesAreSupported, err := l.SQLStore.RecursiveQueriesAreSupported()
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+		element, err := GetLibraryElement(l.SQLStore.GetDialect(), session, uid, signedInUser.GetOrgID())
+		if err != nil {
+			return err
+		}
+		builder := db.NewSqlBuilder(l.Cfg, l.features, l.SQLStore.GetDialect(), recursiveQueriesAreSupported)
+		builder.Write("SELECT DISTINCT dashboard.uid, dashboard.title, dashboard.slug, coalesce(dashboard.folder_uid, '') AS folder_uid")
+		builder.Write(" FROM " + model.LibraryElementConnectionTableName + " AS lec")
+		builder.Write(" INNER JOIN dashboard AS dashboard on lec.connection_id = dashboard.id")
+		builder.Write(` WHERE lec.element_id=? AND lec.kind=?`, element.ID, model.Dashboard)
+		if signedInUser.GetOrgRole() != org.RoleAdmin {
+			builder.WriteDashboardPermissionFilter(signedInUser, dashboards.PERMISSION_VIEW, "")
+		}
+		builder.Write(" ORDER BY dashboard.title")
+		var dashes []struct {
+			UID       string `xorm:"uid"`
+			Title     string `xorm:"title"`
+			Slug      string `xorm:"slug"`
+			FolderUID string `xorm:"folder_uid"`
+		}
+		if err := session.SQL(builder.GetSQLString(), builder.GetParams()...).Find(&dashes); err != nil {
+			return err
+		}
+
+		for _, dash := range dashes {
+			connected = append(connected, model.LibraryElementDashboardDTO{
+				UID:       dash.UID,
+				Title:     dash.Title,
+				FolderUID: dash.FolderUID,
+				URL:       dashboards.GetDashboardURL(dash.UID, dash.Slug),
+			})
+		}
+
+		return nil
+	})
+
+	return connected, err
+}
+
 // getElementsForDashboardID gets all elements for a specific dashboard
 func (l *LibraryElementService) getElementsForDashboardID(c context.Context, dashboardID int64) (map[string]model.LibraryElementDTO, error) {
 	libraryElementMap := make(map[string]model.LibraryElementDTO)
@@ -190,6 +190,50 @@ func TestGetLibraryPanelConnections(t *testing.T) {
 		})
 }
 
+func TestGetLibraryPanelDashboards(t *testing.T) {
+	scenarioWithPanel(t, "When an admin tries to get the dashboards of a library panel, it should return the connected dashboards",
+		func(t *testing.T, sc scenarioContext) {
+			dashJSON := map[string]any{
+				"panels": []any{
+					map[string]any{
+						"id": int64(1),
+						"libraryPanel": map[string]any{
+							"uid":  sc.initialResult.Result.UID,
+							"name": sc.initialResult.Result.Name,
+						},
+					},
+				},
+			}
+			dash := dashboards.Dashboard{
+				Title: "Testing GetLibraryPanelDashboards",
+				Data:  simplejson.NewFromAny(dashJSON),
+			}
+			dashInDB := createDashboard(t, sc.sqlStore, sc.user, &dash, sc.folder.ID)
+			err := sc.service.ConnectElementsToDashboard(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, []string{sc.initialResult.Result.UID}, dashInDB.ID)
+			require.NoError(t, err)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			resp := sc.service.getDashboardsHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result model.LibraryElementDashboardsResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+
+			require.Equal(t, []model.LibraryElementDashboardDTO{{
+				UID:       dashInDB.UID,
+				Title:     dashInDB.Title,
+				FolderUID: dashInDB.FolderUID,
+				URL:       dashInDB.GetURL(),
+			}}, result.Result)
+		})
+
+	scenarioWithPanel(t, "When an admin tries to get the dashboards of a library panel that does not exist, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": "unknown"})
+			resp := sc.service.getDashboardsHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+}
+
 type libraryElement struct {
 	ID    int64 `json:"id"`
 	OrgID int64 `json:"orgId"`
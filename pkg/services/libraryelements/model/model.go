@@ -166,6 +166,14 @@ type LibraryElementConnectionDTO struct {
 	CreatedBy     librarypanel.LibraryElementDTOMetaUser `json:"createdBy"`
 }
 
+// LibraryElementDashboardDTO is a dashboard connected to a library element.
+type LibraryElementDashboardDTO struct {
+	UID       string `json:"uid"`
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid"`
+	URL       string `json:"url"`
+}
+
 var (
 	// errLibraryElementAlreadyExists is an error for when the user tries to add a library element that already exists.
 	ErrLibraryElementAlreadyExists = errors.New("library element with that name or UID already exists")
@@ -278,6 +286,11 @@ type LibraryElementConnectionsResponse struct {
 	Result []LibraryElementConnectionDTO `json:"result"`
 }
 
+// LibraryElementDashboardsResponse is a response struct for an array of LibraryElementDashboardDTO.
+type LibraryElementDashboardsResponse struct {
+	Result []LibraryElementDashboardDTO `json:"result"`
+}
+
 // DeleteLibraryElementResponse is the response struct for deleting a library element.
 type DeleteLibraryElementResponse struct {
 	ID      int64  `json:"id"`
@@ -21,11 +21,14 @@ type actionType string
 
 const (
 	ActionSaved    actionType = "saved"
+	ActionCreated  actionType = "created"
+	ActionUpdated  actionType = "updated"
 	ActionDeleted  actionType = "deleted"
 	EditingStarted actionType = "editing-started"
 	//EditingFinished actionType = "editing-finished"
 
-	GitopsChannel = "grafana/dashboard/gitops"
+	GitopsChannel  = "grafana/dashboard/gitops"
+	ChangesChannel = "grafana/dashboard/changes"
 )
 
 // DashboardEvent events related to dashboards
@@ -39,6 +42,20 @@ type dashboardEvent struct {
 	Error     string                `json:"error,omitempty"`
 }
 
+// DashboardChangeEvent is published on the changes channel whenever a
+// dashboard of the org is created, updated or deleted. PreviousVersion lets
+// subscribers detect events delivered out of order; it is 0 for created
+// dashboards, and Version is 0 for deleted ones.
+type DashboardChangeEvent struct {
+	OrgID           int64      `json:"orgId"`
+	UID             string     `json:"uid"`
+	Action          actionType `json:"action"` // created, updated, deleted
+	Version         int        `json:"version"`
+	PreviousVersion int        `json:"previousVersion"`
+	FolderUID       string     `json:"folderUid"`
+	UserID          int64      `json:"userId"`
+}
+
 // DashboardHandler manages all the `grafana/dashboard/*` channels
 type DashboardHandler struct {
 	Publisher        model.ChannelPublisher
@@ -55,8 +72,8 @@ func (h *DashboardHandler) GetHandlerForPath(_ string) (model.ChannelHandler, er
 // OnSubscribe for now allows anyone to subscribe to any dashboard
 func (h *DashboardHandler) OnSubscribe(ctx context.Context, user identity.Requester, e model.SubscribeEvent) (model.SubscribeReply, backend.SubscribeStreamStatus, error) {
 	parts := strings.Split(e.Path, "/")
-	if parts[0] == "gitops" {
-		// gitops gets all changes for everything, so lets make sure it is an admin user
+	if parts[0] == "gitops" || parts[0] == "changes" {
+		// gitops and changes get all changes for everything, so lets make sure it is an admin user
 		if !user.HasRole(org.RoleAdmin) {
 			return model.SubscribeReply{}, backend.SubscribeStreamStatusPermissionDenied, nil
 		}
@@ -206,6 +223,15 @@ func (h *DashboardHandler) DashboardDeleted(orgID int64, user *user.UserDisplayD
 	})
 }
 
+// DashboardChanged will broadcast the change to the changes channel
+func (h *DashboardHandler) DashboardChanged(event DashboardChangeEvent) error {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return h.Publisher(event.OrgID, ChangesChannel, msg)
+}
+
 // HasGitOpsObserver will return true if anyone is listening to the `gitops` channel
 func (h *DashboardHandler) HasGitOpsObserver(orgID int64) bool {
 	count, err := h.ClientCount(orgID, GitopsChannel)
@@ -419,6 +419,10 @@ type DashboardActivityChannel interface {
 	// Called when a dashboard is deleted
 	DashboardDeleted(orgID int64, user *user.UserDisplayDTO, uid string) error
 
+	// Called when a dashboard is created, updated or deleted, to notify the
+	// subscribers of the org wide `grafana/dashboard/changes` channel
+	DashboardChanged(event features.DashboardChangeEvent) error
+
 	// Experimental! Indicate is GitOps is active.  This really means
 	// someone is subscribed to the `grafana/dashboards/gitops` channel
 	HasGitOpsObserver(orgID int64) bool
@@ -10,13 +10,18 @@ import (
 type FakeQuotaService struct {
 	reached bool
 	err     error
+	// ExpectedQuotas are returned by GetQuotasByScope, keyed by scope.
+	ExpectedQuotas map[quota.Scope][]quota.QuotaDTO
 }
 
 func New(reached bool, err error) *FakeQuotaService {
-	return &FakeQuotaService{reached, err}
+	return &FakeQuotaService{reached: reached, err: err}
 }
 
 func (f *FakeQuotaService) GetQuotasByScope(ctx context.Context, scope quota.Scope, id int64) ([]quota.QuotaDTO, error) {
+	if quotas, ok := f.ExpectedQuotas[scope]; ok {
+		return quotas, nil
+	}
 	return []quota.QuotaDTO{}, nil
 }
 
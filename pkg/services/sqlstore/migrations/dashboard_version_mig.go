@@ -56,4 +56,20 @@ FROM dashboard;`
 	// change column type of dashboard_version.data
 	mg.AddMigration("alter dashboard_version.data to mediumtext v1", NewRawSQLMigration("").
 		Mysql("ALTER TABLE dashboard_version MODIFY data MEDIUMTEXT;"))
+
+	mg.AddMigration("Add column version_tag in dashboard_version", NewAddColumnMigration(dashboardVersionV1, &Column{
+		Name: "version_tag", Type: DB_NVarchar, Length: 190, Nullable: true,
+	}))
+
+	mg.AddMigration("Add column source in dashboard_version", NewAddColumnMigration(dashboardVersionV1, &Column{
+		Name: "source", Type: DB_NVarchar, Length: 20, Nullable: true,
+	}))
+
+	// a tag names a single version of a dashboard. Untagged versions have a null
+	// tag, versions are inserted without one as the version tag is only read
+	// into the model and set by tagging a version.
+	mg.AddMigration("Set empty dashboard_version.version_tag to null", NewRawSQLMigration("UPDATE dashboard_version SET version_tag = NULL WHERE version_tag = ''"))
+	mg.AddMigration("add unique index dashboard_version.dashboard_id and dashboard_version.version_tag", NewAddIndexMigration(dashboardVersionV1, &Index{
+		Cols: []string{"dashboard_id", "version_tag"}, Type: UniqueIndex,
+	}))
 }
@@ -49,6 +49,11 @@ type IsStarredByUserQuery struct {
 	DashboardID int64 `xorm:"dashboard_id"`
 }
 
+// CountDashboardStarsQuery counts the users who starred a dashboard.
+type CountDashboardStarsQuery struct {
+	DashboardID int64 `xorm:"dashboard_id"`
+}
+
 type GetUserStarsResult struct {
 	UserStars map[int64]bool
 }
@@ -10,4 +10,5 @@ type Service interface {
 	DeleteByUser(context.Context, int64) error
 	IsStarredByUser(context.Context, *IsStarredByUserQuery) (bool, error)
 	GetByUser(context.Context, *GetUserStarsQuery) (*GetUserStarsResult, error)
+	CountByDashboard(context.Context, *CountDashboardStarsQuery) (int64, error)
 }
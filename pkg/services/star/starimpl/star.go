@@ -44,3 +44,7 @@ func (s *Service) GetByUser(ctx context.Context, cmd *star.GetUserStarsQuery) (*
 func (s *Service) DeleteByUser(ctx context.Context, userID int64) error {
 	return s.store.DeleteByUser(ctx, userID)
 }
+
+func (s *Service) CountByDashboard(ctx context.Context, query *star.CountDashboardStarsQuery) (int64, error) {
+	return s.store.Count(ctx, query)
+}
@@ -48,6 +48,16 @@ func testIntegrationUserStarsDataAccess(t *testing.T, fn getStore) {
 				require.Equal(t, 1, len(result.UserStars))
 			})
 
+			t.Run("Count should return the number of users who starred the dashboard", func(t *testing.T) {
+				err := starStore.Insert(context.Background(), &star.StarDashboardCommand{DashboardID: 10, UserID: 13})
+				require.NoError(t, err)
+				count, err := starStore.Count(context.Background(), &star.CountDashboardStarsQuery{DashboardID: 10})
+				require.NoError(t, err)
+				require.EqualValues(t, 2, count)
+				err = starStore.Delete(context.Background(), &star.UnstarDashboardCommand{DashboardID: 10, UserID: 13})
+				require.NoError(t, err)
+			})
+
 			t.Run("Delete should remove the star", func(t *testing.T) {
 				deleteQuery := star.UnstarDashboardCommand{DashboardID: 10, UserID: 12}
 				err := starStore.Delete(context.Background(), &deleteQuery)
@@ -71,3 +71,13 @@ func (s *sqlStore) List(ctx context.Context, query *star.GetUserStarsQuery) (*st
 	})
 	return &star.GetUserStarsResult{UserStars: userStars}, err
 }
+
+func (s *sqlStore) Count(ctx context.Context, query *star.CountDashboardStarsQuery) (int64, error) {
+	var count int64
+	err := s.db.WithDbSession(ctx, func(dbSession *db.Session) error {
+		var err error
+		count, err = dbSession.Where("dashboard_id=?", query.DashboardID).Count(&star.Star{})
+		return err
+	})
+	return count, err
+}
@@ -10,6 +10,7 @@ type FakeStarService struct {
 	ExpectedStars     *star.Star
 	ExpectedError     error
 	ExpectedUserStars *star.GetUserStarsResult
+	ExpectedCount     int64
 }
 
 func NewStarServiceFake() *FakeStarService {
@@ -35,3 +36,7 @@ func (f *FakeStarService) DeleteByUser(ctx context.Context, userID int64) error
 func (f *FakeStarService) GetByUser(ctx context.Context, query *star.GetUserStarsQuery) (*star.GetUserStarsResult, error) {
 	return f.ExpectedUserStars, f.ExpectedError
 }
+
+func (f *FakeStarService) CountByDashboard(ctx context.Context, query *star.CountDashboardStarsQuery) (int64, error) {
+	return f.ExpectedCount, f.ExpectedError
+}
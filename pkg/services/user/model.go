@@ -209,6 +209,12 @@ type GetUserByIDQuery struct {
 	ID int64
 }
 
+// GetUsersByIDsQuery gets the users with the given ids. Ids that don't match
+// a user are ignored.
+type GetUsersByIDsQuery struct {
+	IDs []int64
+}
+
 type ErrCaseInsensitiveLoginConflict struct {
 	Users []User
 }
@@ -21,6 +21,7 @@ type store interface {
 	Insert(context.Context, *user.User) (int64, error)
 	Get(context.Context, *user.User) (*user.User, error)
 	GetByID(context.Context, int64) (*user.User, error)
+	GetByIDs(context.Context, []int64) ([]*user.User, error)
 	GetNotServiceAccount(context.Context, int64) (*user.User, error)
 	Delete(context.Context, int64) error
 	LoginConflict(ctx context.Context, login, email string, caseInsensitive bool) error
@@ -159,6 +160,20 @@ func (ss *sqlStore) GetByID(ctx context.Context, userID int64) (*user.User, erro
 	return &usr, err
 }
 
+func (ss *sqlStore) GetByIDs(ctx context.Context, userIDs []int64) ([]*user.User, error) {
+	users := make([]*user.User, 0, len(userIDs))
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	err := ss.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.In("id", userIDs).
+			Where(ss.notServiceAccountFilter()).
+			Find(&users)
+	})
+	return users, err
+}
+
 func (ss *sqlStore) notServiceAccountFilter() string {
 	return fmt.Sprintf("%s.is_service_account = %s",
 		ss.dialect.Quote("user"),
@@ -191,6 +191,13 @@ func TestIntegrationUserDataAccess(t *testing.T) {
 		require.Len(t, result.Salt, 10)
 		require.False(t, result.IsDisabled)
 
+		t.Run("Get users by ids", func(t *testing.T) {
+			users, err := userStore.GetByIDs(context.Background(), []int64{usr.ID, 9999})
+			require.NoError(t, err)
+			require.Len(t, users, 1)
+			require.Equal(t, "user_test_login", users[0].Login)
+		})
+
 		t.Run("Get User by email case insensitive", func(t *testing.T) {
 			userStore.cfg.CaseInsensitiveLogin = true
 			query := user.GetUserByEmailQuery{Email: "USERtest@TEST.COM"}
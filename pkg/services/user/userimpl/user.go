@@ -215,6 +215,26 @@ func (s *Service) GetByID(ctx context.Context, query *user.GetUserByIDQuery) (*u
 	return user, nil
 }
 
+func (s *Service) GetByIDs(ctx context.Context, query *user.GetUsersByIDsQuery) ([]*user.User, error) {
+	users, err := s.store.GetByIDs(ctx, query.IDs)
+	if err != nil {
+		return nil, err
+	}
+	if !s.cfg.CaseInsensitiveLogin {
+		return users, nil
+	}
+
+	// like GetByID, leave out users whose login conflicts with another user
+	result := make([]*user.User, 0, len(users))
+	for _, usr := range users {
+		if err := s.store.CaseInsensitiveLoginConflict(ctx, usr.Login, usr.Email); err != nil {
+			continue
+		}
+		result = append(result, usr)
+	}
+	return result, nil
+}
+
 func (s *Service) GetByLogin(ctx context.Context, query *user.GetUserByLoginQuery) (*user.User, error) {
 	return s.store.GetByLogin(ctx, query)
 }
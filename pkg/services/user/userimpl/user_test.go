@@ -263,6 +263,13 @@ func (f *FakeUserStore) GetByID(context.Context, int64) (*user.User, error) {
 	return f.ExpectedUser, f.ExpectedError
 }
 
+func (f *FakeUserStore) GetByIDs(context.Context, []int64) ([]*user.User, error) {
+	if f.ExpectedUser == nil {
+		return nil, f.ExpectedError
+	}
+	return []*user.User{f.ExpectedUser}, f.ExpectedError
+}
+
 func (f *FakeUserStore) CaseInsensitiveLoginConflict(context.Context, string, string) error {
 	return f.ExpectedError
 }
@@ -8,6 +8,7 @@ import (
 
 type FakeUserService struct {
 	ExpectedUser             *user.User
+	ExpectedUsers            []*user.User
 	ExpectedSignedInUser     *user.SignedInUser
 	ExpectedError            error
 	ExpectedSetUsingOrgError error
@@ -52,6 +53,13 @@ func (f *FakeUserService) GetByID(ctx context.Context, query *user.GetUserByIDQu
 	return f.ExpectedUser, f.ExpectedError
 }
 
+func (f *FakeUserService) GetByIDs(ctx context.Context, query *user.GetUsersByIDsQuery) ([]*user.User, error) {
+	if f.ExpectedUsers == nil && f.ExpectedUser != nil {
+		return []*user.User{f.ExpectedUser}, f.ExpectedError
+	}
+	return f.ExpectedUsers, f.ExpectedError
+}
+
 func (f *FakeUserService) GetByLogin(ctx context.Context, query *user.GetUserByLoginQuery) (*user.User, error) {
 	return f.ExpectedUser, f.ExpectedError
 }
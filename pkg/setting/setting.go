@@ -268,6 +268,21 @@ type Cfg struct {
 
 	// Dashboards
 	DefaultHomeDashboardPath string
+	// DashboardTrashRetention is how long deleted dashboards can be restored.
+	// Zero deletes dashboards permanently.
+	DashboardTrashRetention time.Duration
+	// DashboardVersionRetentionCount and DashboardVersionRetentionAge bound
+	// the versions kept after each dashboard save. Zero disables the bound.
+	DashboardVersionRetentionCount int
+	DashboardVersionRetentionAge   time.Duration
+	// DashboardBatchMaxDashboards is the maximum number of dashboards that
+	// can be fetched with a single batch request.
+	DashboardBatchMaxDashboards int
+	// DashboardAuditLog logs an audit entry for every dashboard mutation.
+	DashboardAuditLog bool
+	// DashboardDefaultDiffType is the diff type used when a diff request
+	// doesn't set one.
+	DashboardDefaultDiffType string
 
 	// Auth
 	LoginCookieName              string
@@ -524,6 +539,8 @@ type Cfg struct {
 
 	Search SearchSettings
 
+	FolderConventions FolderConventionSettings
+
 	SecureSocksDSProxy SecureSocksDSProxySettings
 
 	// SAML Auth
@@ -1090,6 +1107,23 @@ func (cfg *Cfg) Load(args CommandLineArgs) error {
 	MinRefreshInterval = valueAsString(dashboards, "min_refresh_interval", "5s")
 
 	cfg.DefaultHomeDashboardPath = dashboards.Key("default_home_dashboard_path").MustString("")
+	cfg.DashboardTrashRetention, err = gtime.ParseDuration(valueAsString(dashboards, "trash_retention", "30d"))
+	if err != nil {
+		return err
+	}
+	cfg.DashboardVersionRetentionCount = dashboards.Key("version_retention_count").MustInt(0)
+	cfg.DashboardVersionRetentionAge, err = gtime.ParseDuration(valueAsString(dashboards, "version_retention_age", "0"))
+	if err != nil {
+		return err
+	}
+	cfg.DashboardBatchMaxDashboards = dashboards.Key("batch_max_dashboards").MustInt(100)
+	cfg.DashboardAuditLog = dashboards.Key("audit_log").MustBool(false)
+	cfg.DashboardDefaultDiffType = valueAsString(dashboards, "default_diff_type", "basic")
+	switch cfg.DashboardDefaultDiffType {
+	case "basic", "json", "delta", "semantic":
+	default:
+		return fmt.Errorf("invalid dashboards default_diff_type %q, must be one of basic, json, delta or semantic", cfg.DashboardDefaultDiffType)
+	}
 
 	if err := readUserSettings(iniFile, cfg); err != nil {
 		return err
@@ -1205,6 +1239,11 @@ func (cfg *Cfg) Load(args CommandLineArgs) error {
 	cfg.Storage = readStorageSettings(iniFile)
 	cfg.Search = readSearchSettings(iniFile)
 
+	cfg.FolderConventions, err = readFolderConventionSettings(iniFile)
+	if err != nil {
+		return err
+	}
+
 	cfg.SecureSocksDSProxy, err = readSecureSocksDSProxySettings(iniFile)
 	if err != nil {
 		// if the proxy is misconfigured, disable it rather than crashing
@@ -0,0 +1,39 @@
+package setting
+
+import (
+	"fmt"
+	"regexp"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// FolderConventionSettings describes the conventions the folder tree of an
+// organization is expected to follow.
+type FolderConventionSettings struct {
+	// MaxDepth is the maximum nesting depth of folders, 0 means unlimited.
+	MaxDepth int
+	// RequiredTopLevelFolders lists the titles of folders that must exist at the root.
+	RequiredTopLevelFolders []string
+	// NamePattern, if set, must match the title of every folder.
+	NamePattern *regexp.Regexp
+}
+
+func readFolderConventionSettings(iniFile *ini.File) (FolderConventionSettings, error) {
+	s := FolderConventionSettings{}
+
+	section := iniFile.Section("folder_conventions")
+	s.MaxDepth = section.Key("max_depth").MustInt(0)
+	s.RequiredTopLevelFolders = util.SplitString(section.Key("required_top_level_folders").MustString(""))
+
+	if pattern := section.Key("name_pattern").MustString(""); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return s, fmt.Errorf("invalid folder_conventions name_pattern %q: %w", pattern, err)
+		}
+		s.NamePattern = re
+	}
+
+	return s, nil
+}